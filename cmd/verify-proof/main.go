@@ -0,0 +1,119 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+)
+
+const (
+	exitPass    = 0
+	exitFail    = 1
+	exitInvalid = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run verifies the proof bundle named in args without a connection to trillian and returns the exit code of the command
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("verify-proof", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	publicKeyFile := flags.String("public-key", "", "PEM or DER public key of the channel map, overriding the key embedded in the bundle")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: verify-proof [-public-key file] bundle.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitInvalid
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitInvalid
+	}
+
+	content, err := ioutil.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "Unable to read the proof bundle: %v\n", err)
+		return exitInvalid
+	}
+	var bundle models.ProofBundleDefinition
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		fmt.Fprintf(stderr, "Unable to parse the proof bundle: %v\n", err)
+		return exitInvalid
+	}
+	var publicKey []byte
+	keySource := "embedded public key"
+	if *publicKeyFile != "" {
+		publicKey, err = ioutil.ReadFile(*publicKeyFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "Unable to read the public key: %v\n", err)
+			return exitInvalid
+		}
+		keySource = "public key from " + *publicKeyFile
+	}
+	verifier, err := client.NewProofVerifier(&bundle, publicKey)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid proof bundle: %v\n", err)
+		return exitInvalid
+	}
+
+	fmt.Fprintf(stdout, "Record %v of channel %v in map %v at revision %v\n", *bundle.RecordID, *bundle.ChannelID, *bundle.MapID, *bundle.Revision)
+	passed := true
+	mapRoot, err := client.VerifyProofRoot(verifier, &bundle)
+	passed = report(stdout, "map root signature ("+keySource+")", err) && passed
+	index := dbom.RecordIndex(*bundle.RecordID)
+	err = nil
+	if !bytes.Equal(index, *bundle.LeafIndex) {
+		err = fmt.Errorf("leaf index %x is not the SHA-256 of the record ID %x", []byte(*bundle.LeafIndex), index)
+	}
+	passed = report(stdout, "leaf index matches the record ID", err) && passed
+	if mapRoot != nil && err == nil {
+		err = client.VerifyProofLeaf(verifier, mapRoot, &bundle, index)
+		passed = report(stdout, "leaf inclusion ("+*bundle.HashStrategy+")", err) && passed
+	} else {
+		fmt.Fprintf(stdout, "  [SKIP] leaf inclusion (%v)\n", *bundle.HashStrategy)
+	}
+
+	if !passed {
+		fmt.Fprintln(stdout, "FAIL")
+		return exitFail
+	}
+	fmt.Fprintln(stdout, "PASS")
+	return exitPass
+}
+
+// report prints the outcome of a check and returns whether it passed
+func report(stdout io.Writer, check string, err error) bool {
+	if err != nil {
+		fmt.Fprintf(stdout, "  [FAIL] %v: %v\n", check, err)
+		return false
+	}
+	fmt.Fprintf(stdout, "  [PASS] %v\n", check)
+	return true
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeBundle(t *testing.T, dir string, bundle *models.ProofBundleDefinition) string {
+	content, err := json.Marshal(bundle)
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "bundle.json")
+	assert.Nil(t, ioutil.WriteFile(path, content, 0600))
+	return path
+}
+
+func testBundle(t *testing.T) *models.ProofBundleDefinition {
+	bundle, err := mock.NewSignedProofBundle(651, "test-channel", "test-record", dbom.RecordIndex("test-record"), []byte("test-value"), 3)
+	assert.Nil(t, err)
+	return bundle
+}
+
+//TestRunPass tests verifying a valid proof bundle
+func TestRunPass(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "verify-proof")
+	defer os.RemoveAll(dir)
+	bundle := testBundle(t)
+	path := writeBundle(t, dir, bundle)
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitPass, run([]string{path}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "PASS")
+	assert.NotContains(t, stdout.String(), "FAIL")
+
+	keyPath := filepath.Join(dir, "key.der")
+	assert.Nil(t, ioutil.WriteFile(keyPath, *bundle.PublicKey, 0600))
+	stdout.Reset()
+	assert.Equal(t, exitPass, run([]string{"-public-key", keyPath, path}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "public key from "+keyPath)
+}
+
+//TestRunFail tests verifying proof bundles that do not match their map root or public key
+func TestRunFail(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "verify-proof")
+	defer os.RemoveAll(dir)
+
+	bundle := testBundle(t)
+	tampered := strfmt.Base64("tampered-value")
+	bundle.LeafValue = &tampered
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitFail, run([]string{writeBundle(t, dir, bundle)}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "[FAIL] leaf inclusion")
+
+	bundle = testBundle(t)
+	recordID := "other-record"
+	bundle.RecordID = &recordID
+	stdout.Reset()
+	assert.Equal(t, exitFail, run([]string{writeBundle(t, dir, bundle)}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "[FAIL] leaf index")
+
+	bundle = testBundle(t)
+	other := testBundle(t)
+	keyPath := filepath.Join(dir, "key.der")
+	assert.Nil(t, ioutil.WriteFile(keyPath, *other.PublicKey, 0600))
+	stdout.Reset()
+	assert.Equal(t, exitFail, run([]string{"-public-key", keyPath, writeBundle(t, dir, bundle)}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "[FAIL] map root signature")
+}
+
+//TestRunInvalid tests arguments and files that are not a proof bundle
+func TestRunInvalid(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "verify-proof")
+	defer os.RemoveAll(dir)
+	var stdout, stderr bytes.Buffer
+
+	assert.Equal(t, exitInvalid, run(nil, &stdout, &stderr))
+	assert.Equal(t, exitInvalid, run([]string{filepath.Join(dir, "missing.json")}, &stdout, &stderr))
+
+	path := filepath.Join(dir, "bundle.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("not json"), 0600))
+	assert.Equal(t, exitInvalid, run([]string{path}, &stdout, &stderr))
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{}"), 0600))
+	assert.Equal(t, exitInvalid, run([]string{path}, &stdout, &stderr))
+
+	path = writeBundle(t, dir, testBundle(t))
+	assert.Equal(t, exitInvalid, run([]string{"-public-key", filepath.Join(dir, "missing.pem"), path}, &stdout, &stderr))
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// ErrInvalidAttachment is returned when the attachment of a commit is missing or does not name the record of the commit
+var ErrInvalidAttachment = errors.New(responses.InvalidAttachment)
+
+// ErrAlreadyAttached is returned when a commit attaches a record that is already attached
+var ErrAlreadyAttached = errors.New(responses.AlreadyAttached)
+
+// ErrNotAttached is returned when a commit detaches a record that is not attached to the parent
+var ErrNotAttached = errors.New(responses.NotAttached)
+
+// AttachmentRecordIDs returns the IDs of the parent and child records of the attachment of a commit, which must name the record of the commit
+func AttachmentRecordIDs(recordDef *models.RecordDefinition) (string, string, error) {
+	attachment := recordDef.Attachment
+	if attachment == nil || attachment.ParentRecordID == nil || attachment.ChildRecordID == nil {
+		return "", "", fmt.Errorf("%w: %v has no attachment", ErrInvalidAttachment, *recordDef.RecordID)
+	}
+	parentID, childID := *attachment.ParentRecordID, *attachment.ChildRecordID
+	if parentID == childID {
+		return "", "", fmt.Errorf("%w: %v cannot be attached to itself", ErrInvalidAttachment, childID)
+	}
+	if *recordDef.RecordID != parentID && *recordDef.RecordID != childID {
+		return "", "", fmt.Errorf("%w: %v is neither the parent nor the child of the attachment", ErrInvalidAttachment, *recordDef.RecordID)
+	}
+	return parentID, childID, nil
+}
+
+// CheckAttachment validates attaching the child record to a parent record, or detaching it if attach is not set.
+// A record is attached to at most one parent at a time
+func CheckAttachment(parentID string, childID string, child *models.Record, attach bool) error {
+	if attach && child.ParentRecordID != "" {
+		return fmt.Errorf("%w: %v is attached to %v", ErrAlreadyAttached, childID, child.ParentRecordID)
+	}
+	if !attach && child.ParentRecordID != parentID {
+		return fmt.Errorf("%w: %v is not attached to %v", ErrNotAttached, childID, parentID)
+	}
+	return nil
+}
+
+// AttachRecord stages new revisions of the parent and child of the attachment of a commit, recording the child on the parent and the parent on the child,
+// with the attachment in the parents the child is or was attached to. The record of the commit gets the payload of the commit and the other record keeps its payload
+func AttachRecord(ctx context.Context, batch *Batch, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+	recordLogger.Info().Msg("[DBoM:AttachRecord] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:AttachRecord")
+	err := relateRecords(ctx, batch, channelID, commitType, recordDef, true)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+	recordLogger.Info().Msg("[DBoM:AttachRecord] Finished")
+	span.Finish()
+	return nil
+}
+
+// DetachRecord stages new revisions of the parent and child of the attachment of a commit, removing the child from the parent and the parent from the child,
+// and closes the attachment in the parents the child is or was attached to. The record of the commit gets the payload of the commit and the other record keeps its payload
+func DetachRecord(ctx context.Context, batch *Batch, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+	recordLogger.Info().Msg("[DBoM:DetachRecord] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:DetachRecord")
+	err := relateRecords(ctx, batch, channelID, commitType, recordDef, false)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+	recordLogger.Info().Msg("[DBoM:DetachRecord] Finished")
+	span.Finish()
+	return nil
+}
+
+// relateRecords stages the parent and child of an attachment once the attachment is checked against their revisions the batch follows
+func relateRecords(ctx context.Context, batch *Batch, channelID string, commitType string, recordDef *models.RecordDefinition, attach bool) error {
+	parentID, childID, err := AttachmentRecordIDs(recordDef)
+	if err != nil {
+		return err
+	}
+	parent, err := batchRecord(ctx, batch, parentID)
+	if err != nil {
+		return err
+	}
+	child, err := batchRecord(ctx, batch, childID)
+	if err != nil {
+		return err
+	}
+	if parent == nil || child == nil {
+		return fmt.Errorf("%v: %v or %v", responses.ResourceNotFound, parentID, childID)
+	}
+	if err := CheckAttachment(parentID, childID, child, attach); err != nil {
+		return err
+	}
+
+	parentPayload, childPayload := parent.Payload, child.Payload
+	if *recordDef.RecordID == parentID {
+		parentPayload = recordDef
+	} else {
+		childPayload = recordDef
+	}
+	newParent := newRecord(batch, parent, parent.Revision, channelID, commitType, parentID, parentPayload)
+	newChild := newRecord(batch, child, child.Revision, channelID, commitType, childID, childPayload)
+	if attach {
+		newParent.ChildRecordIDs = append(append([]string(nil), parent.ChildRecordIDs...), childID)
+		newChild.ParentRecordID = parentID
+	} else {
+		newParent.ChildRecordIDs = nil
+		for _, id := range parent.ChildRecordIDs {
+			if id != childID {
+				newParent.ChildRecordIDs = append(newParent.ChildRecordIDs, id)
+			}
+		}
+		newChild.ParentRecordID = ""
+	}
+
+	if err := stageRecord(ctx, batch, newParent); err != nil {
+		return err
+	}
+	if err := stageRecord(ctx, batch, newChild); err != nil {
+		return err
+	}
+	whereUsed, err := whereUsedLeaf(ctx, batch, parentID, childID, attach)
+	if err != nil {
+		return err
+	}
+	batch.Set(whereUsed)
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+
+	"github.com/google/trillian"
+	"github.com/stretchr/testify/assert"
+)
+
+func attachment(recordID string, parentID string, childID string) *models.RecordDefinition {
+	return &models.RecordDefinition{
+		RecordID:        &recordID,
+		RecordIDPayload: map[string]interface{}{"part": recordID},
+		Attachment:      &models.AttachmentDefinition{ParentRecordID: &parentID, ChildRecordID: &childID},
+	}
+}
+
+// relate commits an ATTACH or DETACH of two records to an in-memory map through a coordinator
+func relate(t *testing.T, fake *mock.StatefulMapMock, commitType string, recordDef *models.RecordDefinition) error {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	coordinator := NewCoordinator(0, time.Millisecond, 100)
+	stage := AttachRecord
+	if commitType == "DETACH" {
+		stage = DetachRecord
+	}
+	indexes := [][]byte{RecordIndex(*recordDef.Attachment.ParentRecordID), RecordIndex(*recordDef.Attachment.ChildRecordID)}
+	return coordinator.Stage(context.Background(), "channel:test-channel", statefulMapWriter(fake, tracer), indexes, func(ctx context.Context, batch *Batch) error {
+		return stage(ctx, batch, "test-channel", commitType, recordDef, tracer)
+	}, tracer)
+}
+
+func latestRecord(t *testing.T, fake *mock.StatefulMapMock, recordID string) *models.Record {
+	leaves, err := fake.GetLeavesByRevision(context.Background(), &trillian.GetMapLeavesByRevisionRequest{Index: [][]byte{RecordIndex(recordID)}, Revision: fake.Revision()})
+	assert.Nil(t, err)
+	var record models.Record
+	assert.Nil(t, record.UnmarshalBinary(leaves.Leaves[0].LeafValue))
+	return &record
+}
+
+//TestAttachmentRecordIDs tests validating the attachment of a commit
+func TestAttachmentRecordIDs(t *testing.T) {
+	parentID, childID, err := AttachmentRecordIDs(attachment("child", "parent", "child"))
+	assert.Nil(t, err)
+	assert.Equal(t, "parent", parentID)
+	assert.Equal(t, "child", childID)
+
+	recordID := "child"
+	_, _, err = AttachmentRecordIDs(&models.RecordDefinition{RecordID: &recordID})
+	assert.True(t, errors.Is(err, ErrInvalidAttachment))
+	_, _, err = AttachmentRecordIDs(attachment("child", "child", "child"))
+	assert.True(t, errors.Is(err, ErrInvalidAttachment))
+	_, _, err = AttachmentRecordIDs(attachment("other", "parent", "child"))
+	assert.True(t, errors.Is(err, ErrInvalidAttachment))
+}
+
+//TestAttachRecord tests attaching records to a parent, keeping the payload of the record that is not committed
+func TestAttachRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	commitRecords(t, fake, "CREATE", 0, "parent", "child-1", "child-2")
+
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("child-1", "parent", "child-1")))
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("parent", "parent", "child-2")))
+
+	parent := latestRecord(t, fake, "parent")
+	assert.Equal(t, []string{"child-1", "child-2"}, parent.ChildRecordIDs)
+	assert.Equal(t, "ATTACH", *parent.EventType)
+	assert.Equal(t, int64(5), parent.Revision)
+	child := latestRecord(t, fake, "child-1")
+	assert.Equal(t, "parent", child.ParentRecordID)
+	assert.Equal(t, int64(4), child.Revision)
+	assert.NotNil(t, child.Payload)
+
+	err := relate(t, fake, "ATTACH", attachment("child-1", "child-2", "child-1"))
+	assert.True(t, errors.Is(err, ErrAlreadyAttached))
+}
+
+//TestAttachRecordsInOneBatch tests attaching a record created in the same batch and another record to one parent, which is staged once
+func TestAttachRecordsInOneBatch(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	commitRecords(t, fake, "CREATE", 0, "parent", "child-1")
+
+	writer := statefulMapWriter(fake, tracer)
+	batch := &Batch{revision: fake.Revision() + 1, read: writer.Read}
+	childID := "child-2"
+	assert.Nil(t, CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &childID}, tracer))
+	assert.Nil(t, AttachRecord(ctx, batch, "test-channel", "ATTACH", attachment("parent", "parent", "child-2"), tracer))
+	assert.Nil(t, AttachRecord(ctx, batch, "test-channel", "ATTACH", attachment("child-1", "parent", "child-1"), tracer))
+	assert.Nil(t, writer.Write(ctx, batch.Leaves(), batch.Revision()))
+
+	parent := latestRecord(t, fake, "parent")
+	assert.Equal(t, []string{"child-2", "child-1"}, parent.ChildRecordIDs)
+	assert.Equal(t, int64(1), parent.PreviousRevision)
+	assert.Equal(t, int64(2), *parent.HistoryLength)
+	child := latestRecord(t, fake, "child-2")
+	assert.Equal(t, "parent", child.ParentRecordID)
+	assert.Equal(t, int64(0), child.PreviousRevision)
+	assert.Equal(t, int64(1), *child.HistoryLength)
+
+	history, err := GetRecordHistory(ctx, nil, "parent", -1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, int64(1), history[1].Revision)
+}
+
+//TestUpdateAttachedRecord tests that updating an attached record keeps its parent and children
+func TestUpdateAttachedRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	commitRecords(t, fake, "CREATE", 0, "parent", "child")
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("child", "parent", "child")))
+
+	commitRecords(t, fake, "UPDATE", 2, "child", "parent")
+	assert.Equal(t, "parent", latestRecord(t, fake, "child").ParentRecordID)
+	assert.Equal(t, []string{"child"}, latestRecord(t, fake, "parent").ChildRecordIDs)
+}
+
+//TestDetachRecord tests detaching a record from its parent
+func TestDetachRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	commitRecords(t, fake, "CREATE", 0, "parent", "child-1", "child-2")
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("child-1", "parent", "child-1")))
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("child-2", "parent", "child-2")))
+
+	assert.Nil(t, relate(t, fake, "DETACH", attachment("child-1", "parent", "child-1")))
+	assert.Equal(t, []string{"child-2"}, latestRecord(t, fake, "parent").ChildRecordIDs)
+	assert.Equal(t, "", latestRecord(t, fake, "child-1").ParentRecordID)
+
+	err := relate(t, fake, "DETACH", attachment("child-1", "parent", "child-1"))
+	assert.True(t, errors.Is(err, ErrNotAttached))
+	err = relate(t, fake, "ATTACH", attachment("child-1", "parent", "missing"))
+	assert.Error(t, err)
+}
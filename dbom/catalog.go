@@ -0,0 +1,249 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+)
+
+// CatalogPageSize is the number of records appended to a page of the record catalog of a channel before a new page is started
+var CatalogPageSize = 100
+
+// ErrInvalidCursor is returned when a cursor to list records from cannot be decoded
+var ErrInvalidCursor = errors.New(responses.InvalidCursor)
+
+// RecordCatalogIndex returns the index of the leaf holding the number of records in each page of the record catalog of a channel
+func RecordCatalogIndex() []byte {
+	return listIndex(-1)
+}
+
+// RecordCatalogPageIndex returns the index of the leaf holding a page of the record catalog of a channel
+func RecordCatalogPageIndex(page int64) []byte {
+	return listIndex(page)
+}
+
+// catalogRecord sets the catalog page of a record, kept from its previous revision, and returns the leaves of the record catalog of its channel holding the summary of the record, to be staged with it.
+// A record keeps its place in the catalog across commits; records last committed before the catalog are appended to it
+func catalogRecord(ctx context.Context, batch *Batch, record *models.Record) ([]*trillian.MapLeaf, error) {
+	revision := record.Revision
+	summary := &models.RecordSummaryDefinition{
+		RecordID:    record.ResourceID,
+		EventType:   record.EventType,
+		LastChanged: record.Timestamp,
+		Revision:    &revision,
+	}
+
+	page := record.CatalogPage
+	var catalog models.RecordCatalog
+	if err := readBatchLeaf(ctx, batch, RecordCatalogIndex(), &catalog); err != nil {
+		return nil, err
+	}
+	if page != nil && *page < int64(len(catalog.Pages)) {
+		var pageContent models.RecordCatalogPage
+		if err := readBatchLeaf(ctx, batch, RecordCatalogPageIndex(*page), &pageContent); err != nil {
+			return nil, err
+		}
+		for i, entry := range pageContent.Records {
+			if *entry.RecordID == *record.ResourceID {
+				pageContent.Records[i] = summary
+				record.CatalogPage = page
+				return catalogLeaves(nil, *page, &pageContent)
+			}
+		}
+	}
+
+	last := int64(len(catalog.Pages)) - 1
+	var pageContent models.RecordCatalogPage
+	if last >= 0 && catalog.Pages[last] < int64(CatalogPageSize) {
+		if err := readBatchLeaf(ctx, batch, RecordCatalogPageIndex(last), &pageContent); err != nil {
+			return nil, err
+		}
+	} else {
+		last++
+		catalog.Pages = append(catalog.Pages, 0)
+	}
+	pageContent.Records = append(pageContent.Records, summary)
+	catalog.Pages[last] = int64(len(pageContent.Records))
+	record.CatalogPage = &last
+	return catalogLeaves(&catalog, last, &pageContent)
+}
+
+// readBatchLeaf reads the value of a leaf as staged in a batch into value, leaving value empty if the leaf is empty
+func readBatchLeaf(ctx context.Context, batch *Batch, index []byte, value interface{ UnmarshalBinary([]byte) error }) error {
+	leafValue, err := batch.Leaf(ctx, index)
+	if err != nil || len(leafValue) == 0 {
+		return err
+	}
+	return value.UnmarshalBinary(leafValue)
+}
+
+// catalogLeaves returns the leaves holding a page of the record catalog and, if it changed, the number of records in each page
+func catalogLeaves(catalog *models.RecordCatalog, page int64, pageContent *models.RecordCatalogPage) ([]*trillian.MapLeaf, error) {
+	var leaves []*trillian.MapLeaf
+	if catalog != nil {
+		catalogValue, err := catalog.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, &trillian.MapLeaf{Index: RecordCatalogIndex(), LeafValue: catalogValue})
+	}
+	pageValue, err := pageContent.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(leaves, &trillian.MapLeaf{Index: RecordCatalogPageIndex(page), LeafValue: pageValue}), nil
+}
+
+// ListRecords reads a page of the record catalog of a channel, keeping the records whose last commit matches eventType and was made in [changedSince, changedBefore).
+// Filters left empty match every record. The first page is read at the latest revision of the channel map and the cursor it returns pins the following pages to that revision
+func ListRecords(ctx context.Context, client *client.MapClient, cursor string, limit int64, eventType string, changedSince *strfmt.DateTime, changedBefore *strfmt.DateTime, tracer opentracing.Tracer) (*models.RecordListResponseDefinition, error) {
+	recordLogger.Info().Msg("[DBoM:ListRecords] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:ListRecords")
+	var revision int64 = -1
+	var position int64
+	if cursor != "" {
+		var err error
+		revision, position, err = decodeCursor(cursor)
+		if err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InvalidCursor)
+			return nil, err
+		}
+	}
+
+	inclusions, mapRoot, err := readLeaves(ctx, client, [][]byte{RecordCatalogIndex()}, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	var catalog models.RecordCatalog
+	if value := inclusions[0].GetLeaf().GetLeafValue(); len(value) > 0 {
+		if err := catalog.UnmarshalBinary(value); err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+	}
+	mapRevision := int64(mapRoot.Revision)
+
+	var total int64
+	var pages []int64
+	var pageStarts []int64
+	for page, count := range catalog.Pages {
+		if total+count > position {
+			pages = append(pages, int64(page))
+			pageStarts = append(pageStarts, total)
+		}
+		total += count
+	}
+
+	records := make([]*models.RecordSummaryDefinition, 0)
+	chunkSize := int(limit)/CatalogPageSize + 1
+	for start := 0; start < len(pages) && int64(len(records)) < limit; start += chunkSize {
+		end := start + chunkSize
+		if end > len(pages) {
+			end = len(pages)
+		}
+		indexes := make([][]byte, end-start)
+		for i, page := range pages[start:end] {
+			indexes[i] = RecordCatalogPageIndex(page)
+		}
+		inclusions, _, err := readLeaves(ctx, client, indexes, mapRevision, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+		for i, inclusion := range inclusions {
+			var pageContent models.RecordCatalogPage
+			if value := inclusion.GetLeaf().GetLeafValue(); len(value) > 0 {
+				if err := pageContent.UnmarshalBinary(value); err != nil {
+					tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+					return nil, err
+				}
+			}
+			for j, entry := range pageContent.Records {
+				entryPosition := pageStarts[start+i] + int64(j)
+				if entryPosition < position || int64(len(records)) >= limit {
+					continue
+				}
+				position = entryPosition + 1
+				if matchesRecord(entry, eventType, changedSince, changedBefore) {
+					records = append(records, entry)
+				}
+			}
+		}
+	}
+
+	result := models.RecordListResponseDefinition{
+		Records:  records,
+		Revision: &mapRevision,
+	}
+	if position < total {
+		result.NextCursor = encodeCursor(mapRevision, position)
+	}
+	recordLogger.Debug().Msgf("Listed %v records at revision %v", len(records), mapRevision)
+	recordLogger.Info().Msg("[DBoM:ListRecords] Finished")
+	span.Finish()
+	return &result, nil
+}
+
+// matchesRecord reports whether the last commit of a record matches the filters of a listing
+func matchesRecord(entry *models.RecordSummaryDefinition, eventType string, changedSince *strfmt.DateTime, changedBefore *strfmt.DateTime) bool {
+	if eventType != "" && *entry.EventType != eventType {
+		return false
+	}
+	lastChanged := time.Time(*entry.LastChanged)
+	if changedSince != nil && lastChanged.Before(time.Time(*changedSince)) {
+		return false
+	}
+	if changedBefore != nil && !lastChanged.Before(time.Time(*changedBefore)) {
+		return false
+	}
+	return true
+}
+
+// encodeCursor encodes the revision a listing is read at and the position of the next record to read
+func encodeCursor(revision int64, position int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", revision, position)))
+}
+
+// decodeCursor decodes the revision and position encoded in a cursor
+func decodeCursor(cursor string) (int64, int64, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+	var revision, position int64
+	if n, err := fmt.Sscanf(string(decoded), "%d:%d", &revision, &position); err != nil || n != 2 || revision < 1 || position < 0 {
+		return 0, 0, ErrInvalidCursor
+	}
+	if encodeCursor(revision, position) != cursor {
+		return 0, 0, ErrInvalidCursor
+	}
+	return revision, position, nil
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+// commitRecords commits records to an in-memory map through a coordinator, one commit type per record
+func commitRecords(t *testing.T, fake *mock.StatefulMapMock, commitType string, prevRevision int64, recordIDs ...string) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	coordinator := NewCoordinator(0, time.Millisecond, 100)
+	for _, recordID := range recordIDs {
+		recordID := recordID
+		err := coordinator.Stage(context.Background(), "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+			return CreateRecord(ctx, batch, prevRevision, "test-channel", commitType, &models.RecordDefinition{RecordID: &recordID}, tracer)
+		}, tracer)
+		assert.Nil(t, err)
+	}
+}
+
+//TestCatalogBatchedRecords tests that records committed in one batch are all added to the catalog
+func TestCatalogBatchedRecords(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	batch := &Batch{revision: 1}
+	for i := 0; i < 5; i++ {
+		recordID := fmt.Sprintf("record-%v", i)
+		assert.Nil(t, CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID}, tracer))
+	}
+	assert.Equal(t, 5+5+1+3, len(batch.Leaves()))
+
+	var catalog models.RecordCatalog
+	assert.Nil(t, readBatchLeaf(ctx, batch, RecordCatalogIndex(), &catalog))
+	assert.Equal(t, []int64{2, 2, 1}, catalog.Pages)
+}
+
+//TestListRecords tests paging through the catalog with a cursor, keeping records in the order they were created
+func TestListRecords(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	commitRecords(t, fake, "CREATE", 0, "record-0", "record-1", "record-2", "record-3", "record-4")
+	commitRecords(t, fake, "UPDATE", 1, "record-1")
+
+	result, err := ListRecords(ctx, nil, "", 3, "", nil, nil, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), *result.Revision)
+	assert.Equal(t, 3, len(result.Records))
+	assert.Equal(t, "record-1", *result.Records[1].RecordID)
+	assert.Equal(t, "UPDATE", *result.Records[1].EventType)
+	assert.Equal(t, int64(6), *result.Records[1].Revision)
+	assert.NotEmpty(t, result.NextCursor)
+
+	commitRecords(t, fake, "CREATE", 0, "record-5")
+	result, err = ListRecords(ctx, nil, result.NextCursor, 3, "", nil, nil, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), *result.Revision)
+	assert.Equal(t, 2, len(result.Records))
+	assert.Equal(t, "record-3", *result.Records[0].RecordID)
+	assert.Equal(t, "record-4", *result.Records[1].RecordID)
+	assert.Empty(t, result.NextCursor)
+}
+
+//TestListRecordsFilters tests listing the records matching the type and time of their last commit
+func TestListRecordsFilters(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	commitRecords(t, fake, "CREATE", 0, "record-0", "record-1", "record-2", "record-3")
+	changed := strfmt.DateTime(time.Now())
+	commitRecords(t, fake, "UPDATE", 1, "record-0", "record-3")
+
+	result, err := ListRecords(ctx, nil, "", 1, "UPDATE", nil, nil, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(result.Records))
+	assert.Equal(t, "record-0", *result.Records[0].RecordID)
+	assert.NotEmpty(t, result.NextCursor)
+
+	result, err = ListRecords(ctx, nil, result.NextCursor, 1, "UPDATE", nil, nil, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, "record-3", *result.Records[0].RecordID)
+	assert.Empty(t, result.NextCursor)
+
+	result, err = ListRecords(ctx, nil, "", 10, "", nil, &changed, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(result.Records))
+	assert.Equal(t, "record-1", *result.Records[0].RecordID)
+
+	result, err = ListRecords(ctx, nil, "", 10, "", &changed, nil, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(result.Records))
+	assert.Equal(t, "record-3", *result.Records[1].RecordID)
+}
+
+//TestListRecordsEmpty tests listing a channel without records
+func TestListRecordsEmpty(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ListRecords(context.Background(), nil, "", 10, "", nil, nil, tracer)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Records)
+	assert.Empty(t, result.NextCursor)
+}
+
+//TestListRecordsInvalidCursor tests cursors that cannot be decoded
+func TestListRecordsInvalidCursor(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	for _, cursor := range []string{"!", encodeCursor(0, 1), "YWJj", encodeCursor(1, 1) + "="} {
+		_, err := ListRecords(context.Background(), nil, cursor, 10, "", nil, nil, tracer)
+		assert.Equal(t, ErrInvalidCursor, err, cursor)
+	}
+}
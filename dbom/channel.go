@@ -27,6 +27,7 @@ import (
 	"trillian-agent/tracing"
 	client "trillian-agent/trillian"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/golang/protobuf/ptypes"
 	tclient "github.com/google/trillian/client"
 	"github.com/google/trillian/crypto/keyspb"
@@ -41,12 +42,20 @@ var channelLogger = logger.GetLogger("DBoM:Channel")
 var add = (*client.Client).Add
 var getByRevision = (*client.MapClient).GetByRevision
 var get = (*client.MapClient).Get
+var getProof = (*client.MapClient).GetProof
 var getCurrentRevision = (*client.MapClient).GetCurrentRevision
 
-// CreateChannel creates a channel and writes it to trillian
-func CreateChannel(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, trillMapWriteClient trillian.TrillianMapWriteClient, revision int64, channelMapID int64, channelID string, tracer opentracing.Tracer) (int64, error) {
-	channelLogger.Info().Msg("[DBoM:CreateChannel] Entered")
-	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:CreateChannel")
+// ChannelIndex returns the index of the leaf holding a channel in the channel config map
+func ChannelIndex(channelID string) []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte(channelID))
+	return hasher.Sum(nil)
+}
+
+// CreateChannelMap creates and initializes the map of a new channel. The map is deleted again if it cannot be initialized
+func CreateChannelMap(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channelMapID int64, channelID string, tracer opentracing.Tracer) (int64, error) {
+	channelLogger.Info().Msg("[DBoM:CreateChannelMap] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:CreateChannelMap")
 	ctr := trillian.CreateTreeRequest{Tree: &trillian.Tree{
 		TreeId:             channelMapID,
 		TreeState:          trillian.TreeState(trillian.TreeState_ACTIVE),
@@ -73,48 +82,78 @@ func CreateChannel(ctx context.Context, trillAdminClient trillian.TrillianAdminC
 	_, err = trillMapClient.InitMap(ctx, &req)
 	if err != nil {
 		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if deleteErr := DeleteChannelMap(ctx, trillAdminClient, tree.TreeId, tracer); deleteErr != nil {
+			channelLogger.Err(deleteErr).Msgf("Unable to delete the map of channel %v", channelID)
+		}
 		return -1, err
 	}
+
+	channelLogger.Info().Msg("[DBoM:CreateChannelMap] Finished")
+	span.Finish()
+	return tree.TreeId, nil
+}
+
+// DeleteChannelMap deletes the map of a channel that was never written to the channel config map
+func DeleteChannelMap(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, mapID int64, tracer opentracing.Tracer) error {
+	channelLogger.Info().Msg("[DBoM:DeleteChannelMap] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:DeleteChannelMap")
+	_, err := trillAdminClient.DeleteTree(ctx, &trillian.DeleteTreeRequest{TreeId: mapID})
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	channelLogger.Info().Msg("[DBoM:DeleteChannelMap] Finished")
+	span.Finish()
+	return nil
+}
+
+// CreateChannel writes a channel using an existing map to trillian, appending it to the channel registry in the same revision
+func CreateChannel(ctx context.Context, trillMapWriteClient trillian.TrillianMapWriteClient, channelMapClient *client.MapClient, revision int64, channelMapID int64, channelID string, mapID int64, tracer opentracing.Tracer) error {
+	channelLogger.Info().Msg("[DBoM:CreateChannel] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:CreateChannel")
+	registryPage, registryLeaves, err := RegisterChannel(ctx, channelMapClient, channelID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
 	var client = client.NewClient(trillMapWriteClient, channelMapID)
 	channel := models.Channel{
-		ChannelID: channelID,
-		MapID:     tree.TreeId,
+		ChannelID:    channelID,
+		MapID:        mapID,
+		RegistryPage: &registryPage,
 	}
 
-	leaves := make([]*trillian.MapLeaf, 1)
-	hasher := sha256.New()
-	hasher.Write([]byte(channel.ChannelID))
-	index := hasher.Sum(nil)
+	leaves := make([]*trillian.MapLeaf, 1, 1+len(registryLeaves))
+	index := ChannelIndex(channel.ChannelID)
 	val, err := channel.MarshalBinary()
 	if err != nil {
 		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
-		return -1, err
+		return err
 	}
 	leaf := &trillian.MapLeaf{
 		Index:     index,
 		LeafValue: val,
 	}
 	leaves[0] = leaf
+	leaves = append(leaves, registryLeaves...)
 	err = add(client, ctx, leaves, revision, tracer)
 	if err != nil {
 		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
-		return -1, err
+		return err
 	}
 
 	channelLogger.Info().Msg("[DBoM:CreateChannel] Finished")
 	span.Finish()
-	return tree.TreeId, nil
+	return nil
 }
 
 // GetChannel gets a channel from trillian
 func GetChannel(ctx context.Context, client *client.MapClient, channelID string, tracer opentracing.Tracer) (*models.Channel, error) {
 	channelLogger.Info().Msg("[DBoM:GetChannel] Entered")
 	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetChannel")
-	hasher := sha256.New()
-	hasher.Write([]byte(channelID))
-	index := hasher.Sum(nil)
 	indexes := [][]byte{
-		index,
+		ChannelIndex(channelID),
 	}
 	inclusions, mapRoot, err := get(client, ctx, indexes, tracer)
 	if err != nil {
@@ -141,10 +180,10 @@ func GetChannel(ctx context.Context, client *client.MapClient, channelID string,
 	return &result, nil
 }
 
-// GetChannelClient gets a channel client
-func GetChannelClient(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channelMapID int64, tracer opentracing.Tracer) (*tclient.MapClient, error) {
-	channelLogger.Info().Msg("[DBoM:GetChannelClient] Entered")
-	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetChannelClient")
+// GetChannelTree gets the tree of a channel map, holding its public key and hash strategy
+func GetChannelTree(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, channelMapID int64, tracer opentracing.Tracer) (*trillian.Tree, error) {
+	channelLogger.Info().Msg("[DBoM:GetChannelTree] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetChannelTree")
 	rqst := &trillian.GetTreeRequest{
 		TreeId: channelMapID,
 	}
@@ -154,7 +193,132 @@ func GetChannelClient(ctx context.Context, trillAdminClient trillian.TrillianAdm
 		tracing.LogAndTraceErr(channelLogger, span, treeError, responses.InternalError)
 		return nil, treeError
 	}
+	channelLogger.Info().Msg("[DBoM:GetChannelTree] Finished")
+	span.Finish()
+	return channelTree, nil
+}
+
+// GetChannelClient gets a channel client
+func GetChannelClient(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channelMapID int64, tracer opentracing.Tracer) (*tclient.MapClient, error) {
+	channelLogger.Info().Msg("[DBoM:GetChannelClient] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetChannelClient")
+	channelTree, treeError := GetChannelTree(ctx, trillAdminClient, channelMapID, tracer)
+	if treeError != nil {
+		tracing.LogAndTraceErr(channelLogger, span, treeError, responses.InternalError)
+		return nil, treeError
+	}
 	channelLogger.Info().Msg("[DBoM:GetChannelClient] Finished")
 	span.Finish()
 	return tclient.NewMapClientFromTree(trillMapClient, channelTree)
 }
+
+// DescribeChannel gets the state of the map of a channel and its current revision
+func DescribeChannel(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channel *models.Channel, tracer opentracing.Tracer) (*models.ChannelResponseDefinition, error) {
+	channelLogger.Info().Msg("[DBoM:DescribeChannel] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:DescribeChannel")
+	tree, err := GetChannelTree(ctx, trillAdminClient, channel.MapID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	mapClientTree, err := tclient.NewMapClientFromTree(trillMapClient, tree)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	revision, err := getCurrentRevision(&client.MapClient{MapClient: mapClientTree}, ctx, channel.MapID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	result := channelResponse(channel, tree)
+	result.Revision = int64(revision)
+
+	channelLogger.Info().Msg("[DBoM:DescribeChannel] Finished")
+	span.Finish()
+	return result, nil
+}
+
+// DeleteChannel soft deletes the map of a channel and removes the channel, the versions of its schema and its registry entry from the channel config map.
+// Deleting a channel is permanent: the map is only restored if the channel cannot be removed, so that a channel is never left without its map,
+// and a channel created again with the same ID starts without records nor schema
+func DeleteChannel(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapWriteClient trillian.TrillianMapWriteClient, channelMapClient *client.MapClient, revision int64, channelMapID int64, channel *models.Channel, tracer opentracing.Tracer) (*models.ChannelResponseDefinition, error) {
+	channelLogger.Info().Msg("[DBoM:DeleteChannel] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:DeleteChannel")
+	var registryLeaves []*trillian.MapLeaf
+	if channel.RegistryPage != nil {
+		var err error
+		registryLeaves, err = UnregisterChannel(ctx, channelMapClient, channel.ChannelID, *channel.RegistryPage, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+	}
+	tree, err := trillAdminClient.DeleteTree(ctx, &trillian.DeleteTreeRequest{TreeId: channel.MapID})
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+
+	leaves := []*trillian.MapLeaf{
+		{Index: ChannelIndex(channel.ChannelID)},
+	}
+	leaves = append(leaves, clearedSchemaLeaves(channel)...)
+	leaves = append(leaves, registryLeaves...)
+	err = add(client.NewClient(trillMapWriteClient, channelMapID), ctx, leaves, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if _, undeleteErr := trillAdminClient.UndeleteTree(ctx, &trillian.UndeleteTreeRequest{TreeId: channel.MapID}); undeleteErr != nil {
+			channelLogger.Err(undeleteErr).Msgf("Unable to restore the map of channel %v", channel.ChannelID)
+		}
+		return nil, err
+	}
+
+	channelLogger.Info().Msg("[DBoM:DeleteChannel] Finished")
+	span.Finish()
+	return channelResponse(channel, tree), nil
+}
+
+// UpdateChannel writes a new version of a channel to the channel config map, keeping the map and registry page of the channel
+func UpdateChannel(ctx context.Context, trillMapWriteClient trillian.TrillianMapWriteClient, revision int64, channelMapID int64, channel *models.Channel, tracer opentracing.Tracer) error {
+	channelLogger.Info().Msg("[DBoM:UpdateChannel] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:UpdateChannel")
+	val, err := channel.MarshalBinary()
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
+	leaves := []*trillian.MapLeaf{
+		{Index: ChannelIndex(channel.ChannelID), LeafValue: val},
+	}
+	err = add(client.NewClient(trillMapWriteClient, channelMapID), ctx, leaves, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	channelLogger.Info().Msg("[DBoM:UpdateChannel] Finished")
+	span.Finish()
+	return nil
+}
+
+// channelResponse describes a channel from the tree of its map
+func channelResponse(channel *models.Channel, tree *trillian.Tree) *models.ChannelResponseDefinition {
+	channelID := channel.ChannelID
+	mapID := channel.MapID
+	treeState := tree.GetTreeState().String()
+	deleted := tree.GetDeleted()
+	result := models.ChannelResponseDefinition{
+		ChannelID: &channelID,
+		MapID:     &mapID,
+		TreeState: &treeState,
+		Deleted:   &deleted,
+	}
+	if createTime, err := ptypes.Timestamp(tree.GetCreateTime()); err == nil {
+		result.CreateTime = strfmt.DateTime(createTime)
+	}
+	if deleteTime, err := ptypes.Timestamp(tree.GetDeleteTime()); err == nil && deleted {
+		result.DeleteTime = strfmt.DateTime(deleteTime)
+	}
+	return &result
+}
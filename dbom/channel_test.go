@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 	"trillian-agent/mock"
 	"trillian-agent/models"
 	"trillian-agent/tracing"
@@ -37,54 +38,64 @@ import (
 
 // TestCreate tests a successful channel create
 func TestCreate(t *testing.T) {
-	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
-	defer conn.Close()
 	tracer, _, _ := tracing.SetupGlobalTracer()
 	ctx := context.Background()
 
 	add = addMock
-	assert.Equal(t, true, true)
-
-	CreateChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, false), nil, 1, 1, "testChannel", tracer)
+	get = getNoResMock
+	err := CreateChannel(ctx, nil, nil, 1, 1, "testChannel", 3513, tracer)
+	assert.Nil(t, err)
 }
 
 //TestCreateError tests a error during channel creation
 func TestCreateError(t *testing.T) {
-	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
-	defer conn.Close()
 	tracer, _, _ := tracing.SetupGlobalTracer()
 	ctx := context.Background()
 
 	add = addErrorMock
-	assert.Equal(t, true, true)
-	_, err := CreateChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, false), nil, 1, 1, "testChannel", tracer)
+	get = getNoResMock
+	err := CreateChannel(ctx, nil, nil, 1, 1, "testChannel", 3513, tracer)
 	assert.Error(t, err)
 }
 
+//TestCreateChannelMap tests creating and initializing the map of a channel
+func TestCreateChannelMap(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	mock.DeletedTrees()
+
+	mapID, err := CreateChannelMap(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, false), 1, "testChannel", tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3513), mapID)
+	assert.Empty(t, mock.DeletedTrees())
+}
+
 //TestCreateErrorCreateTree tests a error during tree creation
 func TestCreateErrorCreateTree(t *testing.T) {
 	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
 	defer conn.Close()
 	tracer, _, _ := tracing.SetupGlobalTracer()
 	ctx := context.Background()
+	mock.DeletedTrees()
 
-	add = addMock
-	assert.Equal(t, true, true)
-	_, err := CreateChannel(ctx, mock.NewTrillianAdminMockClient(conn, true, false), mock.NewTrillianMapMockClient(conn, false, false, false), nil, 1, 1, "testChannel", tracer)
+	_, err := CreateChannelMap(ctx, mock.NewTrillianAdminMockClient(conn, true, false), mock.NewTrillianMapMockClient(conn, false, false, false), 1, "testChannel", tracer)
 	assert.Error(t, err)
+	assert.Empty(t, mock.DeletedTrees())
 }
 
-//TestCreateErrorInitMap tests a error during map initialization
+//TestCreateErrorInitMap tests that a map which cannot be initialized is deleted again
 func TestCreateErrorInitMap(t *testing.T) {
 	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
 	defer conn.Close()
 	tracer, _, _ := tracing.SetupGlobalTracer()
 	ctx := context.Background()
+	mock.DeletedTrees()
 
-	add = addMock
-	assert.Equal(t, true, true)
-	_, err := CreateChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, true), nil, 1, 1, "testChannel", tracer)
+	_, err := CreateChannelMap(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, true), 1, "testChannel", tracer)
 	assert.Error(t, err)
+	assert.Equal(t, []int64{3513}, mock.DeletedTrees())
 }
 
 //TestGet tests getting a channel successfully
@@ -176,6 +187,130 @@ func TestGetChannelClientError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+//TestDescribeChannel tests describing the map of a channel successfully
+func TestDescribeChannel(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	getCurrentRevision = getCurrentRevisionMock
+	defer func() { getCurrentRevision = (*client.MapClient).GetCurrentRevision }()
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654}
+	result, err := DescribeChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, false), channel, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-channel", *result.ChannelID)
+	assert.Equal(t, int64(1654), *result.MapID)
+	assert.Equal(t, int64(12), result.Revision)
+	assert.Equal(t, "ACTIVE", *result.TreeState)
+	assert.False(t, *result.Deleted)
+	assert.False(t, time.Time(result.CreateTime).IsZero())
+}
+
+//TestDescribeChannelError tests errors when describing the map of a channel
+func TestDescribeChannelError(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	getCurrentRevision = getCurrentRevisionMock
+	defer func() { getCurrentRevision = (*client.MapClient).GetCurrentRevision }()
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654}
+	_, err := DescribeChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, true), mock.NewTrillianMapMockClient(conn, false, false, false), channel, tracer)
+	assert.Error(t, err)
+
+	channel.MapID = -2
+	_, err = DescribeChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), mock.NewTrillianMapMockClient(conn, false, false, false), channel, tracer)
+	assert.Error(t, err)
+}
+
+//TestDeleteChannel tests deleting a channel successfully
+func TestDeleteChannel(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	add = addMock
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654}
+	result, err := DeleteChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), nil, nil, 2, 1, channel, tracer)
+	assert.Nil(t, err)
+	assert.True(t, *result.Deleted)
+	assert.False(t, time.Time(result.DeleteTime).IsZero())
+	assert.Empty(t, mock.UndeletedTrees())
+}
+
+//TestDeleteChannelSchema tests that deleting a channel clears the versions of its schema, so that a channel created again with the same ID does not inherit them
+func TestDeleteChannelSchema(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	var written []*trillian.MapLeaf
+	add = func(c *client.Client, ctx context.Context, leaves []*trillian.MapLeaf, revision int64, tracer opentracing.Tracer) error {
+		written = leaves
+		return nil
+	}
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654, SchemaVersion: 2}
+	_, err := DeleteChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), nil, nil, 2, 1, channel, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(written))
+	assert.Equal(t, ChannelIndex("test-channel"), written[0].Index)
+	assert.Equal(t, ChannelSchemaIndex("test-channel", 1), written[1].Index)
+	assert.Equal(t, ChannelSchemaIndex("test-channel", 2), written[2].Index)
+	for _, leaf := range written {
+		assert.Empty(t, leaf.LeafValue)
+	}
+}
+
+//TestDeleteChannelError tests that the map of a channel is restored when the channel cannot be removed
+func TestDeleteChannelError(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	add = addErrorMock
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654}
+	_, err := DeleteChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), nil, nil, 2, 1, channel, tracer)
+	assert.Error(t, err)
+	assert.Equal(t, []int64{1654}, mock.UndeletedTrees())
+
+	add = addMock
+	channel.MapID = mock.DeleteTreeErrorID
+	_, err = DeleteChannel(ctx, mock.NewTrillianAdminMockClient(conn, false, false), nil, nil, 2, 1, channel, tracer)
+	assert.Error(t, err)
+	assert.Empty(t, mock.UndeletedTrees())
+}
+
+//TestUpdateChannel tests writing a new version of a channel to the channel config map
+func TestUpdateChannel(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	var written []*trillian.MapLeaf
+	var writtenRevision int64
+	add = func(c *client.Client, ctx context.Context, leaves []*trillian.MapLeaf, revision int64, tracer opentracing.Tracer) error {
+		written = leaves
+		writtenRevision = revision
+		return nil
+	}
+	registryPage := int64(0)
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654, RegistryPage: &registryPage, Lifecycle: map[string][]string{"UPDATE": {"active"}}}
+	assert.Nil(t, UpdateChannel(ctx, nil, 3, 1, channel, tracer))
+	assert.Equal(t, int64(3), writtenRevision)
+	assert.Len(t, written, 1)
+	assert.Equal(t, ChannelIndex("test-channel"), written[0].Index)
+	var result models.Channel
+	assert.Nil(t, result.UnmarshalBinary(written[0].LeafValue))
+	assert.Equal(t, *channel, result)
+
+	add = addErrorMock
+	assert.Error(t, UpdateChannel(ctx, nil, 3, 1, channel, tracer))
+}
+
 func addMock(c *client.Client, ctx context.Context, leaves []*trillian.MapLeaf, revision int64, tracer opentracing.Tracer) error {
 	return nil
 }
@@ -219,3 +354,10 @@ func getBadResMock(c *client.MapClient, ctx context.Context, indexes [][]byte, t
 	}
 	return inclusions, &types.MapRootV1{Revision: 1}, nil
 }
+
+func getCurrentRevisionMock(c *client.MapClient, ctx context.Context, mapID int64, tracer opentracing.Tracer) (uint64, error) {
+	if mapID == -2 {
+		return 0, errors.New("Test Error")
+	}
+	return 12, nil
+}
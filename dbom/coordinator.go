@@ -0,0 +1,382 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+	"trillian-agent/logger"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var coordinatorLogger = logger.GetLogger("DBoM:Coordinator")
+
+// CommitFunc reads the state it needs and writes a single map revision
+type CommitFunc func(ctx context.Context) error
+
+// StageFunc validates a commit against the latest revision of a map and stages its leaves in a batch
+type StageFunc func(ctx context.Context, batch *Batch) error
+
+// MapWriter reads the current revision of the map behind a coordinator key and writes new revisions to it
+type MapWriter struct {
+	CurrentRevision func(ctx context.Context) (int64, error)
+	Read            func(ctx context.Context, indexes [][]byte, revision int64) ([]*trillian.MapLeaf, error)
+	Write           func(ctx context.Context, leaves []*trillian.MapLeaf, revision int64) error
+}
+
+// Batch is a type that collects the leaves of the commits written together in one map revision
+type Batch struct {
+	revision int64
+	leaves   []*trillian.MapLeaf
+	read     func(ctx context.Context, indexes [][]byte, revision int64) ([]*trillian.MapLeaf, error)
+}
+
+// Revision returns the map revision the batch will be written at
+func (b *Batch) Revision() int64 {
+	return b.revision
+}
+
+// Add stages leaves to be written with the batch
+func (b *Batch) Add(leaves ...*trillian.MapLeaf) {
+	b.leaves = append(b.leaves, leaves...)
+}
+
+// Leaves returns the leaves staged in the batch
+func (b *Batch) Leaves() []*trillian.MapLeaf {
+	return b.leaves
+}
+
+// Set stages a leaf shared by the commits of the batch, replacing the value staged for its index by an earlier commit
+func (b *Batch) Set(leaf *trillian.MapLeaf) {
+	for i, staged := range b.leaves {
+		if bytes.Equal(staged.Index, leaf.Index) {
+			b.leaves[i] = leaf
+			return
+		}
+	}
+	b.leaves = append(b.leaves, leaf)
+}
+
+// Staged returns the value staged for an index in the batch, and whether one is staged
+func (b *Batch) Staged(index []byte) ([]byte, bool) {
+	for i := len(b.leaves) - 1; i >= 0; i-- {
+		if bytes.Equal(b.leaves[i].Index, index) {
+			return b.leaves[i].LeafValue, true
+		}
+	}
+	return nil, false
+}
+
+// Leaf returns the value staged for an index in the batch, or else its value in the revision the batch follows
+func (b *Batch) Leaf(ctx context.Context, index []byte) ([]byte, error) {
+	if value, ok := b.Staged(index); ok {
+		return value, nil
+	}
+	if b.read == nil || b.revision <= 1 {
+		return nil, nil
+	}
+	leaves, err := b.read(ctx, [][]byte{index}, b.revision-1)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) == 0 {
+		return nil, nil
+	}
+	return leaves[0].LeafValue, nil
+}
+
+// Coordinator serializes the commits made to each channel, groups queued commits into one map revision and retries commits that lose a revision race
+type Coordinator struct {
+	mu           sync.Mutex
+	locks        map[string]*commitLock
+	queues       map[string]*commitQueue
+	maxRetries   int
+	window       time.Duration
+	maxBatchSize int
+}
+
+// commitLock is a ticket lock so that queued commits run in the order they arrived
+type commitLock struct {
+	cond    *sync.Cond
+	next    uint64
+	serving uint64
+	users   int
+}
+
+// commitQueue holds the staged commits of a key waiting for the next batch
+type commitQueue struct {
+	pending []*pendingCommit
+	full    chan struct{}
+}
+
+type pendingCommit struct {
+	ctx     context.Context
+	indexes [][]byte
+	stage   StageFunc
+	writer  MapWriter
+	tracer  opentracing.Tracer
+	done    chan error
+}
+
+// NewCoordinator creates a coordinator that batches the commits queued within window, up to maxBatchSize per revision, and retries a commit up to maxRetries times on a revision mismatch
+func NewCoordinator(maxRetries int, window time.Duration, maxBatchSize int) *Coordinator {
+	if maxBatchSize < 1 {
+		maxBatchSize = 1
+	}
+	return &Coordinator{
+		locks:        make(map[string]*commitLock),
+		queues:       make(map[string]*commitQueue),
+		maxRetries:   maxRetries,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// Commit runs commit while holding the lock for key, running it again with freshly read state if trillian rejects the expected revision
+func (c *Coordinator) Commit(ctx context.Context, key string, commit CommitFunc, tracer opentracing.Tracer) error {
+	coordinatorLogger.Info().Msg("[DBoM:Commit] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:Commit")
+	lock := c.acquire(key)
+	defer c.release(key, lock)
+
+	var err error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		err = commit(ctx)
+		if !IsRevisionMismatch(err) {
+			break
+		}
+		coordinatorLogger.Debug().Msgf("Revision mismatch committing to %v, attempt %v of %v", key, attempt+1, c.maxRetries+1)
+	}
+	if err != nil {
+		tracing.LogAndTraceErr(coordinatorLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	coordinatorLogger.Info().Msg("[DBoM:Commit] Finished")
+	span.Finish()
+	return nil
+}
+
+// Stage queues a commit touching the leaves at indexes and waits until the batch it was grouped into has been written.
+// Commits are staged in the order they arrived; a commit touching a leaf already staged in the batch waits for the next one.
+// The error returned is the one returned by stage, or the error writing the batch
+func (c *Coordinator) Stage(ctx context.Context, key string, writer MapWriter, indexes [][]byte, stage StageFunc, tracer opentracing.Tracer) error {
+	coordinatorLogger.Info().Msg("[DBoM:Stage] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:Stage")
+
+	commit := &pendingCommit{
+		ctx:     ctx,
+		indexes: indexes,
+		stage:   stage,
+		writer:  writer,
+		tracer:  tracer,
+		done:    make(chan error, 1),
+	}
+	c.mu.Lock()
+	queue, ok := c.queues[key]
+	if !ok {
+		queue = &commitQueue{full: make(chan struct{}, 1)}
+		c.queues[key] = queue
+		go c.run(key, queue)
+	}
+	queue.pending = append(queue.pending, commit)
+	if len(queue.pending) >= c.maxBatchSize {
+		queue.signalFull()
+	}
+	c.mu.Unlock()
+
+	err := <-commit.done
+	if err != nil {
+		tracing.LogAndTraceErr(coordinatorLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	coordinatorLogger.Info().Msg("[DBoM:Stage] Finished")
+	span.Finish()
+	return nil
+}
+
+// IsRevisionMismatch reports whether err is trillian rejecting a write because the map has moved past the expected revision
+func IsRevisionMismatch(err error) bool {
+	return err != nil && status.Code(err) == codes.FailedPrecondition
+}
+
+func (c *Coordinator) acquire(key string) *commitLock {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lock, ok := c.locks[key]
+	if !ok {
+		lock = &commitLock{cond: sync.NewCond(&c.mu)}
+		c.locks[key] = lock
+	}
+	lock.users++
+	ticket := lock.next
+	lock.next++
+	for lock.serving != ticket {
+		lock.cond.Wait()
+	}
+	return lock
+}
+
+func (c *Coordinator) release(key string, lock *commitLock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lock.serving++
+	lock.users--
+	if lock.users == 0 {
+		delete(c.locks, key)
+	}
+	lock.cond.Broadcast()
+}
+
+func (q *commitQueue) signalFull() {
+	select {
+	case q.full <- struct{}{}:
+	default:
+	}
+}
+
+func (q *commitQueue) drainFull() {
+	select {
+	case <-q.full:
+	default:
+	}
+}
+
+// run writes the batches of a key until its queue is empty
+func (c *Coordinator) run(key string, queue *commitQueue) {
+	for {
+		timer := time.NewTimer(c.window)
+		select {
+		case <-timer.C:
+		case <-queue.full:
+			timer.Stop()
+		}
+
+		c.mu.Lock()
+		size := len(queue.pending)
+		if size > c.maxBatchSize {
+			size = c.maxBatchSize
+		}
+		commits := append([]*pendingCommit(nil), queue.pending[:size]...)
+		queue.pending = queue.pending[size:]
+		if len(queue.pending) < c.maxBatchSize {
+			queue.drainFull()
+		}
+		c.mu.Unlock()
+
+		deferred := c.writeBatch(key, commits)
+
+		c.mu.Lock()
+		queue.pending = append(deferred, queue.pending...)
+		if len(queue.pending) == 0 {
+			delete(c.queues, key)
+			c.mu.Unlock()
+			return
+		}
+		if len(queue.pending) >= c.maxBatchSize {
+			queue.signalFull()
+		}
+		c.mu.Unlock()
+	}
+}
+
+// writeBatch stages commits in one revision, writes it and reports the result to each commit, returning the commits deferred to the next batch
+func (c *Coordinator) writeBatch(key string, commits []*pendingCommit) []*pendingCommit {
+	first := commits[0]
+	span, ctx := opentracing.StartSpanFromContextWithTracer(opentracing.ContextWithSpan(context.Background(), opentracing.SpanFromContext(first.ctx)), first.tracer, "DBoM:WriteBatch")
+	defer span.Finish()
+
+	var err error
+	var staged, deferred []*pendingCommit
+	var results map[*pendingCommit]error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		staged, deferred = nil, nil
+		results = make(map[*pendingCommit]error)
+		var revision int64
+		revision, err = first.writer.CurrentRevision(ctx)
+		if err != nil {
+			break
+		}
+		batch := &Batch{revision: revision + 1, read: first.writer.Read}
+		touched := make(map[string]bool)
+		for _, commit := range commits {
+			if touchesAny(touched, commit.indexes) {
+				deferred = append(deferred, commit)
+				continue
+			}
+			for _, index := range commit.indexes {
+				touched[string(index)] = true
+			}
+			size := len(batch.leaves)
+			staging := append([]*trillian.MapLeaf(nil), batch.leaves...)
+			if stageErr := commit.stage(commit.ctx, batch); stageErr != nil {
+				batch.leaves = staging
+				results[commit] = stageErr
+				continue
+			}
+			if len(batch.leaves) == size {
+				results[commit] = nil
+				continue
+			}
+			staged = append(staged, commit)
+		}
+		if len(staged) == 0 {
+			break
+		}
+		coordinatorLogger.Debug().Msgf("Writing %v commits to %v at revision %v", len(staged), key, batch.revision)
+		err = first.writer.Write(ctx, batch.leaves, batch.revision)
+		if !IsRevisionMismatch(err) {
+			break
+		}
+		coordinatorLogger.Debug().Msgf("Revision mismatch writing batch to %v, attempt %v of %v", key, attempt+1, c.maxRetries+1)
+	}
+	if err != nil {
+		tracing.LogAndTraceErr(coordinatorLogger, span, err, responses.InternalError)
+		for _, commit := range commits {
+			if _, ok := results[commit]; !ok {
+				results[commit] = err
+			}
+		}
+		deferred = nil
+	}
+	for _, commit := range staged {
+		results[commit] = err
+	}
+	for commit, result := range results {
+		commit.done <- result
+	}
+	return deferred
+}
+
+func touchesAny(touched map[string]bool, indexes [][]byte) bool {
+	for _, index := range indexes {
+		if touched[string(index)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//TestCoordinatorConcurrentCommits tests that concurrent commits to one channel are all written, grouped into fewer revisions
+func TestCoordinatorConcurrentCommits(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	fake := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(0, 20*time.Millisecond, 100)
+
+	const commits = 50
+	var wg sync.WaitGroup
+	errs := make([]error, commits)
+	for i := 0; i < commits; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recordID := fmt.Sprintf("record-%v", i)
+			errs[i] = coordinator.Stage(ctx, "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+				return CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID}, tracer)
+			}, tracer)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.Nil(t, err)
+	}
+	assert.True(t, fake.Writes() < commits)
+	assert.Equal(t, int64(fake.Writes()), fake.Revision())
+	for i := 0; i < commits; i++ {
+		var record models.Record
+		assert.Nil(t, record.UnmarshalBinary(fake.Leaf(RecordIndex(fmt.Sprintf("record-%v", i)), -1)))
+		assert.Equal(t, fmt.Sprintf("record-%v", i), *record.ResourceID)
+		assert.NotNil(t, fake.Leaf(RecordIndex(fmt.Sprintf("record-%v", i)), record.Revision))
+		assert.Nil(t, fake.Leaf(RecordIndex(fmt.Sprintf("record-%v", i)), record.Revision-1))
+	}
+}
+
+//TestCoordinatorMaxBatchSize tests that a full batch is written without waiting for the window
+func TestCoordinatorMaxBatchSize(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	fake := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(0, time.Hour, 10)
+
+	const commits = 30
+	var wg sync.WaitGroup
+	for i := 0; i < commits; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recordID := fmt.Sprintf("record-%v", i)
+			assert.Nil(t, coordinator.Stage(ctx, "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+				return CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID}, tracer)
+			}, tracer))
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 3, fake.Writes())
+}
+
+//TestCoordinatorDeferred tests that a commit touching a leaf already staged is written in the next revision and sees the staged state
+func TestCoordinatorDeferred(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	fake := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(0, 20*time.Millisecond, 100)
+	recordID := "test-record"
+	index := RecordIndex(recordID)
+
+	var wg sync.WaitGroup
+	var previous [2]int64
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i == 1 {
+				time.Sleep(5 * time.Millisecond)
+			}
+			assert.Nil(t, coordinator.Stage(ctx, "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{index}, func(ctx context.Context, batch *Batch) error {
+				var current models.Record
+				if leaf := fake.Leaf(index, -1); leaf != nil {
+					current.UnmarshalBinary(leaf)
+				}
+				previous[i] = current.Revision
+				return CreateRecord(ctx, batch, current.Revision, "test-channel", "UPDATE", &models.RecordDefinition{RecordID: &recordID}, tracer)
+			}, tracer))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 2, fake.Writes())
+	assert.Equal(t, [2]int64{0, 1}, previous)
+}
+
+//TestCoordinatorStageError tests that a rejected commit does not stop the rest of its batch
+func TestCoordinatorStageError(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	fake := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(0, 20*time.Millisecond, 100)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recordID := fmt.Sprintf("record-%v", i)
+			errs[i] = coordinator.Stage(ctx, "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+				if i == 0 {
+					return errors.New("Test Error")
+				}
+				return CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID}, tracer)
+			}, tracer)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Error(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.Equal(t, 1, fake.Writes())
+	assert.Nil(t, fake.Leaf(RecordIndex("record-0"), -1))
+	assert.NotNil(t, fake.Leaf(RecordIndex("record-1"), -1))
+}
+
+//TestCoordinatorRetry tests that a batch losing a revision race is staged again with fresh state
+func TestCoordinatorRetry(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	fake := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(2, 0, 100)
+	writer := statefulMapWriter(fake, tracer)
+	write := writer.Write
+	writer.Write = func(ctx context.Context, leaves []*trillian.MapLeaf, revision int64) error {
+		if fake.Writes() == 0 {
+			// Another writer takes the revision between the read and the write
+			fake.WriteLeaves(ctx, &trillian.WriteMapLeavesRequest{MapId: 1651, ExpectRevision: revision})
+		}
+		return write(ctx, leaves, revision)
+	}
+
+	attempts := 0
+	recordID := "test-record"
+	err := coordinator.Stage(ctx, "channel:test-channel", writer, [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+		attempts++
+		return CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID}, tracer)
+	}, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, int64(2), fake.Revision())
+}
+
+//TestCoordinatorWriteError tests that every commit of a batch gets the error writing it
+func TestCoordinatorWriteError(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	fake := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(2, 0, 100)
+	writer := statefulMapWriter(fake, tracer)
+	writer.Write = func(ctx context.Context, leaves []*trillian.MapLeaf, revision int64) error {
+		return errors.New("Test Error")
+	}
+
+	recordID := "test-record"
+	err := coordinator.Stage(ctx, "channel:test-channel", writer, [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+		return CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID}, tracer)
+	}, tracer)
+	assert.Error(t, err)
+	assert.Equal(t, 0, fake.Writes())
+}
+
+//TestCoordinatorRetryExhausted tests that a commit fails once its retries are used up
+func TestCoordinatorRetryExhausted(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	coordinator := NewCoordinator(2, 0, 1)
+
+	attempts := 0
+	err := coordinator.Commit(ctx, "channel:test-channel", func(ctx context.Context) error {
+		attempts++
+		return status.Error(codes.FailedPrecondition, "revision mismatch")
+	}, tracer)
+	assert.True(t, IsRevisionMismatch(err))
+	assert.Equal(t, 3, attempts)
+}
+
+//TestCoordinatorNoRetry tests that other errors are returned without retrying
+func TestCoordinatorNoRetry(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	coordinator := NewCoordinator(2, 0, 1)
+
+	attempts := 0
+	err := coordinator.Commit(ctx, "channel:test-channel", func(ctx context.Context) error {
+		attempts++
+		return errors.New("Test Error")
+	}, tracer)
+	assert.Error(t, err)
+	assert.False(t, IsRevisionMismatch(err))
+	assert.Equal(t, 1, attempts)
+}
+
+func statefulMapWriter(fake *mock.StatefulMapMock, tracer opentracing.Tracer) MapWriter {
+	mapWriteClient := client.NewClient(fake, 1651)
+	return MapWriter{
+		CurrentRevision: func(ctx context.Context) (int64, error) {
+			return fake.Revision(), nil
+		},
+		Read: func(ctx context.Context, indexes [][]byte, revision int64) ([]*trillian.MapLeaf, error) {
+			leaves, err := fake.GetLeavesByRevision(ctx, &trillian.GetMapLeavesByRevisionRequest{Index: indexes, Revision: revision})
+			if err != nil {
+				return nil, err
+			}
+			return leaves.Leaves, nil
+		},
+		Write: func(ctx context.Context, leaves []*trillian.MapLeaf, revision int64) error {
+			return mapWriteClient.Add(ctx, leaves, revision, tracer)
+		},
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"fmt"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+)
+
+// historyPrefix is the index prefix of the revisions in the history of a record
+const historyPrefix byte = 0xfe
+
+// RecordHistoryIndex returns the index of the leaf holding a revision of a record at a position of its history in the channel map
+func RecordHistoryIndex(recordID string, position int64) []byte {
+	return prefixedIndex(historyPrefix, fmt.Sprintf("%d:%v", position, recordID))
+}
+
+// historyLeaf returns the leaf holding the value of a revision of a record at its position in the history of the record, to be staged with it.
+// Each revision is written once to its own leaf. A history starting over, for a record transferred back in or last committed before the history leaves, overwrites the revisions it had before
+func historyLeaf(record *models.Record, value []byte) *trillian.MapLeaf {
+	return &trillian.MapLeaf{Index: RecordHistoryIndex(*record.ResourceID, *record.HistoryLength-1), LeafValue: value}
+}
+
+// GetRecordHistory gets up to limit revisions of a record in a channel from trillian, newest first, from its revision at the map revision, or at the latest map revision if revision is not positive.
+// The revisions are read from the leaves of the history of the record with one read at the map revision, whatever their number.
+// The history returned ends early at a revision committed before the history leaves; the previous revision and transfer source of the oldest revision returned tell where it goes on
+func GetRecordHistory(ctx context.Context, client *client.MapClient, recordID string, revision int64, limit int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+	recordLogger.Info().Msg("[DBoM:GetRecordHistory] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetRecordHistory")
+
+	inclusions, mapRoot, err := readLeaves(ctx, client, [][]byte{RecordIndex(recordID)}, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	value := inclusions[0].GetLeaf().GetLeafValue()
+	if len(value) == 0 {
+		tracing.LogAndTraceErr(recordLogger, span, nil, responses.ResourceNotFound)
+		return nil, nil
+	}
+	var latest models.Record
+	if err := latest.UnmarshalBinary(value); err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	history := []*models.Record{&latest}
+
+	if latest.HistoryLength != nil && *latest.HistoryLength > 1 && limit > 1 {
+		newest := *latest.HistoryLength - 2
+		oldest := newest - limit + 2
+		if oldest < 0 {
+			oldest = 0
+		}
+		var positions []int64
+		for position := newest; position >= oldest; position-- {
+			positions = append(positions, position)
+		}
+		revisions, err := readHistory(ctx, client, recordID, positions, int64(mapRoot.Revision), tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+		history = append(history, revisions...)
+	}
+	recordLogger.Debug().Msgf("Retrieved %v revisions of asset %v from revision %v", len(history), recordID, latest.Revision)
+
+	recordLogger.Info().Msg("[DBoM:GetRecordHistory] Finished")
+	span.Finish()
+	return history, nil
+}
+
+// GetRecordHistoryRange gets the revisions of a record at the positions from up to to, excluded, of its history in a channel from trillian, oldest first, as of a map revision.
+// The revisions are read from the leaves of the history of the record with one read at the map revision, whatever their number
+func GetRecordHistoryRange(ctx context.Context, client *client.MapClient, recordID string, revision int64, from int64, to int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+	recordLogger.Info().Msg("[DBoM:GetRecordHistoryRange] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetRecordHistoryRange")
+
+	var positions []int64
+	for position := from; position < to; position++ {
+		positions = append(positions, position)
+	}
+	history, err := readHistory(ctx, client, recordID, positions, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	recordLogger.Debug().Msgf("Retrieved %v revisions of asset %v from position %v", len(history), recordID, from)
+
+	recordLogger.Info().Msg("[DBoM:GetRecordHistoryRange] Finished")
+	span.Finish()
+	return history, nil
+}
+
+// readHistory reads the revisions of a record at positions of its history with one read at a map revision. Every position must hold a revision
+func readHistory(ctx context.Context, client *client.MapClient, recordID string, positions []int64, revision int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	indexes := make([][]byte, len(positions))
+	for i, position := range positions {
+		indexes[i] = RecordHistoryIndex(recordID, position)
+	}
+	inclusions, _, err := readLeaves(ctx, client, indexes, revision, tracer)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]*models.Record, len(inclusions))
+	for i, inclusion := range inclusions {
+		value := inclusion.GetLeaf().GetLeafValue()
+		if len(value) == 0 {
+			return nil, fmt.Errorf("revision %v of the history of %v is missing", positions[i], recordID)
+		}
+		var record models.Record
+		if err := record.UnmarshalBinary(value); err != nil {
+			return nil, err
+		}
+		history[i] = &record
+	}
+	return history, nil
+}
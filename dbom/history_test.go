@@ -0,0 +1,224 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func historyRevisions(history []*models.Record) []int64 {
+	revisions := []int64{}
+	for _, record := range history {
+		revisions = append(revisions, record.Revision)
+	}
+	return revisions
+}
+
+// commitRevisions commits a record at revisions 1 to n of a fake map, creating it at revision 1 and updating it at the following revisions
+func commitRevisions(tb testing.TB, fake *mock.StatefulMapMock, recordID string, n int64) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	writer := statefulMapWriter(fake, tracer)
+	for revision := int64(1); revision <= n; revision++ {
+		commitType := "UPDATE"
+		if revision == 1 {
+			commitType = "CREATE"
+		}
+		batch := &Batch{revision: revision, read: writer.Read}
+		if err := CreateRecord(ctx, batch, revision-1, "test-channel", commitType, &models.RecordDefinition{RecordID: &recordID}, tracer); err != nil {
+			tb.Fatal(err)
+		}
+		if err := writer.Write(ctx, batch.Leaves(), revision); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+//TestGetRecordHistory tests reading the revisions of a record from the leaves of its history
+func TestGetRecordHistory(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	commitRevisions(t, fake, "record", 5)
+
+	history, err := GetRecordHistory(ctx, nil, "record", -1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{5, 4, 3, 2, 1}, historyRevisions(history))
+	assert.Equal(t, "CREATE", *history[4].EventType)
+	assert.Equal(t, int64(0), history[4].PreviousRevision)
+
+	history, err = GetRecordHistory(ctx, nil, "record", -1, 3, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{5, 4, 3}, historyRevisions(history))
+
+	history, err = GetRecordHistory(ctx, nil, "record", 4, 2, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{4, 3}, historyRevisions(history))
+
+	history, err = GetRecordHistory(ctx, nil, "record", 3, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, historyRevisions(history))
+
+	history, err = GetRecordHistory(ctx, nil, "record", -1, 1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{5}, historyRevisions(history))
+
+	history, err = GetRecordHistory(ctx, nil, "other-record", -1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, history)
+}
+
+//TestGetRecordHistoryRange tests reading the revisions of a record at a range of positions of its history, oldest first
+func TestGetRecordHistoryRange(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	commitRevisions(t, fake, "record", 5)
+
+	history, err := GetRecordHistoryRange(ctx, nil, "record", 5, 1, 4, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2, 3, 4}, historyRevisions(history))
+
+	history, err = GetRecordHistoryRange(ctx, nil, "record", 3, 0, 3, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, historyRevisions(history))
+
+	history, err = GetRecordHistoryRange(ctx, nil, "record", 5, 2, 2, tracer)
+	assert.Nil(t, err)
+	assert.Empty(t, history)
+
+	_, err = GetRecordHistoryRange(ctx, nil, "record", 3, 2, 4, tracer)
+	assert.NotNil(t, err)
+}
+
+//TestGetRecordHistoryBeforeLeaves tests that the history of a record last committed before the history leaves ends at the revision committed before them
+func TestGetRecordHistoryBeforeLeaves(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	recordID, commitType, channelID := "legacy-record", "CREATE", "test-channel"
+	legacy := models.Record{AuditDefinition: models.AuditDefinition{ChannelID: &channelID, ResourceID: &recordID, EventType: &commitType}, Revision: 1}
+	value, _ := legacy.MarshalBinary()
+	_, err := fake.WriteLeaves(ctx, &trillian.WriteMapLeavesRequest{Leaves: []*trillian.MapLeaf{{Index: RecordIndex(recordID), LeafValue: value}}, ExpectRevision: 1})
+	assert.Nil(t, err)
+	commitRecords(t, fake, "UPDATE", 1, recordID)
+	commitRecords(t, fake, "UPDATE", 2, recordID)
+
+	history, err := GetRecordHistory(ctx, nil, recordID, -1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{3, 2}, historyRevisions(history))
+	assert.Equal(t, int64(1), history[1].PreviousRevision)
+
+	history, err = GetRecordHistory(ctx, nil, recordID, 1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1}, historyRevisions(history))
+}
+
+//TestGetRecordHistoryLargePayload tests that each revision of a record with a large payload is written once, so that the leaves staged by a commit do not grow with the history of the record
+func TestGetRecordHistoryLargePayload(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	writer := statefulMapWriter(fake, tracer)
+
+	recordID, payload := "large-record", map[string]interface{}{"data": strings.Repeat("x", 1<<20)}
+	for revision := int64(1); revision <= 10; revision++ {
+		commitType := "UPDATE"
+		if revision == 1 {
+			commitType = "CREATE"
+		}
+		batch := &Batch{revision: revision, read: writer.Read}
+		assert.Nil(t, CreateRecord(ctx, batch, revision-1, "test-channel", commitType, &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: payload}, tracer))
+		staged := 0
+		for _, leaf := range batch.Leaves() {
+			staged += len(leaf.LeafValue)
+		}
+		assert.Less(t, staged, 5<<19)
+		assert.Nil(t, writer.Write(ctx, batch.Leaves(), revision))
+	}
+
+	history, err := GetRecordHistory(ctx, nil, recordID, -1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, historyRevisions(history))
+	assert.True(t, assert.ObjectsAreEqual(payload, history[9].Payload.(map[string]interface{})["recordIDPayload"]))
+}
+
+// BenchmarkRecordHistory compares reading the 1,000 revisions of a record from the leaves of its history with reading them one revision at a time
+func BenchmarkRecordHistory(b *testing.B) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(b, fake)
+	CatalogPageSize = 100
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	b.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+	tracer := opentracing.NoopTracer{}
+	ctx := context.Background()
+	commitRevisions(b, fake, "record", 1000)
+
+	var reads int
+	read := getByRevision
+	getByRevision = func(c *client.MapClient, ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
+		reads++
+		return read(c, ctx, indexes, revision, tracer)
+	}
+
+	b.Run("history-leaves", func(b *testing.B) {
+		reads = 0
+		for i := 0; i < b.N; i++ {
+			history, err := GetRecordHistory(ctx, nil, "record", -1, 1000, tracer)
+			if err != nil || len(history) != 1000 {
+				b.Fatalf("read %v revisions: %v", len(history), err)
+			}
+		}
+		b.ReportMetric(float64(reads)/float64(b.N), "reads/op")
+	})
+	b.Run("revision-by-revision", func(b *testing.B) {
+		reads = 0
+		for i := 0; i < b.N; i++ {
+			count := 0
+			for revision := int64(-1); revision != 0; count++ {
+				record, err := GetRecord(ctx, nil, "record", revision, tracer)
+				if err != nil {
+					b.Fatal(err)
+				}
+				revision = record.PreviousRevision
+			}
+			if count != 1000 {
+				b.Fatalf("read %v revisions", count)
+			}
+		}
+		b.ReportMetric(float64(reads)/float64(b.N), "reads/op")
+	})
+}
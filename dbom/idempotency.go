@@ -0,0 +1,56 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"trillian-agent/models"
+
+	"github.com/google/trillian"
+)
+
+// idempotencyPrefix is the index prefix of the results of commits made with idempotency keys
+const idempotencyPrefix byte = 0xfd
+
+// IdempotencyKeyIndex returns the index of the leaf holding the result of the commit made with an idempotency key in the channel map
+func IdempotencyKeyIndex(key string) []byte {
+	return prefixedIndex(idempotencyPrefix, key)
+}
+
+// GetIdempotencyKey reads the result of the commit made with an idempotency key from the revision the batch follows, or the one staged in the batch.
+// It returns nil if no commit was made with the key
+func GetIdempotencyKey(ctx context.Context, batch *Batch, key string) (*models.IdempotencyKey, error) {
+	var idempotencyKey models.IdempotencyKey
+	if err := readBatchLeaf(ctx, batch, IdempotencyKeyIndex(key), &idempotencyKey); err != nil {
+		return nil, err
+	} else if idempotencyKey.Key == "" {
+		return nil, nil
+	}
+	return &idempotencyKey, nil
+}
+
+// StageIdempotencyKey stages the result of a commit made with an idempotency key, written at the revision of the batch
+func StageIdempotencyKey(batch *Batch, key string, bodyHash string, recordID string) error {
+	idempotencyKey := models.IdempotencyKey{Key: key, BodyHash: bodyHash, RecordID: recordID, Revision: batch.Revision()}
+	val, err := idempotencyKey.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	batch.Set(&trillian.MapLeaf{Index: IdempotencyKeyIndex(key), LeafValue: val})
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"testing"
+	"trillian-agent/mock"
+	"trillian-agent/tracing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//TestIdempotencyKey tests storing the result of a commit made with an idempotency key in the channel map and reading it back in later batches
+func TestIdempotencyKey(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	writer := statefulMapWriter(fake, tracer)
+
+	batch := &Batch{revision: 1, read: writer.Read}
+	idempotencyKey, err := GetIdempotencyKey(ctx, batch, "key")
+	assert.Nil(t, err)
+	assert.Nil(t, idempotencyKey)
+	assert.Nil(t, StageIdempotencyKey(batch, "key", "hash", "record"))
+	idempotencyKey, err = GetIdempotencyKey(ctx, batch, "key")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), idempotencyKey.Revision)
+	assert.Nil(t, writer.Write(ctx, batch.Leaves(), 1))
+
+	batch = &Batch{revision: 2, read: writer.Read}
+	idempotencyKey, err = GetIdempotencyKey(ctx, batch, "key")
+	assert.Nil(t, err)
+	assert.Equal(t, "key", idempotencyKey.Key)
+	assert.Equal(t, "hash", idempotencyKey.BodyHash)
+	assert.Equal(t, "record", idempotencyKey.RecordID)
+	assert.Equal(t, int64(1), idempotencyKey.Revision)
+	idempotencyKey, err = GetIdempotencyKey(ctx, batch, "other-key")
+	assert.Nil(t, err)
+	assert.Nil(t, idempotencyKey)
+	assert.NotEqual(t, RecordIndex("key"), IdempotencyKeyIndex("key"))
+}
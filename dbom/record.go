@@ -20,6 +20,7 @@ package dbom
 import (
 	"context"
 	"crypto/sha256"
+	"fmt"
 	"time"
 	"trillian-agent/logger"
 	"trillian-agent/models"
@@ -29,54 +30,149 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/google/trillian"
+	"github.com/google/trillian/types"
 	"github.com/opentracing/opentracing-go"
 )
 
 var recordLogger = logger.GetLogger("DBoM:Record")
 
-// CreateRecord creates a record and writes it to trillian
-func CreateRecord(ctx context.Context, client *client.Client, revision int64, prevRevision int64, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+// RecordIndex returns the index of the leaf holding a record in the channel map
+func RecordIndex(recordID string) []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte(recordID))
+	return hasher.Sum(nil)
+}
+
+// CreateRecord creates a record and stages it to be written to trillian with the batch, together with its summary in the record catalog of the channel.
+// The new revision keeps the records attached to the record and the record it is attached to
+func CreateRecord(ctx context.Context, batch *Batch, prevRevision int64, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
 	recordLogger.Info().Msg("[DBoM:CreateRecord] Entered")
 	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:CreateRecord")
 
+	var previous *models.Record
+	if prevRevision > 0 {
+		var err error
+		previous, err = batchRecord(ctx, batch, *recordDef.RecordID)
+		if err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+			return err
+		}
+	}
+	record := newRecord(batch, previous, prevRevision, channelID, commitType, *recordDef.RecordID, recordDef)
+	err := stageRecord(ctx, batch, record)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	recordLogger.Info().Msg("[DBoM:CreateRecord] Finished")
+	span.Finish()
+	return nil
+}
+
+// PatchRecord stages a revision of a record whose record payload is the one of the revision of the record the batch follows, patched by patch.
+// The stored record definition notes the version of the schema of the channel the patched record payload was validated against
+func PatchRecord(ctx context.Context, batch *Batch, channelID string, commitType string, recordID string, schemaVersion int64, patch func(payload interface{}) (interface{}, error), tracer opentracing.Tracer) error {
+	recordLogger.Info().Msg("[DBoM:PatchRecord] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:PatchRecord")
+
+	previous, err := batchRecord(ctx, batch, recordID)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	} else if previous == nil {
+		err := fmt.Errorf("%v: %v", responses.ResourceNotFound, recordID)
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.ResourceNotFound)
+		return err
+	}
+	var payload interface{}
+	if rec, ok := previous.Payload.(map[string]interface{}); ok {
+		payload = rec["recordIDPayload"]
+	}
+	payload, err = patch(payload)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+	recordDef := &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: payload, SchemaVersion: schemaVersion}
+	record := newRecord(batch, previous, previous.Revision, channelID, commitType, recordID, recordDef)
+	if err := stageRecord(ctx, batch, record); err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	recordLogger.Info().Msg("[DBoM:PatchRecord] Finished")
+	span.Finish()
+	return nil
+}
+
+// newRecord builds the revision of a record written with the batch, carrying over the attachments of its previous revision
+func newRecord(batch *Batch, previous *models.Record, prevRevision int64, channelID string, commitType string, recordID string, payload interface{}) *models.Record {
 	t := strfmt.DateTime(time.Now())
 	audit := models.AuditDefinition{
 		ChannelID:  &channelID,
-		ResourceID: recordDef.RecordID,
+		ResourceID: &recordID,
 		EventType:  &commitType,
-		Payload:    recordDef,
+		Payload:    payload,
 		Timestamp:  &t,
 	}
 	record := models.Record{
 		AuditDefinition:  audit,
-		Revision:         revision,
+		Revision:         batch.Revision(),
 		PreviousRevision: prevRevision,
 	}
+	historyLength := int64(1)
+	if previous != nil {
+		record.ParentRecordID = previous.ParentRecordID
+		record.ChildRecordIDs = previous.ChildRecordIDs
+		record.CatalogPage = previous.CatalogPage
+		if previous.HistoryLength != nil {
+			historyLength = *previous.HistoryLength + 1
+		}
+		if previous.Revision == batch.Revision() {
+			// An earlier commit of the batch staged this revision of the record, which replaces it
+			record.PreviousRevision = previous.PreviousRevision
+			historyLength--
+		}
+	}
+	record.HistoryLength = &historyLength
+	return &record
+}
+
+// batchRecord reads the revision of a record the batch follows, or the one staged in the batch
+func batchRecord(ctx context.Context, batch *Batch, recordID string) (*models.Record, error) {
+	value, err := batch.Leaf(ctx, RecordIndex(recordID))
+	if err != nil || len(value) == 0 {
+		return nil, err
+	}
+	var record models.Record
+	if err := record.UnmarshalBinary(value); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// stageRecord stages the leaf of a record with its summary in the record catalog of the channel and its revision in the history of the record
+func stageRecord(ctx context.Context, batch *Batch, record *models.Record) error {
+	catalog, err := catalogRecord(ctx, batch, record)
+	if err != nil {
+		return err
+	}
 
-	leaves := make([]*trillian.MapLeaf, 1)
-	hasher := sha256.New()
-	hasher.Write([]byte(*record.ResourceID))
-	index := hasher.Sum(nil)
 	val, err := record.MarshalBinary()
 	if err != nil {
-		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
 		return err
 	}
 	leaf := &trillian.MapLeaf{
-		Index:     index,
+		Index:     RecordIndex(*record.ResourceID),
 		LeafValue: val,
 	}
-	leaves[0] = leaf
-	recordLogger.Debug().Msgf("Adding asset %v at revision %v", *record.ResourceID, revision)
-	err = add(client, ctx, leaves, revision, tracer)
-	if err != nil {
-		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
-		return err
+	batch.Set(leaf)
+	for _, catalogLeaf := range catalog {
+		batch.Set(catalogLeaf)
 	}
-	recordLogger.Debug().Msgf("Added asset %v at revision %v", *record.ResourceID, revision)
-
-	recordLogger.Info().Msg("[DBoM:CreateRecord] Finished")
-	span.Finish()
+	batch.Set(historyLeaf(record, val))
+	recordLogger.Debug().Msgf("Staged asset %v at revision %v", *record.ResourceID, record.Revision)
 	return nil
 }
 
@@ -85,11 +181,8 @@ func GetRecord(ctx context.Context, client *client.MapClient, recordID string, r
 	recordLogger.Info().Msg("[DBoM:GetRecord] Entered")
 	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetRecord")
 
-	hasher := sha256.New()
-	hasher.Write([]byte(recordID))
-	index := hasher.Sum(nil)
 	indexes := [][]byte{
-		index,
+		RecordIndex(recordID),
 	}
 	var inclusions []*trillian.MapLeafInclusion
 	var err error
@@ -122,3 +215,63 @@ func GetRecord(ctx context.Context, client *client.MapClient, recordID string, r
 	span.Finish()
 	return &result, nil
 }
+
+// GetRecordProof gets the leaf of a record together with everything needed to verify its inclusion without trillian: the signed map root and the public key and hash strategy of the map
+func GetRecordProof(ctx context.Context, client *client.MapClient, tree *trillian.Tree, channelID string, recordID string, revision int64, tracer opentracing.Tracer) (*models.ProofBundleDefinition, error) {
+	recordLogger.Info().Msg("[DBoM:GetRecordProof] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetRecordProof")
+
+	indexes := [][]byte{
+		RecordIndex(recordID),
+	}
+	inclusions, signedRoot, mapRoot, err := getProof(client, ctx, indexes, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	leaf := inclusions[0].GetLeaf()
+	if len(leaf.GetLeafValue()) == 0 {
+		tracing.LogAndTraceErr(recordLogger, span, nil, responses.ResourceNotFound)
+		return nil, nil
+	}
+
+	result := proofBundle(tree, channelID, recordID, inclusions[0], signedRoot, mapRoot)
+	recordLogger.Debug().Msgf("Retrieved proof of asset %v at revision %v", recordID, *result.Revision)
+
+	recordLogger.Info().Msg("[DBoM:GetRecordProof] Finished")
+	span.Finish()
+	return result, nil
+}
+
+// proofBundle builds the proof bundle of the leaf of a record from its inclusion in a signed map root
+func proofBundle(tree *trillian.Tree, channelID string, recordID string, inclusion *trillian.MapLeafInclusion, signedRoot *trillian.SignedMapRoot, mapRoot *types.MapRootV1) *models.ProofBundleDefinition {
+	leaf := inclusion.GetLeaf()
+	proof := make([]strfmt.Base64, len(inclusion.GetInclusion()))
+	for i, hash := range inclusion.GetInclusion() {
+		proof[i] = hash
+	}
+	leafIndex := strfmt.Base64(leaf.GetIndex())
+	leafValue := strfmt.Base64(leaf.GetLeafValue())
+	publicKey := strfmt.Base64(tree.GetPublicKey().GetDer())
+	mapID := tree.GetTreeId()
+	mapRevision := int64(mapRoot.Revision)
+	hashStrategy := tree.GetHashStrategy().String()
+	hashAlgorithm := tree.GetHashAlgorithm().String()
+	signatureAlgorithm := tree.GetSignatureAlgorithm().String()
+	mapRootBytes := strfmt.Base64(signedRoot.GetMapRoot())
+	signature := strfmt.Base64(signedRoot.GetSignature())
+	return &models.ProofBundleDefinition{
+		ChannelID:          &channelID,
+		RecordID:           &recordID,
+		MapID:              &mapID,
+		Revision:           &mapRevision,
+		LeafIndex:          &leafIndex,
+		LeafValue:          &leafValue,
+		Inclusion:          proof,
+		SignedMapRoot:      &models.SignedMapRootDefinition{MapRoot: &mapRootBytes, Signature: &signature},
+		PublicKey:          &publicKey,
+		HashStrategy:       &hashStrategy,
+		HashAlgorithm:      &hashAlgorithm,
+		SignatureAlgorithm: &signatureAlgorithm,
+	}
+}
@@ -18,6 +18,7 @@
 package dbom
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -27,6 +28,8 @@ import (
 	client "trillian-agent/trillian"
 
 	tclient "github.com/google/trillian/client"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
 	"github.com/google/trillian/types"
 
 	"github.com/google/trillian"
@@ -35,39 +38,97 @@ import (
 	"google.golang.org/grpc"
 )
 
-//TestCreateRecord tests creating a record successfully
+//TestCreateRecord tests staging a record successfully
 func TestCreateRecord(t *testing.T) {
-	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
-	defer conn.Close()
 	tracer, _, _ := tracing.SetupGlobalTracer()
 	ctx := context.Background()
 
-	add = addMock
-	assert.Equal(t, true, true)
-
-	client := client.NewClient(mock.NewTrillianMapWriteMockClient(conn, false, false), 1651)
+	batch := &Batch{revision: 2}
 	recID := "test-record"
 
 	recordDef := &models.RecordDefinition{RecordID: &recID}
 
-	CreateRecord(ctx, client, 2, 1, "test-channel", "CREATE", recordDef, tracer)
+	assert.Nil(t, CreateRecord(ctx, batch, 1, "test-channel", "CREATE", recordDef, tracer))
+	assert.Equal(t, 4, len(batch.Leaves()))
+	assert.Equal(t, RecordIndex(recID), batch.Leaves()[0].Index)
+	var record models.Record
+	assert.Nil(t, record.UnmarshalBinary(batch.Leaves()[0].LeafValue))
+	assert.Equal(t, int64(2), record.Revision)
+	assert.Equal(t, int64(1), record.PreviousRevision)
+	assert.Equal(t, int64(0), *record.CatalogPage)
+	assert.Equal(t, int64(1), *record.HistoryLength)
+	assert.Equal(t, RecordCatalogIndex(), batch.Leaves()[1].Index)
+	assert.Equal(t, RecordCatalogPageIndex(0), batch.Leaves()[2].Index)
+	assert.Equal(t, RecordHistoryIndex(recID, 0), batch.Leaves()[3].Index)
 }
 
-//TestCreateRecordError tests an error when creating a record
+//TestCreateRecordError tests an error when staging a record
 func TestCreateRecordError(t *testing.T) {
-	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
-	defer conn.Close()
 	tracer, _, _ := tracing.SetupGlobalTracer()
 	ctx := context.Background()
 
-	add = addErrorMock
-
-	client := client.NewClient(mock.NewTrillianMapWriteMockClient(conn, false, true), 1651)
+	batch := &Batch{revision: 2}
 	recID := "test-record"
 
-	recordDef := &models.RecordDefinition{RecordID: &recID}
+	recordDef := &models.RecordDefinition{RecordID: &recID, RecordIDPayload: map[string]interface{}{"test": make(chan int)}}
+
+	assert.Error(t, CreateRecord(ctx, batch, 1, "test-channel", "CREATE", recordDef, tracer))
+	assert.Equal(t, 0, len(batch.Leaves()))
+}
+
+//TestRetireRecord tests that the tombstone of a retired record follows its previous revision
+func TestRetireRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	commitRecords(t, fake, "CREATE", 0, "test-record")
+	created := latestRecord(t, fake, "test-record")
+
+	commitRecords(t, fake, "RETIRE", created.Revision, "test-record")
+	retired := latestRecord(t, fake, "test-record")
+	assert.Equal(t, "RETIRE", *retired.EventType)
+	assert.Equal(t, created.Revision, retired.PreviousRevision)
+	assert.Equal(t, created.CatalogPage, retired.CatalogPage)
+}
+
+//TestPatchRecord tests staging a revision of a record patching the record payload of the revision the batch follows
+func TestPatchRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	writer := statefulMapWriter(fake, tracer)
+
+	recordID := "test-record"
+	batch := &Batch{revision: 1, read: writer.Read}
+	assert.Nil(t, CreateRecord(ctx, batch, 0, "test-channel", "CREATE", &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{"weight": 12}}, tracer))
+	assert.Nil(t, writer.Write(ctx, batch.Leaves(), 1))
+
+	batch = &Batch{revision: 2, read: writer.Read}
+	err := PatchRecord(ctx, batch, "test-channel", "UPDATE", recordID, 3, func(payload interface{}) (interface{}, error) {
+		assert.Equal(t, map[string]interface{}{"weight": float64(12)}, payload)
+		return map[string]interface{}{"weight": 12.5}, nil
+	}, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Write(ctx, batch.Leaves(), 2))
+	patched := latestRecord(t, fake, recordID)
+	assert.Equal(t, "UPDATE", *patched.EventType)
+	assert.Equal(t, int64(1), patched.PreviousRevision)
+	assert.Equal(t, int64(2), *patched.HistoryLength)
+	assert.Equal(t, map[string]interface{}{"weight": 12.5}, patched.Payload.(map[string]interface{})["recordIDPayload"])
+	assert.Equal(t, float64(3), patched.Payload.(map[string]interface{})["schemaVersion"])
+
+	batch = &Batch{revision: 3, read: writer.Read}
+	err = PatchRecord(ctx, batch, "test-channel", "UPDATE", recordID, 0, func(payload interface{}) (interface{}, error) {
+		return nil, errors.New("test-error")
+	}, tracer)
+	assert.Error(t, err)
+	assert.Empty(t, batch.Leaves())
 
-	assert.Error(t, CreateRecord(ctx, client, 2, 1, "test-channel", "CREATE", recordDef, tracer))
+	err = PatchRecord(ctx, batch, "test-channel", "UPDATE", "random-record", 0, func(payload interface{}) (interface{}, error) {
+		return payload, nil
+	}, tracer)
+	assert.Error(t, err)
+	assert.Empty(t, batch.Leaves())
 }
 
 //TestGetRecord tests getting a record successfully
@@ -81,7 +142,7 @@ func TestGetRecord(t *testing.T) {
 	trillMapWriteClient := mock.NewTrillianMapMockClient(conn, false, false, false)
 	assert.Equal(t, true, true)
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapWriteClient}
-	record, err := GetRecord(ctx, &client.MapClient{mapClientTree}, "test-record", 0, tracer)
+	record, err := GetRecord(ctx, &client.MapClient{MapClient: mapClientTree}, "test-record", 0, tracer)
 	assert.NotNil(t, record)
 	assert.Equal(t, record.Revision, int64(2))
 	assert.Equal(t, record.PreviousRevision, int64(1))
@@ -99,7 +160,7 @@ func TestGetRecordByRevision(t *testing.T) {
 	trillMapWriteClient := mock.NewTrillianMapMockClient(conn, false, false, false)
 	assert.Equal(t, true, true)
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapWriteClient}
-	channel, err := GetRecord(ctx, &client.MapClient{mapClientTree}, "test-record", 2, tracer)
+	channel, err := GetRecord(ctx, &client.MapClient{MapClient: mapClientTree}, "test-record", 2, tracer)
 	assert.NotNil(t, channel)
 	//assert.Equal(t, channel.ChannelID, "test-channel")
 	//assert.Equal(t, channel.MapID, int64(1654))
@@ -117,7 +178,7 @@ func TestGetRecordError(t *testing.T) {
 	trillMapWriteClient := mock.NewTrillianMapMockClient(conn, false, false, false)
 	assert.Equal(t, true, true)
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapWriteClient}
-	_, err := GetRecord(ctx, &client.MapClient{mapClientTree}, "test-record", 0, tracer)
+	_, err := GetRecord(ctx, &client.MapClient{MapClient: mapClientTree}, "test-record", 0, tracer)
 	assert.Error(t, err)
 }
 
@@ -132,7 +193,7 @@ func TestGetRecordNoRes(t *testing.T) {
 	trillMapWriteClient := mock.NewTrillianMapMockClient(conn, false, false, false)
 	assert.Equal(t, true, true)
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapWriteClient}
-	channel, err := GetRecord(ctx, &client.MapClient{mapClientTree}, "test-record", 0, tracer)
+	channel, err := GetRecord(ctx, &client.MapClient{MapClient: mapClientTree}, "test-record", 0, tracer)
 	assert.Nil(t, channel)
 	assert.Nil(t, err)
 }
@@ -148,7 +209,7 @@ func TestGetRecordBadRes(t *testing.T) {
 	trillMapWriteClient := mock.NewTrillianMapMockClient(conn, false, false, false)
 	assert.Equal(t, true, true)
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapWriteClient}
-	_, err := GetRecord(ctx, &client.MapClient{mapClientTree}, "test-record", 0, tracer)
+	_, err := GetRecord(ctx, &client.MapClient{MapClient: mapClientTree}, "test-record", 0, tracer)
 	assert.Error(t, err)
 }
 
@@ -177,6 +238,54 @@ func TestGetRecordChannelClientError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+//TestGetRecordProof tests getting the proof bundle of a record successfully
+func TestGetRecordProof(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	getProof = getRecordProofMock
+	tree := &trillian.Tree{
+		TreeId:             651,
+		HashStrategy:       trillian.HashStrategy_CONIKS_SHA256,
+		HashAlgorithm:      sigpb.DigitallySigned_SHA256,
+		SignatureAlgorithm: sigpb.DigitallySigned_ECDSA,
+		PublicKey:          &keyspb.PublicKey{Der: []byte("test-key")},
+	}
+	proof, err := GetRecordProof(ctx, &client.MapClient{}, tree, "test-channel", "test-record", 2, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, RecordIndex("test-record"), []byte(*proof.LeafIndex))
+	assert.Equal(t, int64(2), *proof.Revision)
+	assert.Equal(t, int64(651), *proof.MapID)
+	assert.Equal(t, 256, len(proof.Inclusion))
+	assert.Equal(t, []byte("test-root"), []byte(*proof.SignedMapRoot.MapRoot))
+	assert.Equal(t, []byte("test-signature"), []byte(*proof.SignedMapRoot.Signature))
+	assert.Equal(t, []byte("test-key"), []byte(*proof.PublicKey))
+	assert.Equal(t, "CONIKS_SHA256", *proof.HashStrategy)
+	assert.Equal(t, "SHA256", *proof.HashAlgorithm)
+	assert.Equal(t, "ECDSA", *proof.SignatureAlgorithm)
+}
+
+//TestGetRecordProofNoRes tests getting the proof bundle of a missing record
+func TestGetRecordProofNoRes(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	getProof = getRecordProofMock
+	proof, err := GetRecordProof(ctx, &client.MapClient{}, &trillian.Tree{}, "test-channel", "random-record", -1, tracer)
+	assert.Nil(t, proof)
+	assert.Nil(t, err)
+}
+
+//TestGetRecordProofError tests an error when getting the proof bundle of a record
+func TestGetRecordProofError(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	getProof = getRecordProofMock
+	_, err := GetRecordProof(ctx, &client.MapClient{}, &trillian.Tree{}, "test-channel", "error-record", -1, tracer)
+	assert.Error(t, err)
+}
+
 func addRecordMock(c *client.Client, ctx context.Context, leaves []*trillian.MapLeaf, revision int64, tracer opentracing.Tracer) error {
 	return nil
 }
@@ -221,3 +330,17 @@ func verifyMock(c tclient.MapVerifier, smr *trillian.SignedMapRoot) (*types.MapR
 	}
 	return &types.MapRootV1{Revision: 1}, nil
 }
+
+func getRecordProofMock(c *client.MapClient, ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *trillian.SignedMapRoot, *types.MapRootV1, error) {
+	if bytes.Equal(indexes[0], RecordIndex("error-record")) {
+		return nil, nil, nil, errors.New("Test Error")
+	}
+	mapLeaf := trillian.MapLeaf{Index: indexes[0]}
+	if bytes.Equal(indexes[0], RecordIndex("test-record")) {
+		mapLeaf.LeafValue = []byte("test-value")
+	}
+	inclusions := []*trillian.MapLeafInclusion{
+		{Leaf: &mapLeaf, Inclusion: make([][]byte, 256)},
+	}
+	return inclusions, &trillian.SignedMapRoot{MapRoot: []byte("test-root"), Signature: []byte("test-signature")}, &types.MapRootV1{Revision: 2}, nil
+}
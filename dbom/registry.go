@@ -0,0 +1,257 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/opentracing/opentracing-go"
+)
+
+// sha256Size is the size of the indexes of the channel maps
+const sha256Size = 32
+
+// RegistryPageSize is the number of channel IDs appended to a page of the channel registry before a new page is started
+var RegistryPageSize = 100
+
+// RegistryIndex returns the index of the leaf holding the number of channels in each page of the channel registry
+func RegistryIndex() []byte {
+	return listIndex(-1)
+}
+
+// RegistryPageIndex returns the index of the leaf holding a page of the channel registry
+func RegistryPageIndex(page int64) []byte {
+	return listIndex(page)
+}
+
+// listIndex returns the index of a page of a list kept in a map, or of the head of the list if page is negative.
+// List indexes are all zero but for the page number in their last bytes, so that they cannot be the SHA-256 of a channel or record ID
+func listIndex(page int64) []byte {
+	index := make([]byte, sha256Size)
+	if page >= 0 {
+		binary.BigEndian.PutUint64(index[sha256Size-8:], uint64(page)+1)
+	}
+	return index
+}
+
+// prefixedIndex returns the index of a leaf kept for an ID besides the leaf of the channel or record with that ID.
+// Prefixes are bytes that never appear in valid UTF-8, which channel and record IDs are, so that the index cannot be the one of a channel or record, nor the one of an ID with another prefix
+func prefixedIndex(prefix byte, id string) []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte{prefix})
+	hasher.Write([]byte(id))
+	return hasher.Sum(nil)
+}
+
+// RegisterChannel reads the channel registry and returns the page a channel is appended to with the registry leaves to write with the channel
+func RegisterChannel(ctx context.Context, client *client.MapClient, channelID string, tracer opentracing.Tracer) (int64, []*trillian.MapLeaf, error) {
+	channelLogger.Info().Msg("[DBoM:RegisterChannel] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:RegisterChannel")
+	registry, _, err := readRegistry(ctx, client, -1, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return 0, nil, err
+	}
+
+	page := int64(len(registry.Pages)) - 1
+	var pageContent *models.ChannelRegistryPage
+	if page >= 0 && registry.Pages[page] < int64(RegistryPageSize) {
+		pages, _, err := readRegistryPages(ctx, client, []int64{page}, -1, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+			return 0, nil, err
+		}
+		pageContent = pages[0]
+	} else {
+		page++
+		registry.Pages = append(registry.Pages, 0)
+		pageContent = &models.ChannelRegistryPage{}
+	}
+	pageContent.ChannelIDs = append(pageContent.ChannelIDs, channelID)
+	registry.Pages[page] = int64(len(pageContent.ChannelIDs))
+
+	leaves, err := registryLeaves(registry, page, pageContent)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return 0, nil, err
+	}
+	channelLogger.Info().Msg("[DBoM:RegisterChannel] Finished")
+	span.Finish()
+	return page, leaves, nil
+}
+
+// UnregisterChannel reads the channel registry and returns the registry leaves to write to remove a channel from its page
+func UnregisterChannel(ctx context.Context, client *client.MapClient, channelID string, page int64, tracer opentracing.Tracer) ([]*trillian.MapLeaf, error) {
+	channelLogger.Info().Msg("[DBoM:UnregisterChannel] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:UnregisterChannel")
+	registry, _, err := readRegistry(ctx, client, -1, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	if page < 0 || page >= int64(len(registry.Pages)) {
+		channelLogger.Debug().Msgf("Channel %v is not in the registry", channelID)
+		span.Finish()
+		return nil, nil
+	}
+	pages, _, err := readRegistryPages(ctx, client, []int64{page}, -1, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	pageContent := pages[0]
+	channelIDs := make([]string, 0, len(pageContent.ChannelIDs))
+	for _, id := range pageContent.ChannelIDs {
+		if id != channelID {
+			channelIDs = append(channelIDs, id)
+		}
+	}
+	pageContent.ChannelIDs = channelIDs
+	registry.Pages[page] = int64(len(channelIDs))
+
+	leaves, err := registryLeaves(registry, page, pageContent)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	channelLogger.Info().Msg("[DBoM:UnregisterChannel] Finished")
+	span.Finish()
+	return leaves, nil
+}
+
+// ListChannels reads a page of the channel registry, with every leaf verified against the same map root of the channel config map.
+// The latest revision is read unless revision is positive
+func ListChannels(ctx context.Context, client *client.MapClient, offset int64, limit int64, revision int64, tracer opentracing.Tracer) (*models.ChannelListResponseDefinition, error) {
+	channelLogger.Info().Msg("[DBoM:ListChannels] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:ListChannels")
+	registry, mapRoot, err := readRegistry(ctx, client, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+
+	var total int64
+	var wanted []int64
+	var skipped int64
+	for page, count := range registry.Pages {
+		if count > 0 && total+count > offset && total < offset+limit {
+			if len(wanted) == 0 {
+				skipped = offset - total
+			}
+			wanted = append(wanted, int64(page))
+		}
+		total += count
+	}
+
+	channels := make([]string, 0)
+	if len(wanted) > 0 {
+		pages, _, err := readRegistryPages(ctx, client, wanted, int64(mapRoot.Revision), tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+		for _, page := range pages {
+			channels = append(channels, page.ChannelIDs...)
+		}
+		channels = channels[skipped:]
+		if int64(len(channels)) > limit {
+			channels = channels[:limit]
+		}
+	}
+
+	mapRevision := int64(mapRoot.Revision)
+	result := models.ChannelListResponseDefinition{
+		Channels: channels,
+		Offset:   &offset,
+		Total:    &total,
+		Revision: &mapRevision,
+	}
+	channelLogger.Debug().Msgf("Listed %v of %v channels at revision %v", len(channels), total, mapRevision)
+	channelLogger.Info().Msg("[DBoM:ListChannels] Finished")
+	span.Finish()
+	return &result, nil
+}
+
+// readRegistry reads the number of channels in each page of the channel registry, at the latest revision unless revision is positive
+func readRegistry(ctx context.Context, client *client.MapClient, revision int64, tracer opentracing.Tracer) (*models.ChannelRegistry, *types.MapRootV1, error) {
+	inclusions, mapRoot, err := readLeaves(ctx, client, [][]byte{RegistryIndex()}, revision, tracer)
+	if err != nil {
+		return nil, nil, err
+	}
+	var registry models.ChannelRegistry
+	if value := inclusions[0].GetLeaf().GetLeafValue(); len(value) > 0 {
+		if err := registry.UnmarshalBinary(value); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &registry, mapRoot, nil
+}
+
+// readRegistryPages reads pages of the channel registry, at the latest revision unless revision is positive
+func readRegistryPages(ctx context.Context, client *client.MapClient, pages []int64, revision int64, tracer opentracing.Tracer) ([]*models.ChannelRegistryPage, *types.MapRootV1, error) {
+	indexes := make([][]byte, len(pages))
+	for i, page := range pages {
+		indexes[i] = RegistryPageIndex(page)
+	}
+	inclusions, mapRoot, err := readLeaves(ctx, client, indexes, revision, tracer)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]*models.ChannelRegistryPage, len(inclusions))
+	for i, inclusion := range inclusions {
+		result[i] = &models.ChannelRegistryPage{}
+		if value := inclusion.GetLeaf().GetLeafValue(); len(value) > 0 {
+			if err := result[i].UnmarshalBinary(value); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return result, mapRoot, nil
+}
+
+// readLeaves reads leaves at the latest revision unless revision is positive
+func readLeaves(ctx context.Context, client *client.MapClient, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
+	if revision > 0 {
+		return getByRevision(client, ctx, indexes, revision, tracer)
+	}
+	return get(client, ctx, indexes, tracer)
+}
+
+// registryLeaves returns the leaves holding the registry and one of its pages
+func registryLeaves(registry *models.ChannelRegistry, page int64, pageContent *models.ChannelRegistryPage) ([]*trillian.MapLeaf, error) {
+	registryValue, err := registry.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	pageValue, err := pageContent.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return []*trillian.MapLeaf{
+		{Index: RegistryIndex(), LeafValue: registryValue},
+		{Index: RegistryPageIndex(page), LeafValue: pageValue},
+	}, nil
+}
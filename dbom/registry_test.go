@@ -0,0 +1,213 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// useStatefulMap reads, proves and writes maps from an in-memory map, with pages of two entries
+func useStatefulMap(t testing.TB, configMap *mock.StatefulMapMock) {
+	get = func(c *client.MapClient, ctx context.Context, indexes [][]byte, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
+		return getByRevision(c, ctx, indexes, configMap.Revision(), tracer)
+	}
+	getByRevision = func(c *client.MapClient, ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
+		leaves, _ := configMap.GetLeavesByRevision(ctx, &trillian.GetMapLeavesByRevisionRequest{Index: indexes, Revision: revision})
+		inclusions := make([]*trillian.MapLeafInclusion, len(leaves.Leaves))
+		for i, leaf := range leaves.Leaves {
+			inclusions[i] = &trillian.MapLeafInclusion{Leaf: leaf}
+		}
+		return inclusions, &types.MapRootV1{Revision: uint64(revision)}, nil
+	}
+	getProof = func(c *client.MapClient, ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *trillian.SignedMapRoot, *types.MapRootV1, error) {
+		if revision > configMap.Revision() {
+			return nil, nil, nil, status.Errorf(codes.NotFound, "revision %v not found", revision)
+		} else if revision <= 0 {
+			revision = configMap.Revision()
+		}
+		inclusions, mapRoot, err := getByRevision(c, ctx, indexes, revision, tracer)
+		mapRoot.RootHash = []byte(fmt.Sprintf("root-%v", revision))
+		return inclusions, &trillian.SignedMapRoot{MapRoot: []byte("test-root")}, mapRoot, err
+	}
+	add = func(c *client.Client, ctx context.Context, leaves []*trillian.MapLeaf, revision int64, tracer opentracing.Tracer) error {
+		_, err := configMap.WriteLeaves(ctx, &trillian.WriteMapLeavesRequest{Leaves: leaves, ExpectRevision: revision})
+		return err
+	}
+	registryPageSize, catalogPageSize := RegistryPageSize, CatalogPageSize
+	RegistryPageSize, CatalogPageSize = 2, 2
+	t.Cleanup(func() {
+		get = (*client.MapClient).Get
+		getByRevision = (*client.MapClient).GetByRevision
+		getProof = (*client.MapClient).GetProof
+		add = (*client.Client).Add
+		RegistryPageSize, CatalogPageSize = registryPageSize, catalogPageSize
+	})
+}
+
+func registerChannels(t *testing.T, ctx context.Context, configMap *mock.StatefulMapMock, channelIDs ...string) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	for _, channelID := range channelIDs {
+		_, leaves, err := RegisterChannel(ctx, nil, channelID, tracer)
+		assert.Nil(t, err)
+		_, err = configMap.WriteLeaves(ctx, &trillian.WriteMapLeavesRequest{Leaves: leaves, ExpectRevision: configMap.Revision() + 1})
+		assert.Nil(t, err)
+	}
+}
+
+//TestRegistryIndex tests that registry indexes are distinct from each other
+func TestRegistryIndex(t *testing.T) {
+	assert.Equal(t, 32, len(RegistryIndex()))
+	assert.NotEqual(t, RegistryIndex(), RegistryPageIndex(0))
+	assert.NotEqual(t, RegistryPageIndex(0), RegistryPageIndex(1))
+	assert.NotEqual(t, RegistryIndex(), ChannelIndex(""))
+}
+
+//TestPrefixedIndex tests that prefixed indexes are distinct from record indexes and from each other
+func TestPrefixedIndex(t *testing.T) {
+	assert.Equal(t, 32, len(prefixedIndex(whereUsedPrefix, "record")))
+	assert.NotEqual(t, RecordIndex("record"), prefixedIndex(whereUsedPrefix, "record"))
+	assert.NotEqual(t, prefixedIndex(whereUsedPrefix, "record"), prefixedIndex(0xfe, "record"))
+}
+
+//TestRegisterChannel tests appending channels to the pages of the registry
+func TestRegisterChannel(t *testing.T) {
+	configMap := mock.NewStatefulMapMock()
+	useStatefulMap(t, configMap)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	registerChannels(t, ctx, configMap, "channel-0", "channel-1")
+	page, leaves, err := RegisterChannel(ctx, nil, "channel-2", tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), page)
+	assert.Equal(t, 2, len(leaves))
+
+	var registry models.ChannelRegistry
+	assert.Nil(t, registry.UnmarshalBinary(leaves[0].LeafValue))
+	assert.Equal(t, []int64{2, 1}, registry.Pages)
+	var registryPage models.ChannelRegistryPage
+	assert.Nil(t, registryPage.UnmarshalBinary(leaves[1].LeafValue))
+	assert.Equal(t, []string{"channel-2"}, registryPage.ChannelIDs)
+}
+
+//TestUnregisterChannel tests removing a channel from its page of the registry
+func TestUnregisterChannel(t *testing.T) {
+	configMap := mock.NewStatefulMapMock()
+	useStatefulMap(t, configMap)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	registerChannels(t, ctx, configMap, "channel-0", "channel-1", "channel-2")
+	leaves, err := UnregisterChannel(ctx, nil, "channel-0", 0, tracer)
+	assert.Nil(t, err)
+	_, err = configMap.WriteLeaves(ctx, &trillian.WriteMapLeavesRequest{Leaves: leaves, ExpectRevision: configMap.Revision() + 1})
+	assert.Nil(t, err)
+
+	result, err := ListChannels(ctx, nil, 0, 10, -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"channel-1", "channel-2"}, result.Channels)
+	assert.Equal(t, int64(2), *result.Total)
+
+	leaves, err = UnregisterChannel(ctx, nil, "channel-0", 5, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, leaves)
+}
+
+//TestListChannels tests paging through the registry at the latest and at an earlier revision
+func TestListChannels(t *testing.T) {
+	configMap := mock.NewStatefulMapMock()
+	useStatefulMap(t, configMap)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		registerChannels(t, ctx, configMap, fmt.Sprintf("channel-%v", i))
+	}
+
+	result, err := ListChannels(ctx, nil, 1, 3, -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"channel-1", "channel-2", "channel-3"}, result.Channels)
+	assert.Equal(t, int64(5), *result.Total)
+	assert.Equal(t, int64(1), *result.Offset)
+	assert.Equal(t, int64(5), *result.Revision)
+
+	result, err = ListChannels(ctx, nil, 4, 3, -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"channel-4"}, result.Channels)
+
+	result, err = ListChannels(ctx, nil, 10, 3, -1, tracer)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Channels)
+
+	result, err = ListChannels(ctx, nil, 0, 10, 2, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"channel-0", "channel-1"}, result.Channels)
+	assert.Equal(t, int64(2), *result.Total)
+	assert.Equal(t, int64(2), *result.Revision)
+}
+
+//TestCreateChannelRegistry tests that a channel is written with the registry in one revision
+func TestCreateChannelRegistry(t *testing.T) {
+	configMap := mock.NewStatefulMapMock()
+	useStatefulMap(t, configMap)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	err := CreateChannel(ctx, nil, nil, 1, 1, "channel-0", 3513, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), configMap.Revision())
+
+	var channel models.Channel
+	assert.Nil(t, channel.UnmarshalBinary(configMap.Leaf(ChannelIndex("channel-0"), -1)))
+	assert.Equal(t, int64(0), *channel.RegistryPage)
+
+	_, err = DeleteChannel(ctx, mock.NewTrillianAdminMockClient(nil, false, false), nil, nil, 2, 1, &channel, tracer)
+	assert.Nil(t, err)
+	result, err := ListChannels(ctx, nil, 0, 10, -1, tracer)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Channels)
+	assert.Equal(t, int64(2), *result.Revision)
+}
+
+//TestListChannelsError tests an error reading the registry
+func TestListChannelsError(t *testing.T) {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	get = getErrorMock
+	defer func() { get = (*client.MapClient).Get }()
+	_, err := ListChannels(context.Background(), nil, 0, 10, -1, tracer)
+	assert.Error(t, err)
+
+	_, _, err = RegisterChannel(context.Background(), nil, "channel-0", tracer)
+	assert.Error(t, err)
+
+	_, err = UnregisterChannel(context.Background(), nil, "channel-0", 0, tracer)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"fmt"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+)
+
+// schemaPrefix is the index prefix of the versions of the schema of a channel
+const schemaPrefix byte = 0xfc
+
+// ChannelSchemaIndex returns the index of the leaf holding a version of the schema of a channel in the channel config map
+func ChannelSchemaIndex(channelID string, version int64) []byte {
+	return prefixedIndex(schemaPrefix, fmt.Sprintf("%d:%v", version, channelID))
+}
+
+// clearedSchemaLeaves returns the leaves clearing every version of the schema of a channel, so that the versions are not inherited by a channel created again with the same ID
+func clearedSchemaLeaves(channel *models.Channel) []*trillian.MapLeaf {
+	leaves := make([]*trillian.MapLeaf, 0, channel.SchemaVersion)
+	for version := int64(1); version <= channel.SchemaVersion; version++ {
+		leaves = append(leaves, &trillian.MapLeaf{Index: ChannelSchemaIndex(channel.ChannelID, version)})
+	}
+	return leaves
+}
+
+// UpdateChannelSchema writes a channel with a new version of its schema to the channel config map, keeping the version in its own leaf so that it can still be read once it is replaced
+func UpdateChannelSchema(ctx context.Context, trillMapWriteClient trillian.TrillianMapWriteClient, revision int64, channelMapID int64, channel *models.Channel, tracer opentracing.Tracer) error {
+	channelLogger.Info().Msg("[DBoM:UpdateChannelSchema] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:UpdateChannelSchema")
+	val, err := channel.MarshalBinary()
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
+	schema := models.ChannelSchema{ChannelID: channel.ChannelID, Version: channel.SchemaVersion, Schema: channel.Schema}
+	schemaVal, err := schema.MarshalBinary()
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
+	leaves := []*trillian.MapLeaf{
+		{Index: ChannelIndex(channel.ChannelID), LeafValue: val},
+		{Index: ChannelSchemaIndex(channel.ChannelID, channel.SchemaVersion), LeafValue: schemaVal},
+	}
+	err = add(client.NewClient(trillMapWriteClient, channelMapID), ctx, leaves, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	channelLogger.Info().Msg("[DBoM:UpdateChannelSchema] Finished")
+	span.Finish()
+	return nil
+}
+
+// GetChannelSchema gets a version of the schema of a channel from trillian, or nil if the channel has no such version
+func GetChannelSchema(ctx context.Context, client *client.MapClient, channelID string, version int64, tracer opentracing.Tracer) (*models.ChannelSchema, error) {
+	channelLogger.Info().Msg("[DBoM:GetChannelSchema] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetChannelSchema")
+	inclusions, _, err := get(client, ctx, [][]byte{ChannelSchemaIndex(channelID, version)}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	value := inclusions[0].GetLeaf().GetLeafValue()
+	if len(value) == 0 {
+		channelLogger.Debug().Msgf("Channel %v has no schema version %v", channelID, version)
+		span.Finish()
+		return nil, nil
+	}
+	var result models.ChannelSchema
+	if err := result.UnmarshalBinary(value); err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+
+	channelLogger.Info().Msg("[DBoM:GetChannelSchema] Finished")
+	span.Finish()
+	return &result, nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"testing"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//TestChannelSchema tests writing versions of the schema of a channel and reading them back once replaced
+func TestChannelSchema(t *testing.T) {
+	configMap := mock.NewStatefulMapMock()
+	useStatefulMap(t, configMap)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	channel := &models.Channel{ChannelID: "test-channel", MapID: 1654}
+	for version := int64(1); version <= 2; version++ {
+		channel.SchemaVersion = version
+		channel.Schema = map[string]interface{}{"maxProperties": float64(version)}
+		assert.Nil(t, UpdateChannelSchema(ctx, nil, version, 1, channel, tracer))
+	}
+
+	found, err := GetChannel(ctx, nil, "test-channel", tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), found.SchemaVersion)
+	assert.Equal(t, map[string]interface{}{"maxProperties": float64(2)}, found.Schema)
+
+	schema, err := GetChannelSchema(ctx, nil, "test-channel", 1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, models.ChannelSchema{ChannelID: "test-channel", Version: 1, Schema: map[string]interface{}{"maxProperties": float64(1)}}, *schema)
+	schema, err = GetChannelSchema(ctx, nil, "test-channel", 2, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), schema.Version)
+	schema, err = GetChannelSchema(ctx, nil, "test-channel", 3, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, schema)
+
+	add = addErrorMock
+	assert.Error(t, UpdateChannelSchema(ctx, nil, 3, 1, channel, tracer))
+}
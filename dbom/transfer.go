@@ -0,0 +1,122 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/opentracing/opentracing-go"
+)
+
+// ErrNotTransferredOut is returned when the source of a transfer is not the TRANSFER-OUT revision of the record
+var ErrNotTransferredOut = errors.New(responses.NotTransferredOut)
+
+// TransferOutRecord stages the TRANSFER-OUT revision of a record, which keeps the payload of its previous revision and names the channel the record is transferred to
+func TransferOutRecord(ctx context.Context, batch *Batch, channelID string, commitType string, recordID string, targetChannelID string, tracer opentracing.Tracer) error {
+	recordLogger.Info().Msg("[DBoM:TransferOutRecord] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:TransferOutRecord")
+
+	previous, err := batchRecord(ctx, batch, recordID)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	} else if previous == nil {
+		err := fmt.Errorf("%v: %v", responses.ResourceNotFound, recordID)
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.ResourceNotFound)
+		return err
+	}
+	record := newRecord(batch, previous, previous.Revision, channelID, commitType, recordID, previous.Payload)
+	record.TransferTargetChannelID = targetChannelID
+	if err := stageRecord(ctx, batch, record); err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	recordLogger.Info().Msg("[DBoM:TransferOutRecord] Finished")
+	span.Finish()
+	return nil
+}
+
+// GetTransferSource gets the TRANSFER-OUT revision of a record with the root hash of the channel map at that revision, which links the TRANSFER-IN commit of the record to it.
+// It returns ErrNotTransferredOut if the record was not transferred out of the channel at the revision
+func GetTransferSource(ctx context.Context, client *client.MapClient, channelID string, recordID string, revision int64, tracer opentracing.Tracer) (*models.TransferSourceDefinition, *models.Record, error) {
+	recordLogger.Info().Msg("[DBoM:GetTransferSource] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetTransferSource")
+
+	inclusions, _, mapRoot, err := getProof(client, ctx, [][]byte{RecordIndex(recordID)}, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, nil, err
+	}
+	value := inclusions[0].GetLeaf().GetLeafValue()
+	if len(value) == 0 {
+		tracing.LogAndTraceErr(recordLogger, span, nil, responses.ResourceNotFound)
+		return nil, nil, nil
+	}
+	var record models.Record
+	if err := record.UnmarshalBinary(value); err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, nil, err
+	}
+	if record.Revision != revision || record.TransferTargetChannelID == "" {
+		err := fmt.Errorf("%w: %v at revision %v of %v", ErrNotTransferredOut, recordID, revision, channelID)
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.NotTransferredOut)
+		return nil, nil, err
+	}
+
+	mapRootHash := strfmt.Base64(mapRoot.RootHash)
+	source := &models.TransferSourceDefinition{ChannelID: &channelID, Revision: &revision, MapRootHash: &mapRootHash}
+	recordLogger.Debug().Msgf("Retrieved transfer of asset %v out of %v at revision %v", recordID, channelID, revision)
+
+	recordLogger.Info().Msg("[DBoM:GetTransferSource] Finished")
+	span.Finish()
+	return source, &record, nil
+}
+
+// TransferInRecord stages the TRANSFER-IN revision of a record transferred from another channel, which has the payload of its TRANSFER-OUT revision and links back to it.
+// A record transferred back into a channel it was transferred out of keeps its place in the record catalog, while its history starts over from the TRANSFER-IN revision
+func TransferInRecord(ctx context.Context, batch *Batch, channelID string, commitType string, source *models.TransferSourceDefinition, transferred *models.Record, tracer opentracing.Tracer) error {
+	recordLogger.Info().Msg("[DBoM:TransferInRecord] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:TransferInRecord")
+
+	previous, err := batchRecord(ctx, batch, *transferred.ResourceID)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+	record := newRecord(batch, nil, 0, channelID, commitType, *transferred.ResourceID, transferred.Payload)
+	record.TransferSource = source
+	if previous != nil {
+		record.CatalogPage = previous.CatalogPage
+	}
+	if err := stageRecord(ctx, batch, record); err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return err
+	}
+
+	recordLogger.Info().Msg("[DBoM:TransferInRecord] Finished")
+	span.Finish()
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// transferOut commits the TRANSFER-OUT revision of a record to an in-memory map through a coordinator
+func transferOut(t *testing.T, fake *mock.StatefulMapMock, recordID string, targetChannelID string) error {
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	coordinator := NewCoordinator(0, time.Millisecond, 100)
+	return coordinator.Stage(context.Background(), "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{RecordIndex(recordID)}, func(ctx context.Context, batch *Batch) error {
+		return TransferOutRecord(ctx, batch, "test-channel", "TRANSFER-OUT", recordID, targetChannelID, tracer)
+	}, tracer)
+}
+
+//TestTransferRecord tests transferring a record out of a channel and into another one linked to its TRANSFER-OUT revision
+func TestTransferRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	commitRecords(t, fake, "CREATE", 0, "test-record")
+
+	assert.Nil(t, transferOut(t, fake, "test-record", "target-channel"))
+	record := latestRecord(t, fake, "test-record")
+	assert.Equal(t, "TRANSFER-OUT", *record.EventType)
+	assert.Equal(t, "target-channel", record.TransferTargetChannelID)
+	assert.Equal(t, int64(1), record.PreviousRevision)
+	assert.NotNil(t, record.Payload)
+
+	source, transferred, err := GetTransferSource(ctx, &client.MapClient{}, "test-channel", "test-record", 2, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-channel", *source.ChannelID)
+	assert.Equal(t, int64(2), *source.Revision)
+	assert.Equal(t, []byte("root-2"), []byte(*source.MapRootHash))
+
+	target := mock.NewStatefulMapMock()
+	coordinator := NewCoordinator(0, time.Millisecond, 100)
+	err = coordinator.Stage(ctx, "channel:target-channel", statefulMapWriter(target, tracer), [][]byte{RecordIndex("test-record")}, func(ctx context.Context, batch *Batch) error {
+		return TransferInRecord(ctx, batch, "target-channel", "TRANSFER-IN", source, transferred, tracer)
+	}, tracer)
+	assert.Nil(t, err)
+	var transferredIn models.Record
+	assert.Nil(t, transferredIn.UnmarshalBinary(target.Leaf(RecordIndex("test-record"), -1)))
+	assert.Equal(t, "TRANSFER-IN", *transferredIn.EventType)
+	assert.Equal(t, "target-channel", *transferredIn.ChannelID)
+	assert.Equal(t, int64(0), transferredIn.PreviousRevision)
+	assert.Equal(t, source, transferredIn.TransferSource)
+	assert.Equal(t, record.Payload, transferredIn.Payload)
+}
+
+//TestTransferRecordBackIn tests transferring a record back into the channel it was transferred out of, where it keeps its place in the catalog and its history starts over
+func TestTransferRecordBackIn(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	commitRecords(t, fake, "CREATE", 0, "test-record")
+	assert.Nil(t, transferOut(t, fake, "test-record", "target-channel"))
+	_, transferred, err := GetTransferSource(ctx, &client.MapClient{}, "test-channel", "test-record", 2, tracer)
+	assert.Nil(t, err)
+
+	channelID, revision := "target-channel", int64(2)
+	source := &models.TransferSourceDefinition{ChannelID: &channelID, Revision: &revision}
+	coordinator := NewCoordinator(0, time.Millisecond, 100)
+	err = coordinator.Stage(ctx, "channel:test-channel", statefulMapWriter(fake, tracer), [][]byte{RecordIndex("test-record")}, func(ctx context.Context, batch *Batch) error {
+		return TransferInRecord(ctx, batch, "test-channel", "TRANSFER-IN", source, transferred, tracer)
+	}, tracer)
+	assert.Nil(t, err)
+
+	var page models.RecordCatalogPage
+	assert.Nil(t, page.UnmarshalBinary(fake.Leaf(RecordCatalogPageIndex(0), -1)))
+	assert.Equal(t, 1, len(page.Records))
+	assert.Equal(t, "TRANSFER-IN", *page.Records[0].EventType)
+
+	history, err := GetRecordHistory(ctx, nil, "test-record", -1, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{3}, historyRevisions(history))
+	assert.Equal(t, source, history[0].TransferSource)
+	history, err = GetRecordHistory(ctx, nil, "test-record", 2, 10, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{2, 1}, historyRevisions(history))
+}
+
+//TestGetTransferSourceNotTransferred tests getting the transfer of a record at a revision that did not transfer it out
+func TestGetTransferSourceNotTransferred(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+	commitRecords(t, fake, "CREATE", 0, "test-record", "other-record")
+	assert.Nil(t, transferOut(t, fake, "test-record", "target-channel"))
+
+	_, _, err := GetTransferSource(ctx, &client.MapClient{}, "test-channel", "other-record", 3, tracer)
+	assert.True(t, errors.Is(err, ErrNotTransferredOut))
+	_, _, err = GetTransferSource(ctx, &client.MapClient{}, "test-channel", "test-record", 1, tracer)
+	assert.True(t, errors.Is(err, ErrNotTransferredOut))
+
+	source, transferred, err := GetTransferSource(ctx, &client.MapClient{}, "test-channel", "random-record", 3, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, source)
+	assert.Nil(t, transferred)
+}
+
+//TestTransferOutMissingRecord tests transferring out a record that does not exist
+func TestTransferOutMissingRecord(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	commitRecords(t, fake, "CREATE", 0, "test-record")
+
+	assert.Error(t, transferOut(t, fake, "random-record", "target-channel"))
+	assert.Equal(t, int64(1), fake.Revision())
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"fmt"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+)
+
+// treeNode is a node of a record tree that is resolved with the other nodes of its level
+type treeNode struct {
+	node *models.RecordTreeNodeDefinition
+	path []string
+}
+
+// treeLeaf is a record read for a record tree together with its proof, if proofs are requested
+type treeLeaf struct {
+	record *models.Record
+	proof  *models.ProofBundleDefinition
+}
+
+// ResolveRecordTree resolves the records attached to a record recursively, up to depth levels below it, at a revision of the channel map or at the latest one.
+// Every level of the tree is read in one request at the same revision. The proof of every record is included when the tree of the map is set.
+// A record that is one of its own ancestors is marked as a cycle and reported with the path leading to it, without resolving its attached records again
+func ResolveRecordTree(ctx context.Context, client *client.MapClient, tree *trillian.Tree, channelID string, recordID string, depth int64, revision int64, tracer opentracing.Tracer) (*models.RecordTreeDefinition, error) {
+	recordLogger.Info().Msg("[DBoM:ResolveRecordTree] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:ResolveRecordTree")
+
+	root := &models.RecordTreeNodeDefinition{RecordID: &recordID}
+	result := &models.RecordTreeDefinition{Root: root, Cycles: [][]string{}}
+	level := []*treeNode{{node: root, path: []string{recordID}}}
+	for d := int64(0); len(level) > 0; d++ {
+		leaves, mapRevision, err := readTreeLevel(ctx, client, tree, channelID, level, revision, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+		revision = mapRevision
+
+		var next []*treeNode
+		for _, n := range level {
+			leaf := leaves[*n.node.RecordID]
+			if leaf == nil && n.node == root {
+				tracing.LogAndTraceErr(recordLogger, span, nil, responses.ResourceNotFound)
+				return nil, nil
+			} else if leaf == nil {
+				err := fmt.Errorf("%v: %v is attached to %v", responses.ResourceNotFound, *n.node.RecordID, n.path[len(n.path)-2])
+				tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+				return nil, err
+			}
+			n.node.Revision = &leaf.record.Revision
+			n.node.EventType = leaf.record.EventType
+			n.node.Payload = leaf.record.Payload
+			n.node.Proof = leaf.proof
+			if n.node.Cycle || len(leaf.record.ChildRecordIDs) == 0 {
+				continue
+			}
+			if d == depth {
+				n.node.Truncated = true
+				continue
+			}
+			for _, childID := range leaf.record.ChildRecordIDs {
+				childID := childID
+				child := &models.RecordTreeNodeDefinition{RecordID: &childID}
+				path := append(append([]string(nil), n.path...), childID)
+				for _, ancestorID := range n.path {
+					if ancestorID == childID {
+						child.Cycle = true
+						result.Cycles = append(result.Cycles, path)
+						break
+					}
+				}
+				n.node.Children = append(n.node.Children, child)
+				next = append(next, &treeNode{node: child, path: path})
+			}
+		}
+		level = next
+	}
+	result.Revision = &revision
+	recordLogger.Debug().Msgf("Resolved tree of asset %v at revision %v with %v cycles", recordID, revision, len(result.Cycles))
+
+	recordLogger.Info().Msg("[DBoM:ResolveRecordTree] Finished")
+	span.Finish()
+	return result, nil
+}
+
+// readTreeLevel reads the records of a level of a record tree at a revision of the channel map, or at the latest one, and returns them with the revision they were read at
+func readTreeLevel(ctx context.Context, client *client.MapClient, tree *trillian.Tree, channelID string, level []*treeNode, revision int64, tracer opentracing.Tracer) (map[string]*treeLeaf, int64, error) {
+	var recordIDs []string
+	var indexes [][]byte
+	seen := make(map[string]bool)
+	for _, n := range level {
+		if !seen[*n.node.RecordID] {
+			seen[*n.node.RecordID] = true
+			recordIDs = append(recordIDs, *n.node.RecordID)
+			indexes = append(indexes, RecordIndex(*n.node.RecordID))
+		}
+	}
+	inclusions, signedRoot, mapRoot, err := getProof(client, ctx, indexes, revision, tracer)
+	if err != nil {
+		return nil, 0, err
+	}
+	byIndex := make(map[string]*trillian.MapLeafInclusion)
+	for _, inclusion := range inclusions {
+		byIndex[string(inclusion.GetLeaf().GetIndex())] = inclusion
+	}
+
+	leaves := make(map[string]*treeLeaf)
+	for i, recordID := range recordIDs {
+		inclusion := byIndex[string(indexes[i])]
+		if len(inclusion.GetLeaf().GetLeafValue()) == 0 {
+			continue
+		}
+		var record models.Record
+		if err := record.UnmarshalBinary(inclusion.GetLeaf().GetLeafValue()); err != nil {
+			return nil, 0, err
+		}
+		leaf := &treeLeaf{record: &record}
+		if tree != nil {
+			leaf.proof = proofBundle(tree, channelID, recordID, inclusion, signedRoot, mapRoot)
+		}
+		leaves[recordID] = leaf
+	}
+	return leaves, int64(mapRoot.Revision), nil
+}
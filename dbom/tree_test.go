@@ -0,0 +1,156 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"testing"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assemble commits a product with two assemblies, the first of which has a part
+func assemble(t *testing.T, fake *mock.StatefulMapMock) {
+	commitRecords(t, fake, "CREATE", 0, "product", "assembly-1", "assembly-2", "part")
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("assembly-1", "product", "assembly-1")))
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("assembly-2", "product", "assembly-2")))
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("part", "assembly-1", "part")))
+}
+
+func childIDs(node *models.RecordTreeNodeDefinition) []string {
+	var recordIDs []string
+	for _, child := range node.Children {
+		recordIDs = append(recordIDs, *child.RecordID)
+	}
+	return recordIDs
+}
+
+//TestResolveRecordTree tests resolving the whole tree of a record at the latest revision
+func TestResolveRecordTree(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	assemble(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 10, -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), *result.Revision)
+	assert.Empty(t, result.Cycles)
+	assert.Equal(t, int64(6), *result.Root.Revision)
+	assert.Equal(t, []string{"assembly-1", "assembly-2"}, childIDs(result.Root))
+	assembly := result.Root.Children[0]
+	assert.Equal(t, int64(7), *assembly.Revision)
+	assert.Equal(t, "ATTACH", *assembly.EventType)
+	assert.Equal(t, []string{"part"}, childIDs(assembly))
+	assert.Empty(t, assembly.Children[0].Children)
+	assert.False(t, assembly.Truncated)
+	assert.Nil(t, assembly.Proof)
+}
+
+//TestResolveRecordTreeDepth tests that records below the depth limit are not resolved
+func TestResolveRecordTreeDepth(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	assemble(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 1, -1, tracer)
+	assert.Nil(t, err)
+	assert.True(t, result.Root.Children[0].Truncated)
+	assert.Empty(t, result.Root.Children[0].Children)
+	assert.False(t, result.Root.Children[1].Truncated)
+
+	result, err = ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 0, -1, tracer)
+	assert.Nil(t, err)
+	assert.True(t, result.Root.Truncated)
+	assert.Empty(t, result.Root.Children)
+}
+
+//TestResolveRecordTreeRevision tests resolving the tree of a record as of an earlier revision and a revision that does not exist
+func TestResolveRecordTreeRevision(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	assemble(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 10, 5, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), *result.Revision)
+	assert.Equal(t, []string{"assembly-1"}, childIDs(result.Root))
+	assert.Empty(t, result.Root.Children[0].Children)
+
+	result, err = ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 10, 3, tracer)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Root.Children)
+
+	_, err = ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 10, 8, tracer)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+//TestResolveRecordTreeProofs tests including the proof of every record of a tree, at the revision the tree is resolved at
+func TestResolveRecordTreeProofs(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	assemble(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ResolveRecordTree(context.Background(), &client.MapClient{}, &trillian.Tree{TreeId: 651}, "test-channel", "product", 10, -1, tracer)
+	assert.Nil(t, err)
+	part := result.Root.Children[0].Children[0]
+	assert.Equal(t, "part", *part.Proof.RecordID)
+	assert.Equal(t, int64(7), *part.Proof.Revision)
+	assert.Equal(t, RecordIndex("part"), []byte(*part.Proof.LeafIndex))
+	assert.Equal(t, fake.Leaf(RecordIndex("part"), 7), []byte(*part.Proof.LeafValue))
+	assert.Equal(t, []byte("test-root"), []byte(*part.Proof.SignedMapRoot.MapRoot))
+}
+
+//TestResolveRecordTreeCycle tests that a record attached below itself is reported as a cycle
+func TestResolveRecordTreeCycle(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	assemble(t, fake)
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("product", "part", "product")))
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "product", 10, -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"product", "assembly-1", "part", "product"}}, result.Cycles)
+	cycle := result.Root.Children[0].Children[0].Children[0]
+	assert.True(t, cycle.Cycle)
+	assert.Equal(t, "product", *cycle.RecordID)
+	assert.Empty(t, cycle.Children)
+}
+
+//TestResolveRecordTreeNotFound tests resolving the tree of a missing record
+func TestResolveRecordTreeNotFound(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	assemble(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+
+	result, err := ResolveRecordTree(context.Background(), &client.MapClient{}, nil, "test-channel", "random-record", 10, -1, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+)
+
+// whereUsedPrefix is the index prefix of the parents of a record
+const whereUsedPrefix byte = 0xff
+
+// WhereUsedIndex returns the index of the leaf holding the parents a record is or was attached to in the channel map
+func WhereUsedIndex(recordID string) []byte {
+	return prefixedIndex(whereUsedPrefix, recordID)
+}
+
+// whereUsedLeaf returns the leaf holding the parents of a child record once it is attached to or detached from a parent at the revision of the batch.
+// Detaching closes the attachment to the parent that is still open
+func whereUsedLeaf(ctx context.Context, batch *Batch, parentID string, childID string, attach bool) (*trillian.MapLeaf, error) {
+	var whereUsed models.WhereUsed
+	if err := readBatchLeaf(ctx, batch, WhereUsedIndex(childID), &whereUsed); err != nil {
+		return nil, err
+	}
+	revision := batch.Revision()
+	if attach {
+		whereUsed.Parents = append(whereUsed.Parents, &models.WhereUsedParentDefinition{ParentRecordID: &parentID, AttachRevision: &revision})
+	} else {
+		for i := len(whereUsed.Parents) - 1; i >= 0; i-- {
+			parent := whereUsed.Parents[i]
+			if *parent.ParentRecordID == parentID && parent.DetachRevision == 0 {
+				parent.DetachRevision = revision
+				break
+			}
+		}
+	}
+	val, err := whereUsed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.MapLeaf{Index: WhereUsedIndex(childID), LeafValue: val}, nil
+}
+
+// GetWhereUsed gets every parent a record is or was attached to, with the revisions it was attached and detached at, from trillian
+func GetWhereUsed(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.WhereUsedResponseDefinition, error) {
+	recordLogger.Info().Msg("[DBoM:GetWhereUsed] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "DBoM:GetWhereUsed")
+
+	indexes := [][]byte{
+		RecordIndex(recordID),
+		WhereUsedIndex(recordID),
+	}
+	inclusions, mapRoot, err := readLeaves(ctx, client, indexes, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+		return nil, err
+	}
+	if len(inclusions[0].GetLeaf().GetLeafValue()) == 0 {
+		tracing.LogAndTraceErr(recordLogger, span, nil, responses.ResourceNotFound)
+		return nil, nil
+	}
+	var whereUsed models.WhereUsed
+	if value := inclusions[1].GetLeaf().GetLeafValue(); len(value) > 0 {
+		if err := whereUsed.UnmarshalBinary(value); err != nil {
+			tracing.LogAndTraceErr(recordLogger, span, err, responses.InternalError)
+			return nil, err
+		}
+	}
+	if whereUsed.Parents == nil {
+		whereUsed.Parents = []*models.WhereUsedParentDefinition{}
+	}
+
+	mapRevision := int64(mapRoot.Revision)
+	result := &models.WhereUsedResponseDefinition{RecordID: &recordID, Revision: &mapRevision, Parents: whereUsed.Parents}
+	recordLogger.Debug().Msgf("Retrieved %v parents of asset %v at revision %v", len(whereUsed.Parents), recordID, mapRevision)
+
+	recordLogger.Info().Msg("[DBoM:GetWhereUsed] Finished")
+	span.Finish()
+	return result, nil
+}
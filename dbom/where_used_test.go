@@ -0,0 +1,106 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package dbom
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parentIDs(parents []*models.WhereUsedParentDefinition) []string {
+	recordIDs := []string{}
+	for _, parent := range parents {
+		recordIDs = append(recordIDs, *parent.ParentRecordID)
+	}
+	return recordIDs
+}
+
+//TestWhereUsedIndex tests that the parents of a record are not kept at the index of a record
+func TestWhereUsedIndex(t *testing.T) {
+	assert.NotEqual(t, RecordIndex("test-record"), WhereUsedIndex("test-record"))
+	assert.Equal(t, sha256.Size, len(WhereUsedIndex("test-record")))
+	assert.NotEqual(t, WhereUsedIndex("test-record"), WhereUsedIndex("other-record"))
+}
+
+//TestGetWhereUsed tests listing the parents a record is and was attached to, with the revisions of the attachments
+func TestGetWhereUsed(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	commitRecords(t, fake, "CREATE", 0, "assembly-1", "assembly-2", "part")
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("part", "assembly-1", "part")))
+	assert.Nil(t, relate(t, fake, "DETACH", attachment("part", "assembly-1", "part")))
+	assert.Nil(t, relate(t, fake, "ATTACH", attachment("assembly-2", "assembly-2", "part")))
+
+	result, err := GetWhereUsed(context.Background(), &client.MapClient{}, "part", -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, "part", *result.RecordID)
+	assert.Equal(t, int64(6), *result.Revision)
+	assert.Equal(t, []string{"assembly-1", "assembly-2"}, parentIDs(result.Parents))
+	assert.Equal(t, int64(4), *result.Parents[0].AttachRevision)
+	assert.Equal(t, int64(5), result.Parents[0].DetachRevision)
+	assert.Equal(t, int64(6), *result.Parents[1].AttachRevision)
+	assert.Equal(t, int64(0), result.Parents[1].DetachRevision)
+
+	result, err = GetWhereUsed(context.Background(), &client.MapClient{}, "part", 4, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"assembly-1"}, parentIDs(result.Parents))
+	assert.Equal(t, int64(0), result.Parents[0].DetachRevision)
+}
+
+//TestGetWhereUsedReattach tests that attaching a record to the same parent again is listed as another attachment
+func TestGetWhereUsedReattach(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	commitRecords(t, fake, "CREATE", 0, "assembly", "part")
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, relate(t, fake, "ATTACH", attachment("part", "assembly", "part")))
+		assert.Nil(t, relate(t, fake, "DETACH", attachment("part", "assembly", "part")))
+	}
+
+	result, err := GetWhereUsed(context.Background(), &client.MapClient{}, "part", -1, tracer)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"assembly", "assembly"}, parentIDs(result.Parents))
+	assert.Equal(t, int64(5), *result.Parents[1].AttachRevision)
+	assert.Equal(t, int64(6), result.Parents[1].DetachRevision)
+}
+
+//TestGetWhereUsedNotAttached tests listing the parents of a record that was never attached and of a missing record
+func TestGetWhereUsedNotAttached(t *testing.T) {
+	fake := mock.NewStatefulMapMock()
+	useStatefulMap(t, fake)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	commitRecords(t, fake, "CREATE", 0, "part")
+
+	result, err := GetWhereUsed(context.Background(), &client.MapClient{}, "part", -1, tracer)
+	assert.Nil(t, err)
+	assert.NotNil(t, result.Parents)
+	assert.Empty(t, result.Parents)
+
+	result, err = GetWhereUsed(context.Background(), &client.MapClient{}, "random-record", -1, tracer)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
@@ -17,7 +17,10 @@
 
 package helpers
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 const defaultPort = "5000"
 
@@ -35,3 +38,12 @@ func GetEnv(key, fallback string) string {
 	}
 	return value
 }
+
+//GetEnvDuration gets the value of an environmental variable as a duration if it exists and is valid otherwise it returns the default value
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -19,9 +19,16 @@ package mock
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"errors"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
 	"google.golang.org/grpc"
 )
 
@@ -48,7 +55,7 @@ func (c *trillianAdminMockClient) GetTree(ctx context.Context, in *trillian.GetT
 	if c.getTreeError {
 		return nil, errors.New("Get Tree Error")
 	}
-	return &trillian.Tree{TreeId: 651}, nil
+	return mapTree(in.TreeId), nil
 }
 
 func (c *trillianAdminMockClient) CreateTree(ctx context.Context, in *trillian.CreateTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
@@ -68,19 +75,54 @@ func (c *trillianAdminMockClient) UpdateTree(ctx context.Context, in *trillian.U
 }
 
 func (c *trillianAdminMockClient) DeleteTree(ctx context.Context, in *trillian.DeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
-	out := new(trillian.Tree)
-	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/DeleteTree", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if in.TreeId == DeleteTreeErrorID {
+		return nil, errors.New("Delete Tree Error")
 	}
+	deletedTrees = append(deletedTrees, in.TreeId)
+	out := mapTree(in.TreeId)
+	out.Deleted = true
+	out.DeleteTime = ptypes.TimestampNow()
 	return out, nil
 }
 
 func (c *trillianAdminMockClient) UndeleteTree(ctx context.Context, in *trillian.UndeleteTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
-	out := new(trillian.Tree)
-	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/UndeleteTree", in, out, opts...)
-	if err != nil {
-		return nil, err
+	undeletedTrees = append(undeletedTrees, in.TreeId)
+	return mapTree(in.TreeId), nil
+}
+
+// DeleteTreeErrorID is the ID of a tree the mock admin client fails to delete
+const DeleteTreeErrorID = 4041
+
+var deletedTrees []int64
+var undeletedTrees []int64
+
+// DeletedTrees returns the IDs of the trees deleted by mock admin clients since the last call
+func DeletedTrees() []int64 {
+	trees := deletedTrees
+	deletedTrees = nil
+	return trees
+}
+
+// UndeletedTrees returns the IDs of the trees restored by mock admin clients since the last call
+func UndeletedTrees() []int64 {
+	trees := undeletedTrees
+	undeletedTrees = nil
+	return trees
+}
+
+var mapKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+// mapTree returns an active map tree signed with a test key
+func mapTree(treeID int64) *trillian.Tree {
+	der, _ := x509.MarshalPKIXPublicKey(mapKey.Public())
+	return &trillian.Tree{
+		TreeId:             treeID,
+		TreeState:          trillian.TreeState_ACTIVE,
+		TreeType:           trillian.TreeType_MAP,
+		HashStrategy:       trillian.HashStrategy_CONIKS_SHA256,
+		HashAlgorithm:      sigpb.DigitallySigned_SHA256,
+		SignatureAlgorithm: sigpb.DigitallySigned_ECDSA,
+		PublicKey:          &keyspb.PublicKey{Der: der},
+		CreateTime:         ptypes.TimestampNow(),
 	}
-	return out, nil
 }
@@ -59,6 +59,7 @@ func (c *trillianMapMockClient) GetLeaves(ctx context.Context, in *trillian.GetM
 	if c.getLeavesError {
 		return nil, errors.New("Test Error")
 	}
+	out.MapLeafInclusion = emptyInclusions(in.Index)
 	return out, nil
 }
 
@@ -67,9 +68,18 @@ func (c *trillianMapMockClient) GetLeavesByRevision(ctx context.Context, in *tri
 	if c.getLeavesError {
 		return nil, errors.New("Test Error")
 	}
+	out.MapLeafInclusion = emptyInclusions(in.Index)
 	return out, nil
 }
 
+func emptyInclusions(indexes [][]byte) []*trillian.MapLeafInclusion {
+	inclusions := make([]*trillian.MapLeafInclusion, len(indexes))
+	for i, index := range indexes {
+		inclusions[i] = &trillian.MapLeafInclusion{Leaf: &trillian.MapLeaf{Index: index}}
+	}
+	return inclusions
+}
+
 // Deprecated: Do not use.
 func (c *trillianMapMockClient) GetLeavesByRevisionNoProof(ctx context.Context, in *trillian.GetMapLeavesByRevisionRequest, opts ...grpc.CallOption) (*trillian.MapLeaves, error) {
 	out := new(trillian.MapLeaves)
@@ -110,9 +120,9 @@ func (c *trillianMapMockClient) GetSignedMapRoot(ctx context.Context, in *trilli
 
 func (c *trillianMapMockClient) GetSignedMapRootByRevision(ctx context.Context, in *trillian.GetSignedMapRootByRevisionRequest, opts ...grpc.CallOption) (*trillian.GetSignedMapRootResponse, error) {
 	out := new(trillian.GetSignedMapRootResponse)
-	err := c.cc.Invoke(ctx, "/trillian.TrillianMap/GetSignedMapRootByRevision", in, out, opts...)
-	if err != nil {
-		return nil, err
+	out.MapRoot = &trillian.SignedMapRoot{}
+	if c.getRootError {
+		return nil, errors.New("Test Error")
 	}
 	return out, nil
 }
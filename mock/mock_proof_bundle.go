@@ -0,0 +1,86 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mock
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"trillian-agent/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/trillian"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/merkle"
+	"github.com/google/trillian/merkle/hashers"
+	"github.com/google/trillian/types"
+)
+
+// NewSignedProofBundle creates the proof bundle of a map holding a single leaf, with a map root signed by a freshly generated ECDSA key
+func NewSignedProofBundle(mapID int64, channelID string, recordID string, index []byte, value []byte, revision int64) (*models.ProofBundleDefinition, error) {
+	hasher, err := hashers.NewMapHasher(trillian.HashStrategy_CONIKS_SHA256)
+	if err != nil {
+		return nil, err
+	}
+	hStar2 := merkle.NewHStar2(mapID, hasher)
+	rootHash, err := hStar2.HStar2Root(hasher.BitLen(), []*merkle.HStar2LeafHash{
+		{Index: new(big.Int).SetBytes(index), LeafHash: hasher.HashLeaf(mapID, index, value)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	signedRoot, err := tcrypto.NewSigner(mapID, key, crypto.SHA256).SignMapRoot(&types.MapRootV1{RootHash: rootHash, Revision: uint64(revision)})
+	if err != nil {
+		return nil, err
+	}
+
+	leafIndex := strfmt.Base64(index)
+	leafValue := strfmt.Base64(value)
+	publicKey := strfmt.Base64(der)
+	mapRoot := strfmt.Base64(signedRoot.MapRoot)
+	signature := strfmt.Base64(signedRoot.Signature)
+	hashStrategy := trillian.HashStrategy_CONIKS_SHA256.String()
+	hashAlgorithm := "SHA256"
+	signatureAlgorithm := "ECDSA"
+	return &models.ProofBundleDefinition{
+		ChannelID:          &channelID,
+		RecordID:           &recordID,
+		MapID:              &mapID,
+		Revision:           &revision,
+		LeafIndex:          &leafIndex,
+		LeafValue:          &leafValue,
+		Inclusion:          make([]strfmt.Base64, hasher.BitLen()),
+		SignedMapRoot:      &models.SignedMapRootDefinition{MapRoot: &mapRoot, Signature: &signature},
+		PublicKey:          &publicKey,
+		HashStrategy:       &hashStrategy,
+		HashAlgorithm:      &hashAlgorithm,
+		SignatureAlgorithm: &signatureAlgorithm,
+	}, nil
+}
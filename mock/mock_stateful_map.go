@@ -0,0 +1,99 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type leafVersion struct {
+	revision int64
+	value    []byte
+}
+
+// StatefulMapMock is an in-memory map that keeps every revision and, like trillian, rejects writes that do not expect the next revision
+type StatefulMapMock struct {
+	mu       sync.Mutex
+	revision int64
+	leaves   map[string][]leafVersion
+	writes   int
+}
+
+// NewStatefulMapMock creates an empty map at revision 0
+func NewStatefulMapMock() *StatefulMapMock {
+	return &StatefulMapMock{leaves: make(map[string][]leafVersion)}
+}
+
+// Revision returns the latest revision of the map
+func (m *StatefulMapMock) Revision() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.revision
+}
+
+// Writes returns the number of successful writes to the map
+func (m *StatefulMapMock) Writes() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.writes
+}
+
+// Leaf returns the value of a leaf at a revision, or at the latest revision if revision is negative
+func (m *StatefulMapMock) Leaf(index []byte, revision int64) []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if revision < 0 {
+		revision = m.revision
+	}
+	var value []byte
+	for _, version := range m.leaves[string(index)] {
+		if version.revision > revision {
+			break
+		}
+		value = version.value
+	}
+	return value
+}
+
+func (m *StatefulMapMock) GetLeavesByRevision(ctx context.Context, in *trillian.GetMapLeavesByRevisionRequest, opts ...grpc.CallOption) (*trillian.MapLeaves, error) {
+	out := new(trillian.MapLeaves)
+	for _, index := range in.Index {
+		out.Leaves = append(out.Leaves, &trillian.MapLeaf{Index: index, LeafValue: m.Leaf(index, in.Revision)})
+	}
+	return out, nil
+}
+
+func (m *StatefulMapMock) WriteLeaves(ctx context.Context, in *trillian.WriteMapLeavesRequest, opts ...grpc.CallOption) (*trillian.WriteMapLeavesResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if in.ExpectRevision != m.revision+1 {
+		return nil, status.Errorf(codes.FailedPrecondition, "can't write to revision %v", in.ExpectRevision)
+	}
+	m.revision++
+	m.writes++
+	for _, leaf := range in.Leaves {
+		m.leaves[string(leaf.Index)] = append(m.leaves[string(leaf.Index)], leafVersion{m.revision, leaf.LeafValue})
+	}
+	return &trillian.WriteMapLeavesResponse{Revision: m.revision}, nil
+}
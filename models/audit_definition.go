@@ -44,6 +44,12 @@ type AuditDefinition struct {
 	// Required: true
 	// Format: date-time
 	Timestamp *strfmt.DateTime `json:"timestamp"`
+
+	// transfer source
+	TransferSource *TransferSourceDefinition `json:"transferSource,omitempty"`
+
+	// Channel the record was transferred to by a TRANSFER-OUT commit
+	TransferTargetChannelID string `json:"transferTargetChannelID,omitempty"`
 }
 
 // Validate validates this audit definition
@@ -74,6 +80,10 @@ func (m *AuditDefinition) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTransferSource(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -138,8 +148,48 @@ func (m *AuditDefinition) validateTimestamp(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this audit definition based on context it is used
+func (m *AuditDefinition) validateTransferSource(formats strfmt.Registry) error {
+	if swag.IsZero(m.TransferSource) { // not required
+		return nil
+	}
+
+	if m.TransferSource != nil {
+		if err := m.TransferSource.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("transferSource")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this audit definition based on the context it is used
 func (m *AuditDefinition) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateTransferSource(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AuditDefinition) contextValidateTransferSource(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.TransferSource != nil {
+		if err := m.TransferSource.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("transferSource")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -24,6 +24,9 @@ type AuditResponseDefinition struct {
 	// history
 	// Required: true
 	History []*AuditDefinition `json:"history"`
+
+	// Cursor to request the next page of the history with, not set on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // Validate validates this audit response definition
@@ -28,6 +28,18 @@ type Channel struct {
 	// Map ID
 	// Required: true
 	MapID int64 `json:"mapID"`
+
+	// Page of the channel registry listing the channel, unset for channels created before the registry
+	RegistryPage *int64 `json:"registryPage,omitempty"`
+
+	// Lifecycle states a record may be in for each commit type changing it, overriding the default states of the commit types it sets
+	Lifecycle map[string][]string `json:"lifecycle,omitempty"`
+
+	// Latest version of the JSON Schema record payloads committed to the channel must validate against
+	Schema interface{} `json:"schema,omitempty"`
+
+	// Version of the schema, 0 if no schema was set for the channel
+	SchemaVersion int64 `json:"schemaVersion,omitempty"`
 }
 
 // MarshalBinary interface implementation
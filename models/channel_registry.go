@@ -0,0 +1,68 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package models
+
+import "github.com/go-openapi/swag"
+
+//ChannelRegistry defines the structure for storing the number of channels in each page of the channel registry in trillian
+type ChannelRegistry struct {
+	// Number of channels in each page
+	Pages []int64 `json:"pages"`
+}
+
+// MarshalBinary interface implementation
+func (m *ChannelRegistry) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ChannelRegistry) UnmarshalBinary(b []byte) error {
+	var res ChannelRegistry
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+//ChannelRegistryPage defines the structure for storing a page of channel IDs of the channel registry in trillian
+type ChannelRegistryPage struct {
+	// Channel IDs
+	ChannelIDs []string `json:"channelIDs"`
+}
+
+// MarshalBinary interface implementation
+func (m *ChannelRegistryPage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ChannelRegistryPage) UnmarshalBinary(b []byte) error {
+	var res ChannelRegistryPage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
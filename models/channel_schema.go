@@ -0,0 +1,50 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package models
+
+import "github.com/go-openapi/swag"
+
+//ChannelSchema defines the structure for storing a version of the schema of a channel in trillian
+type ChannelSchema struct {
+	// Channel ID
+	ChannelID string `json:"channelID"`
+
+	// Version of the schema
+	Version int64 `json:"version"`
+
+	// JSON Schema record payloads committed to the channel must validate against
+	Schema interface{} `json:"schema"`
+}
+
+// MarshalBinary interface implementation
+func (m *ChannelSchema) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ChannelSchema) UnmarshalBinary(b []byte) error {
+	var res ChannelSchema
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
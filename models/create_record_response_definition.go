@@ -20,6 +20,9 @@ import (
 // swagger:model CreateRecordResponseDefinition
 type CreateRecordResponseDefinition struct {
 
+	// Map revision the commit was written at
+	Revision int64 `json:"revision,omitempty"`
+
 	// success
 	// Required: true
 	Success *bool `json:"success"`
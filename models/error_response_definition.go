@@ -30,6 +30,9 @@ type ErrorResponseDefinition struct {
 	// success
 	// Required: true
 	Success *bool `json:"success"`
+
+	// Violations of the schema of the channel by the record payload of a commit
+	Violations []string `json:"violations,omitempty"`
 }
 
 // Validate validates this error response definition
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package models
+
+import "github.com/go-openapi/swag"
+
+//IdempotencyKey defines the structure for storing the result of a commit made with an idempotency key in a channel in trillian
+type IdempotencyKey struct {
+	// Key the commit was made with
+	Key string `json:"key"`
+	// Hex encoded SHA-256 hash of the commit type and body of the commit
+	BodyHash string `json:"bodyHash"`
+	// ID of the record of the commit
+	RecordID string `json:"recordID"`
+	// Map revision the commit was written at
+	Revision int64 `json:"revision"`
+}
+
+// MarshalBinary interface implementation
+func (m *IdempotencyKey) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IdempotencyKey) UnmarshalBinary(b []byte) error {
+	var res IdempotencyKey
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
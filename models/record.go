@@ -30,6 +30,18 @@ type Record struct {
 	// Previous Revision
 	// Required: true
 	PreviousRevision int64 `json:"previousRevision"`
+
+	// Record the record is attached to
+	ParentRecordID string `json:"parentRecordID,omitempty"`
+
+	// Records attached to the record, in the order they were attached
+	ChildRecordIDs []string `json:"childRecordIDs,omitempty"`
+
+	// Page of the record catalog of the channel holding the record, not set for records last committed before the catalog
+	CatalogPage *int64 `json:"catalogPage,omitempty"`
+
+	// Number of revisions of the record in the history leaves of the channel holding the record, up to this one, not set for records last committed before the history leaves
+	HistoryLength *int64 `json:"historyLength,omitempty"`
 }
 
 // MarshalBinary interface implementation
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package models
+
+import "github.com/go-openapi/swag"
+
+//RecordCatalog defines the structure for storing the number of records in each page of the record catalog of a channel in trillian
+type RecordCatalog struct {
+	// Number of records in each page
+	Pages []int64 `json:"pages"`
+}
+
+// MarshalBinary interface implementation
+func (m *RecordCatalog) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *RecordCatalog) UnmarshalBinary(b []byte) error {
+	var res RecordCatalog
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+//RecordCatalogPage defines the structure for storing a page of the record catalog of a channel in trillian
+type RecordCatalogPage struct {
+	// Summaries of the records, in the order they were created
+	Records []*RecordSummaryDefinition `json:"records"`
+}
+
+// MarshalBinary interface implementation
+func (m *RecordCatalogPage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *RecordCatalogPage) UnmarshalBinary(b []byte) error {
+	var res RecordCatalogPage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
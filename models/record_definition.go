@@ -20,6 +20,13 @@ import (
 // swagger:model RecordDefinition
 type RecordDefinition struct {
 
+	// Parent and child of an ATTACH or DETACH commit, one of which is the record of the commit
+	Attachment *AttachmentDefinition `json:"attachment,omitempty"`
+
+	// Revision the record must be at for the commit to be made on it
+	// Minimum: 1
+	ExpectedRevision int64 `json:"expectedRevision,omitempty"`
+
 	// record ID
 	// Required: true
 	RecordID *string `json:"recordID"`
@@ -27,12 +34,24 @@ type RecordDefinition struct {
 	// record ID payload
 	// Required: true
 	RecordIDPayload interface{} `json:"recordIDPayload"`
+
+	// Version of the schema of the channel the record payload was validated against, set by the agent
+	// Read Only: true
+	SchemaVersion int64 `json:"schemaVersion,omitempty"`
 }
 
 // Validate validates this record definition
 func (m *RecordDefinition) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAttachment(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExpectedRevision(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateRecordID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -47,6 +66,35 @@ func (m *RecordDefinition) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *RecordDefinition) validateAttachment(formats strfmt.Registry) error {
+	if swag.IsZero(m.Attachment) { // not required
+		return nil
+	}
+
+	if m.Attachment != nil {
+		if err := m.Attachment.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("attachment")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *RecordDefinition) validateExpectedRevision(formats strfmt.Registry) error {
+	if swag.IsZero(m.ExpectedRevision) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("expectedRevision", "body", m.ExpectedRevision, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *RecordDefinition) validateRecordID(formats strfmt.Registry) error {
 
 	if err := validate.Required("recordID", "body", m.RecordID); err != nil {
@@ -65,8 +113,44 @@ func (m *RecordDefinition) validateRecordIDPayload(formats strfmt.Registry) erro
 	return nil
 }
 
-// ContextValidate validates this record definition based on context it is used
+// ContextValidate validate this record definition based on the context it is used
 func (m *RecordDefinition) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAttachment(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateSchemaVersion(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *RecordDefinition) contextValidateAttachment(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Attachment != nil {
+		if err := m.Attachment.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("attachment")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *RecordDefinition) contextValidateSchemaVersion(ctx context.Context, formats strfmt.Registry) error {
+
+	if err := validate.ReadOnly(ctx, "schemaVersion", "body", int64(m.SchemaVersion)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
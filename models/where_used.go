@@ -0,0 +1,44 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package models
+
+import "github.com/go-openapi/swag"
+
+//WhereUsed defines the structure for storing the parents a record is or was attached to in trillian
+type WhereUsed struct {
+	// Attachments of the record to a parent, in the order they were made
+	Parents []*WhereUsedParentDefinition `json:"parents"`
+}
+
+// MarshalBinary interface implementation
+func (m *WhereUsed) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *WhereUsed) UnmarshalBinary(b []byte) error {
+	var res WhereUsed
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,459 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPatch is returned when a patch document is not an RFC 6902 JSON Patch
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// ErrPatchFailed is returned when an operation of a patch does not apply to the document it is applied to
+var ErrPatchFailed = errors.New("patch does not apply")
+
+// Operation is an operation of an RFC 6902 JSON Patch
+type Operation struct {
+	Op    string
+	Path  string
+	From  string
+	Value interface{}
+
+	// previous is the value a remove or replace operation takes out of the document
+	previous interface{}
+}
+
+// Diff returns the RFC 6902 JSON Patch turning the JSON document from into the JSON document to.
+// Objects are compared member by member and arrays element by element, so that the patch only touches what changed
+func Diff(from interface{}, to interface{}) ([]*Operation, error) {
+	from, err := normalize(from)
+	if err != nil {
+		return nil, err
+	}
+	to, err = normalize(to)
+	if err != nil {
+		return nil, err
+	}
+	return diff("", from, to, nil), nil
+}
+
+// normalize turns a value into the generic JSON document it encodes to
+func normalize(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	err = json.Unmarshal(encoded, &result)
+	return result, err
+}
+
+// diff appends the operations turning the value at path in from into the value at path in to
+func diff(path string, from interface{}, to interface{}, ops []*Operation) []*Operation {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		if toValue, ok := to.(map[string]interface{}); ok {
+			return diffObjects(path, fromValue, toValue, ops)
+		}
+	case []interface{}:
+		if toValue, ok := to.([]interface{}); ok {
+			return diffArrays(path, fromValue, toValue, ops)
+		}
+	}
+	if reflect.DeepEqual(from, to) {
+		return ops
+	}
+	return append(ops, &Operation{Op: "replace", Path: path, Value: to, previous: from})
+}
+
+// diffObjects appends the operations turning an object into another, member by member in the order of their names
+func diffObjects(path string, from map[string]interface{}, to map[string]interface{}, ops []*Operation) []*Operation {
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		memberPath := path + "/" + escape(name)
+		fromMember, inFrom := from[name]
+		toMember, inTo := to[name]
+		if !inTo {
+			ops = append(ops, &Operation{Op: "remove", Path: memberPath, previous: fromMember})
+		} else if !inFrom {
+			ops = append(ops, &Operation{Op: "add", Path: memberPath, Value: toMember})
+		} else {
+			ops = diff(memberPath, fromMember, toMember, ops)
+		}
+	}
+	return ops
+}
+
+// diffArrays appends the operations turning an array into another, comparing the elements at the same positions.
+// Elements past the end of the shorter array are removed from the last one or appended in order
+func diffArrays(path string, from []interface{}, to []interface{}, ops []*Operation) []*Operation {
+	common := len(from)
+	if len(to) < common {
+		common = len(to)
+	}
+	for i := 0; i < common; i++ {
+		ops = diff(path+"/"+strconv.Itoa(i), from[i], to[i], ops)
+	}
+	for i := len(from) - 1; i >= common; i-- {
+		ops = append(ops, &Operation{Op: "remove", Path: path + "/" + strconv.Itoa(i), previous: from[i]})
+	}
+	for i := common; i < len(to); i++ {
+		ops = append(ops, &Operation{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: to[i]})
+	}
+	return ops
+}
+
+// escape escapes a member name to be a reference token of a JSON Pointer
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
+
+// Summary describes the change an operation of a patch returned by Diff makes
+func (o *Operation) Summary() string {
+	location := o.Path
+	if location == "" {
+		location = "the document"
+	}
+	switch o.Op {
+	case "add":
+		return fmt.Sprintf("%v added as %v", location, describe(o.Value))
+	case "remove":
+		return fmt.Sprintf("%v removed, was %v", location, describe(o.previous))
+	case "replace":
+		return fmt.Sprintf("%v changed from %v to %v", location, describe(o.previous), describe(o.Value))
+	}
+	return fmt.Sprintf("%v %v", o.Op, location)
+}
+
+// describe renders a value of a document as compact JSON
+func describe(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+// MergePatch applies an RFC 7386 JSON Merge Patch to a JSON document and returns the patched document, leaving the document as it was
+func MergePatch(document interface{}, mergePatch interface{}) (interface{}, error) {
+	document, err := normalize(document)
+	if err != nil {
+		return nil, err
+	}
+	mergePatch, err = normalize(mergePatch)
+	if err != nil {
+		return nil, err
+	}
+	return merge(document, mergePatch), nil
+}
+
+// merge merges a merge patch into a value, members set to null in the patch being removed
+func merge(value interface{}, mergePatch interface{}) interface{} {
+	patchMembers, ok := mergePatch.(map[string]interface{})
+	if !ok {
+		return mergePatch
+	}
+	members, ok := value.(map[string]interface{})
+	if !ok {
+		members = map[string]interface{}{}
+	}
+	for name, patchMember := range patchMembers {
+		if patchMember == nil {
+			delete(members, name)
+		} else {
+			members[name] = merge(members[name], patchMember)
+		}
+	}
+	return members
+}
+
+// ParsePatch reads the operations of an RFC 6902 JSON Patch from a JSON document
+func ParsePatch(document interface{}) ([]*Operation, error) {
+	document, err := normalize(document)
+	if err != nil {
+		return nil, err
+	}
+	elements, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: a patch is an array of operations", ErrInvalidPatch)
+	}
+	ops := make([]*Operation, len(elements))
+	for i, element := range elements {
+		members, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: operation %v is not an object", ErrInvalidPatch, i)
+		}
+		op, _ := members["op"].(string)
+		path, hasPath := members["path"].(string)
+		from, hasFrom := members["from"].(string)
+		value, hasValue := members["value"]
+		switch {
+		case op != "add" && op != "remove" && op != "replace" && op != "move" && op != "copy" && op != "test":
+			return nil, fmt.Errorf("%w: operation %v has no valid op", ErrInvalidPatch, i)
+		case !hasPath:
+			return nil, fmt.Errorf("%w: operation %v has no path", ErrInvalidPatch, i)
+		case (op == "move" || op == "copy") && !hasFrom:
+			return nil, fmt.Errorf("%w: %v operation %v has no from", ErrInvalidPatch, op, i)
+		case (op == "add" || op == "replace" || op == "test") && !hasValue:
+			return nil, fmt.Errorf("%w: %v operation %v has no value", ErrInvalidPatch, op, i)
+		}
+		if _, err := parsePointer(path); err != nil {
+			return nil, err
+		} else if _, err := parsePointer(from); hasFrom && err != nil {
+			return nil, err
+		}
+		ops[i] = &Operation{Op: op, Path: path, From: from, Value: value}
+	}
+	return ops, nil
+}
+
+// Apply applies the operations of an RFC 6902 JSON Patch to a JSON document in order and returns the patched document, leaving the document as it was.
+// It fails if any operation does not apply
+func Apply(document interface{}, ops []*Operation) (interface{}, error) {
+	document, err := normalize(document)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		document, err = apply(document, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %v: %w", i, err)
+		}
+	}
+	return document, nil
+}
+
+// apply applies an operation of a patch to a document
+func apply(document interface{}, op *Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add":
+		value, err := normalize(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case "remove":
+		document, _, err := remove(document, path)
+		return document, err
+	case "replace":
+		value, err := normalize(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		document, _, err = remove(document, path)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if len(from) < len(path) && reflect.DeepEqual(from, path[:len(from)]) {
+				return nil, fmt.Errorf("%w: cannot move %v into itself", ErrPatchFailed, op.From)
+			}
+			document, value, err := remove(document, from)
+			if err != nil {
+				return nil, err
+			}
+			return add(document, path, value)
+		}
+		value, err := get(document, from)
+		if err != nil {
+			return nil, err
+		}
+		value, err = normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, path, value)
+	case "test":
+		value, err := normalize(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		current, err := get(document, path)
+		if err != nil {
+			return nil, err
+		} else if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: %v is %v, not %v", ErrPatchFailed, op.Path, describe(current), describe(value))
+		}
+		return document, nil
+	}
+	return nil, fmt.Errorf("%w: unknown op %v", ErrInvalidPatch, op.Op)
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	} else if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %v is not a JSON Pointer", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex returns the index of an array element a reference token names, which may be the length of the array if end is set
+func arrayIndex(array []interface{}, token string, end bool) (int, error) {
+	if end && token == "-" {
+		return len(array), nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || strconv.Itoa(index) != token {
+		return 0, fmt.Errorf("%w: %v is not an array index", ErrPatchFailed, token)
+	}
+	if index > len(array) || (index == len(array) && !end) {
+		return 0, fmt.Errorf("%w: index %v is out of range", ErrPatchFailed, index)
+	}
+	return index, nil
+}
+
+// get returns the value at a path of a document
+func get(document interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := document.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %v does not exist", ErrPatchFailed, token)
+			}
+			document = value
+		case []interface{}:
+			index, err := arrayIndex(container, token, false)
+			if err != nil {
+				return nil, err
+			}
+			document = container[index]
+		default:
+			return nil, fmt.Errorf("%w: %v is not in an object or array", ErrPatchFailed, token)
+		}
+	}
+	return document, nil
+}
+
+// add adds a value at a path of a document, replacing an object member or inserting an array element, and returns the document
+func add(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token, rest := path[0], path[1:]
+	switch container := document.(type) {
+	case map[string]interface{}:
+		if len(rest) == 0 {
+			container[token] = value
+			return container, nil
+		}
+		member, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: member %v does not exist", ErrPatchFailed, token)
+		}
+		member, err := add(member, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		container[token] = member
+		return container, nil
+	case []interface{}:
+		index, err := arrayIndex(container, token, len(rest) == 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) == 0 {
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		}
+		element, err := add(container[index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = element
+		return container, nil
+	}
+	return nil, fmt.Errorf("%w: %v is not in an object or array", ErrPatchFailed, token)
+}
+
+// remove removes the value at a path of a document and returns the document and the value removed
+func remove(document interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrPatchFailed)
+	}
+	token, rest := path[0], path[1:]
+	switch container := document.(type) {
+	case map[string]interface{}:
+		member, ok := container[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: member %v does not exist", ErrPatchFailed, token)
+		}
+		if len(rest) == 0 {
+			delete(container, token)
+			return container, member, nil
+		}
+		member, removed, err := remove(member, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		container[token] = member
+		return container, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(container, token, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(rest) == 0 {
+			removed := container[index]
+			return append(container[:index], container[index+1:]...), removed, nil
+		}
+		element, removed, err := remove(container[index], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		container[index] = element
+		return container, removed, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %v is not in an object or array", ErrPatchFailed, token)
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package patch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func summaries(ops []*Operation) []string {
+	result := []string{}
+	for _, op := range ops {
+		result = append(result, op.Summary())
+	}
+	return result
+}
+
+//TestDiffObjects tests the patch between two objects adding, removing and replacing members, nested ones included
+func TestDiffObjects(t *testing.T) {
+	from := map[string]interface{}{"weight": 12, "color": "blue", "size": map[string]interface{}{"width": 3, "height": 4}, "a/b~c": true}
+	to := map[string]interface{}{"weight": 12.5, "size": map[string]interface{}{"width": 3, "depth": 2}, "a/b~c": true, "tags": []string{"new"}}
+	ops, err := Diff(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Operation{
+		{Op: "remove", Path: "/color", previous: "blue"},
+		{Op: "add", Path: "/size/depth", Value: float64(2)},
+		{Op: "remove", Path: "/size/height", previous: float64(4)},
+		{Op: "add", Path: "/tags", Value: []interface{}{"new"}},
+		{Op: "replace", Path: "/weight", Value: 12.5, previous: float64(12)},
+	}, ops)
+	assert.Equal(t, []string{
+		`/color removed, was "blue"`,
+		`/size/depth added as 2`,
+		`/size/height removed, was 4`,
+		`/tags added as ["new"]`,
+		`/weight changed from 12 to 12.5`,
+	}, summaries(ops))
+
+	ops, err = Diff(map[string]interface{}{"a/b~c": 1}, map[string]interface{}{"a/b~c": 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "/a~1b~0c", ops[0].Path)
+
+	ops, err = Diff(from, from)
+	assert.Nil(t, err)
+	assert.Empty(t, ops)
+}
+
+//TestDiffArrays tests the patch between arrays of different lengths
+func TestDiffArrays(t *testing.T) {
+	ops, err := Diff([]interface{}{1, 2, 3, 4}, []interface{}{1, 5})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/1 changed from 2 to 5", "/3 removed, was 4", "/2 removed, was 3"}, summaries(ops))
+
+	ops, err = Diff([]interface{}{1}, []interface{}{1, 2, 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/1 added as 2", "/2 added as 3"}, summaries(ops))
+}
+
+//TestDiffTypes tests the patch between values of different types and between documents that cannot be encoded
+func TestDiffTypes(t *testing.T) {
+	ops, err := Diff(map[string]interface{}{"a": 1}, []interface{}{1})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`the document changed from {"a":1} to [1]`}, summaries(ops))
+
+	ops, err = Diff(nil, map[string]interface{}{"a": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "replace", ops[0].Op)
+
+	_, err = Diff(make(chan int), nil)
+	assert.Error(t, err)
+}
+
+//TestMergePatch tests merging RFC 7386 merge patches into documents, removing the members set to null
+func TestMergePatch(t *testing.T) {
+	document := map[string]interface{}{"title": "Goodbye!", "author": map[string]interface{}{"givenName": "John", "familyName": "Doe"}, "tags": []interface{}{"example", "sample"}, "content": "This will be unchanged"}
+	patched, err := MergePatch(document, map[string]interface{}{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": map[string]interface{}{"familyName": nil}, "tags": []interface{}{"example"}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "Hello!", "author": map[string]interface{}{"givenName": "John"}, "tags": []interface{}{"example"}, "content": "This will be unchanged", "phoneNumber": "+01-123-456-7890"}, patched)
+	assert.Equal(t, "Goodbye!", document["title"])
+
+	patched, err = MergePatch(map[string]interface{}{"a": "b"}, map[string]interface{}{"a": map[string]interface{}{"b": "c", "d": nil}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": "c"}}, patched)
+
+	patched, err = MergePatch(map[string]interface{}{"a": "b"}, []interface{}{"c"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"c"}, patched)
+}
+
+//TestParsePatch tests reading the operations of RFC 6902 patches and rejecting documents that are not patches
+func TestParsePatch(t *testing.T) {
+	ops, err := ParsePatch([]interface{}{
+		map[string]interface{}{"op": "add", "path": "/a", "value": nil},
+		map[string]interface{}{"op": "move", "path": "/b", "from": "/a"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []*Operation{{Op: "add", Path: "/a"}, {Op: "move", Path: "/b", From: "/a"}}, ops)
+
+	for _, document := range []interface{}{
+		map[string]interface{}{"op": "add", "path": "/a", "value": 1},
+		[]interface{}{"add"},
+		[]interface{}{map[string]interface{}{"op": "merge", "path": "/a"}},
+		[]interface{}{map[string]interface{}{"op": "remove"}},
+		[]interface{}{map[string]interface{}{"op": "add", "path": "/a"}},
+		[]interface{}{map[string]interface{}{"op": "copy", "path": "/a"}},
+		[]interface{}{map[string]interface{}{"op": "remove", "path": "a"}},
+	} {
+		_, err := ParsePatch(document)
+		assert.True(t, errors.Is(err, ErrInvalidPatch), "%v", document)
+	}
+}
+
+//TestApply tests applying each operation of RFC 6902 patches
+func TestApply(t *testing.T) {
+	document := map[string]interface{}{"foo": []interface{}{"bar", "baz"}, "qux": map[string]interface{}{"baz": 1}}
+	patched, err := Apply(document, []*Operation{
+		{Op: "add", Path: "/foo/1", Value: "qux"},
+		{Op: "add", Path: "/foo/-", Value: "end"},
+		{Op: "remove", Path: "/foo/0"},
+		{Op: "replace", Path: "/qux/baz", Value: "boo"},
+		{Op: "copy", Path: "/copied", From: "/qux"},
+		{Op: "move", Path: "/moved", From: "/qux/baz"},
+		{Op: "test", Path: "/copied/baz", Value: "boo"},
+		{Op: "add", Path: "/a~1b", Value: map[string]interface{}{"c": 2}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"foo":    []interface{}{"qux", "baz", "end"},
+		"qux":    map[string]interface{}{},
+		"copied": map[string]interface{}{"baz": "boo"},
+		"moved":  "boo",
+		"a/b":    map[string]interface{}{"c": float64(2)},
+	}, patched)
+	assert.Equal(t, []interface{}{"bar", "baz"}, document["foo"])
+
+	patched, err = Apply(document, []*Operation{{Op: "replace", Path: "", Value: []interface{}{1}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{float64(1)}, patched)
+}
+
+//TestApplyFailed tests operations that do not apply to the document, failing the whole patch
+func TestApplyFailed(t *testing.T) {
+	document := map[string]interface{}{"foo": []interface{}{"bar"}, "baz": "qux"}
+	for _, op := range []*Operation{
+		{Op: "add", Path: "/missing/a", Value: 1},
+		{Op: "add", Path: "/foo/2", Value: 1},
+		{Op: "add", Path: "/foo/01", Value: 1},
+		{Op: "remove", Path: "/missing"},
+		{Op: "remove", Path: "/foo/1"},
+		{Op: "remove", Path: ""},
+		{Op: "replace", Path: "/missing", Value: 1},
+		{Op: "move", Path: "/baz/a", From: "/baz"},
+		{Op: "copy", Path: "/a", From: "/missing"},
+		{Op: "test", Path: "/baz", Value: "quux"},
+		{Op: "test", Path: "/baz/a", Value: "qux"},
+	} {
+		_, err := Apply(document, []*Operation{{Op: "add", Path: "/added", Value: 1}, op})
+		assert.True(t, errors.Is(err, ErrPatchFailed), "%v %v", op.Op, op.Path)
+	}
+	assert.Equal(t, map[string]interface{}{"foo": []interface{}{"bar"}, "baz": "qux"}, document)
+}
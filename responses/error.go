@@ -21,6 +21,7 @@ import (
 	"errors"
 	"trillian-agent/logger"
 	"trillian-agent/models"
+	"trillian-agent/restapi/operations/channel"
 	"trillian-agent/restapi/operations/record"
 )
 
@@ -29,6 +30,9 @@ var log = logger.GetLogger("Responses:Error")
 //ChannelNotFound is the message to log if a channel is not found
 var ChannelNotFound = "No Such Channel"
 
+//ChannelExists is the message to log if a channel already exists
+var ChannelExists = "Channel Already Exists"
+
 //ResourceNotFound is the message to log if a resource is not found
 var ResourceNotFound = "No Such Resource"
 
@@ -38,6 +42,60 @@ var ResourceExists = "Resource Already Exists"
 //InvalidCommitType is the message to log if an invalid commit type is specified
 var InvalidCommitType = "Invalid Commit Type"
 
+//VerificationFailed is the message to log if data returned by trillian fails verification
+var VerificationFailed = "Verification Failed"
+
+//InvalidTransaction is the message to log if a transaction cannot be committed as a whole
+var InvalidTransaction = "Invalid Transaction"
+
+//InvalidCursor is the message to log if a cursor to list records from cannot be decoded
+var InvalidCursor = "Invalid Cursor"
+
+//InvalidAttachment is the message to log if the attachment of an ATTACH or DETACH commit is missing or does not name the record of the commit
+var InvalidAttachment = "Invalid Attachment"
+
+//AlreadyAttached is the message to log if a commit attaches a record that is already attached
+var AlreadyAttached = "Record Already Attached"
+
+//NotAttached is the message to log if a commit detaches a record that is not attached to the parent
+var NotAttached = "Record Not Attached"
+
+//InvalidTransition is the message to log if a commit changes a record in a lifecycle state the commit type is not allowed in
+var InvalidTransition = "Invalid Lifecycle Transition"
+
+//Retired is the message to log if a retired record is retrieved
+var Retired = "Record Retired"
+
+//InvalidLifecycle is the message to log if the lifecycle of a channel sets states for a commit type that does not change records or sets unknown states
+var InvalidLifecycle = "Invalid Lifecycle"
+
+//NotTransferredOut is the message to log if the source of a transfer is not the TRANSFER-OUT revision of the record
+var NotTransferredOut = "Record Not Transferred Out"
+
+//InvalidTransfer is the message to log if a record is transferred to the channel it is in
+var InvalidTransfer = "Invalid Transfer"
+
+//InvalidQuery is the message to log if a record is queried at both a revision and a point in time
+var InvalidQuery = "Invalid Query"
+
+//InvalidPatch is the message to log if the body of a patch is not a merge patch or a JSON patch
+var InvalidPatch = "Invalid Patch"
+
+//PatchFailed is the message to log if a patch cannot be applied to the payload of a record
+var PatchFailed = "Patch Failed"
+
+//PreconditionFailed is the message to log if a commit expects a record at another revision than its latest one
+var PreconditionFailed = "Precondition Failed"
+
+//IdempotencyKeyReused is the message to log if an idempotency key is used by commits with different commit types or bodies
+var IdempotencyKeyReused = "Idempotency Key Reused"
+
+//InvalidSchema is the message to log if the schema set for a channel is not a valid JSON Schema
+var InvalidSchema = "Invalid Schema"
+
+//SchemaViolation is the message to log if a record payload violates the schema of its channel
+var SchemaViolation = "Schema Violation"
+
 //InternalError is the messsage to log if an internal erro occurs
 var InternalError = "Internal Error"
 
@@ -62,6 +120,17 @@ func ErrAuditChannelNotFound() *record.AuditRecordNotFound {
 	return &res
 }
 
+//ErrAuditInvalidCursor returns error for when the cursor of a page of history cannot be decoded or does not name a revision of the record
+func ErrAuditInvalidCursor() *record.AuditRecordBadRequest {
+	err := errors.New(InvalidCursor)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.AuditRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
 //ErrAuditResourceNotFound returns error for when a resource is not found
 func ErrAuditResourceNotFound() *record.AuditRecordNotFound {
 	err := errors.New(ResourceNotFound)
@@ -73,6 +142,295 @@ func ErrAuditResourceNotFound() *record.AuditRecordNotFound {
 	return &res
 }
 
+//ErrAuditVerificationFailed returns error for when data returned by trillian fails verification
+func ErrAuditVerificationFailed(err error) *record.AuditRecordBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.AuditRecordBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrCreateChannelInternalServerError returns error when an internal error occurs
+func ErrCreateChannelInternalServerError(err error) *channel.CreateChannelInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.CreateChannelInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrCreateChannelConflict returns error for when a channel already exists
+func ErrCreateChannelConflict() *channel.CreateChannelConflict {
+	err := errors.New(ChannelExists)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.CreateChannelConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrCreateChannelVerificationFailed returns error for when data returned by trillian fails verification
+func ErrCreateChannelVerificationFailed(err error) *channel.CreateChannelBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.CreateChannelBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelInternalServerError returns error when an internal error occurs
+func ErrGetChannelInternalServerError(err error) *channel.GetChannelInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelNotFound returns error for when a channel is not found
+func ErrGetChannelNotFound() *channel.GetChannelNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelVerificationFailed returns error for when data returned by trillian fails verification
+func ErrGetChannelVerificationFailed(err error) *channel.GetChannelBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.GetChannelBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrListChannelsInternalServerError returns error when an internal error occurs
+func ErrListChannelsInternalServerError(err error) *channel.ListChannelsInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.ListChannelsInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrListChannelsVerificationFailed returns error for when data returned by trillian fails verification
+func ErrListChannelsVerificationFailed(err error) *channel.ListChannelsBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.ListChannelsBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrDeleteChannelInternalServerError returns error when an internal error occurs
+func ErrDeleteChannelInternalServerError(err error) *channel.DeleteChannelInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.DeleteChannelInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrDeleteChannelNotFound returns error for when a channel is not found
+func ErrDeleteChannelNotFound() *channel.DeleteChannelNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.DeleteChannelNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrDeleteChannelVerificationFailed returns error for when data returned by trillian fails verification
+func ErrDeleteChannelVerificationFailed(err error) *channel.DeleteChannelBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.DeleteChannelBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelLifecycleInternalServerError returns error when an internal error occurs
+func ErrGetChannelLifecycleInternalServerError(err error) *channel.GetChannelLifecycleInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelLifecycleInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelLifecycleNotFound returns error for when a channel is not found
+func ErrGetChannelLifecycleNotFound() *channel.GetChannelLifecycleNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelLifecycleNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelLifecycleVerificationFailed returns error for when data returned by trillian fails verification
+func ErrGetChannelLifecycleVerificationFailed(err error) *channel.GetChannelLifecycleBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.GetChannelLifecycleBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelLifecycleInvalidLifecycle returns error for when a lifecycle sets states for a commit type that does not change records or sets unknown states
+func ErrUpdateChannelLifecycleInvalidLifecycle(err error) *channel.UpdateChannelLifecycleBadRequest {
+	var status = InvalidLifecycle
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.UpdateChannelLifecycleBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelLifecycleInternalServerError returns error when an internal error occurs
+func ErrUpdateChannelLifecycleInternalServerError(err error) *channel.UpdateChannelLifecycleInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.UpdateChannelLifecycleInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelLifecycleNotFound returns error for when a channel is not found
+func ErrUpdateChannelLifecycleNotFound() *channel.UpdateChannelLifecycleNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.UpdateChannelLifecycleNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelLifecycleVerificationFailed returns error for when data returned by trillian fails verification
+func ErrUpdateChannelLifecycleVerificationFailed(err error) *channel.UpdateChannelLifecycleBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.UpdateChannelLifecycleBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelSchemaInternalServerError returns error when an internal error occurs
+func ErrGetChannelSchemaInternalServerError(err error) *channel.GetChannelSchemaInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelSchemaInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelSchemaNotFound returns error for when a channel is not found
+func ErrGetChannelSchemaNotFound() *channel.GetChannelSchemaNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelSchemaNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelSchemaResourceNotFound returns error for when no schema or no such version of it was set for a channel
+func ErrGetChannelSchemaResourceNotFound() *channel.GetChannelSchemaNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.GetChannelSchemaNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrGetChannelSchemaVerificationFailed returns error for when data returned by trillian fails verification
+func ErrGetChannelSchemaVerificationFailed(err error) *channel.GetChannelSchemaBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.GetChannelSchemaBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelSchemaInvalidSchema returns error for when the schema set for a channel is not a valid JSON Schema
+func ErrUpdateChannelSchemaInvalidSchema(err error) *channel.UpdateChannelSchemaBadRequest {
+	var status = InvalidSchema
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.UpdateChannelSchemaBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelSchemaInternalServerError returns error when an internal error occurs
+func ErrUpdateChannelSchemaInternalServerError(err error) *channel.UpdateChannelSchemaInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.UpdateChannelSchemaInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelSchemaNotFound returns error for when a channel is not found
+func ErrUpdateChannelSchemaNotFound() *channel.UpdateChannelSchemaNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = channel.UpdateChannelSchemaNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrUpdateChannelSchemaVerificationFailed returns error for when data returned by trillian fails verification
+func ErrUpdateChannelSchemaVerificationFailed(err error) *channel.UpdateChannelSchemaBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = channel.UpdateChannelSchemaBadGateway{Payload: &errRes}
+	return &res
+}
+
 //ErrCommitInternalServerError returns rror when an internal error occurs
 func ErrCommitInternalServerError(err error) *record.CommitRecordInternalServerError {
 	var status = err.Error()
@@ -94,6 +452,17 @@ func ErrCommitRecordConflict() *record.CommitRecordConflict {
 	return &res
 }
 
+//ErrCommitInvalidTransition returns error for when a commit changes a record in a lifecycle state the commit type is not allowed in
+func ErrCommitInvalidTransition(err error) *record.CommitRecordConflict {
+	var status = InvalidTransition
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitRecordConflict{Payload: &errRes}
+	return &res
+}
+
 //ErrCommitChannelNotFound returns error for when a channel is not found
 func ErrCommitChannelNotFound() *record.CommitRecordNotFound {
 	err := errors.New(ChannelNotFound)
@@ -127,6 +496,71 @@ func ErrCommitInvalidCommitType() *record.CommitRecordNotFound {
 	return &res
 }
 
+//ErrCommitInvalidAttachment returns error for when the attachment of an ATTACH or DETACH commit is invalid
+func ErrCommitInvalidAttachment(err error) *record.CommitRecordBadRequest {
+	var status = InvalidAttachment
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrCommitAttachmentConflict returns error for when a commit attaches a record that is already attached or detaches one that is not
+func ErrCommitAttachmentConflict(err error) *record.CommitRecordConflict {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.CommitRecordConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrCommitPreconditionFailed returns error for when a commit expects a record at another revision than its latest one
+func ErrCommitPreconditionFailed(err error) *record.CommitRecordPreconditionFailed {
+	var status = PreconditionFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitRecordPreconditionFailed{Payload: &errRes}
+	return &res
+}
+
+//ErrCommitIdempotencyKeyReused returns error for when an idempotency key was used by a commit with another commit type or body
+func ErrCommitIdempotencyKeyReused(err error) *record.CommitRecordUnprocessableEntity {
+	var status = IdempotencyKeyReused
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitRecordUnprocessableEntity{Payload: &errRes}
+	return &res
+}
+
+//ErrCommitSchemaViolation returns error for when a record payload violates the schema of its channel, listing the violations
+func ErrCommitSchemaViolation(err error, violations []string) *record.CommitRecordBadRequest {
+	var status = SchemaViolation
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg, Violations: violations}
+	var res = record.CommitRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrCommitVerificationFailed returns error for when data returned by trillian fails verification
+func ErrCommitVerificationFailed(err error) *record.CommitRecordBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitRecordBadGateway{Payload: &errRes}
+	return &res
+}
+
 //ErrRetrieveRecordInternalServerError returns error when an internal error occurs
 func ErrRetrieveRecordInternalServerError(err error) *record.RetrieveRecordInternalServerError {
 	var status = err.Error()
@@ -158,3 +592,541 @@ func ErrRetrieveResourceNotFound() *record.RetrieveRecordNotFound {
 	var res = record.RetrieveRecordNotFound{Payload: &errRes}
 	return &res
 }
+
+//ErrRetrieveVerificationFailed returns error for when data returned by trillian fails verification
+func ErrRetrieveVerificationFailed(err error) *record.RetrieveRecordBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.RetrieveRecordBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveRecordInvalidQuery returns error for when a record is queried at both a revision and a point in time
+func ErrRetrieveRecordInvalidQuery(err error) *record.RetrieveRecordBadRequest {
+	var status = InvalidQuery
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.RetrieveRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveRecordRetired returns the retirement of a retired record
+func ErrRetrieveRecordRetired(retirement *models.RetirementDefinition) *record.RetrieveRecordGone {
+	err := errors.New(Retired)
+	log.Err(err).Msg(*retirement.RecordID)
+	var res = record.RetrieveRecordGone{Payload: retirement}
+	return &res
+}
+
+//ErrRetrieveProofInternalServerError returns error when an internal error occurs
+func ErrRetrieveProofInternalServerError(err error) *record.RetrieveRecordProofInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordProofInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveProofChannelNotFound returns error for when a channel is not found
+func ErrRetrieveProofChannelNotFound() *record.RetrieveRecordProofNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordProofNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveProofResourceNotFound returns error for when a resource is not found
+func ErrRetrieveProofResourceNotFound() *record.RetrieveRecordProofNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordProofNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveProofVerificationFailed returns error for when data returned by trillian fails verification
+func ErrRetrieveProofVerificationFailed(err error) *record.RetrieveRecordProofBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.RetrieveRecordProofBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveTreeInternalServerError returns error when an internal error occurs
+func ErrRetrieveTreeInternalServerError(err error) *record.RetrieveRecordTreeInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordTreeInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveTreeChannelNotFound returns error for when a channel is not found
+func ErrRetrieveTreeChannelNotFound() *record.RetrieveRecordTreeNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordTreeNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveTreeResourceNotFound returns error for when a resource is not found
+func ErrRetrieveTreeResourceNotFound() *record.RetrieveRecordTreeNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordTreeNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveTreeVerificationFailed returns error for when data returned by trillian fails verification
+func ErrRetrieveTreeVerificationFailed(err error) *record.RetrieveRecordTreeBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.RetrieveRecordTreeBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveWhereUsedInternalServerError returns error when an internal error occurs
+func ErrRetrieveWhereUsedInternalServerError(err error) *record.RetrieveWhereUsedInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveWhereUsedInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveWhereUsedChannelNotFound returns error for when a channel is not found
+func ErrRetrieveWhereUsedChannelNotFound() *record.RetrieveWhereUsedNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveWhereUsedNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveWhereUsedResourceNotFound returns error for when a resource is not found
+func ErrRetrieveWhereUsedResourceNotFound() *record.RetrieveWhereUsedNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveWhereUsedNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveWhereUsedVerificationFailed returns error for when data returned by trillian fails verification
+func ErrRetrieveWhereUsedVerificationFailed(err error) *record.RetrieveWhereUsedBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.RetrieveWhereUsedBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveRecordDiffInternalServerError returns error when an internal error occurs
+func ErrRetrieveRecordDiffInternalServerError(err error) *record.RetrieveRecordDiffInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordDiffInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveRecordDiffChannelNotFound returns error for when a channel is not found
+func ErrRetrieveRecordDiffChannelNotFound() *record.RetrieveRecordDiffNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordDiffNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveRecordDiffResourceNotFound returns error for when a resource is not found
+func ErrRetrieveRecordDiffResourceNotFound() *record.RetrieveRecordDiffNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.RetrieveRecordDiffNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrRetrieveRecordDiffVerificationFailed returns error for when data returned by trillian fails verification
+func ErrRetrieveRecordDiffVerificationFailed(err error) *record.RetrieveRecordDiffBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.RetrieveRecordDiffBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordInternalServerError returns error when an internal error occurs
+func ErrPatchRecordInternalServerError(err error) *record.PatchRecordInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.PatchRecordInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordChannelNotFound returns error for when a channel is not found
+func ErrPatchRecordChannelNotFound() *record.PatchRecordNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.PatchRecordNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordResourceNotFound returns error for when a resource is not found
+func ErrPatchRecordResourceNotFound() *record.PatchRecordNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.PatchRecordNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordInvalidPatch returns error for when the body of a patch is not a valid merge patch or JSON patch
+func ErrPatchRecordInvalidPatch(err error) *record.PatchRecordBadRequest {
+	var status = InvalidPatch
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.PatchRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordPatchFailed returns error for when a patch cannot be applied to the latest payload of a record
+func ErrPatchRecordPatchFailed(err error) *record.PatchRecordConflict {
+	var status = PatchFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.PatchRecordConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordInvalidTransition returns error for when a patch changes a record in a lifecycle state UPDATE is not allowed in
+func ErrPatchRecordInvalidTransition(err error) *record.PatchRecordConflict {
+	var status = InvalidTransition
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.PatchRecordConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordPreconditionFailed returns error for when a commit expects a record at another revision than its latest one
+func ErrPatchRecordPreconditionFailed(err error) *record.PatchRecordPreconditionFailed {
+	var status = PreconditionFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.PatchRecordPreconditionFailed{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordSchemaViolation returns error for when a record payload violates the schema of its channel, listing the violations
+func ErrPatchRecordSchemaViolation(err error, violations []string) *record.PatchRecordBadRequest {
+	var status = SchemaViolation
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg, Violations: violations}
+	var res = record.PatchRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrPatchRecordVerificationFailed returns error for when data returned by trillian fails verification
+func ErrPatchRecordVerificationFailed(err error) *record.PatchRecordBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.PatchRecordBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionInternalServerError returns error when an internal error occurs
+func ErrTransactionInternalServerError(err error) *record.CommitTransactionInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.CommitTransactionInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionInvalid returns error for when a transaction cannot be committed as a whole
+func ErrTransactionInvalid(reason string) *record.CommitTransactionBadRequest {
+	err := errors.New(InvalidTransaction)
+	var status = err.Error()
+	log.Err(err).Msg(reason)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: reason}
+	var res = record.CommitTransactionBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionChannelNotFound returns error for when a channel is not found
+func ErrTransactionChannelNotFound() *record.CommitTransactionNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.CommitTransactionNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionResourceNotFound returns error naming the record of a transaction that is not found
+func ErrTransactionResourceNotFound(recordID string) *record.CommitTransactionNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(recordID)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: recordID}
+	var res = record.CommitTransactionNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionRecordConflict returns error naming the record of a transaction that already exists
+func ErrTransactionRecordConflict(recordID string) *record.CommitTransactionConflict {
+	err := errors.New(ResourceExists)
+	var status = err.Error()
+	log.Err(err).Msg(recordID)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: recordID}
+	var res = record.CommitTransactionConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionInvalidTransition returns error for when an operation of a transaction changes a record in a lifecycle state the commit type is not allowed in
+func ErrTransactionInvalidTransition(err error) *record.CommitTransactionConflict {
+	var status = InvalidTransition
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitTransactionConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionAttachmentConflict returns error for when an operation of a transaction attaches a record that is already attached or detaches one that is not
+func ErrTransactionAttachmentConflict(err error) *record.CommitTransactionConflict {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.CommitTransactionConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionPreconditionFailed returns error for when a commit expects a record at another revision than its latest one
+func ErrTransactionPreconditionFailed(err error) *record.CommitTransactionPreconditionFailed {
+	var status = PreconditionFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitTransactionPreconditionFailed{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionSchemaViolation returns error for when a record payload violates the schema of its channel, listing the violations
+func ErrTransactionSchemaViolation(err error, violations []string) *record.CommitTransactionBadRequest {
+	var status = SchemaViolation
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg, Violations: violations}
+	var res = record.CommitTransactionBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrTransactionVerificationFailed returns error for when data returned by trillian fails verification
+func ErrTransactionVerificationFailed(err error) *record.CommitTransactionBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.CommitTransactionBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrListRecordsInternalServerError returns error when an internal error occurs
+func ErrListRecordsInternalServerError(err error) *record.ListRecordsInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.ListRecordsInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrListRecordsChannelNotFound returns error for when a channel is not found
+func ErrListRecordsChannelNotFound() *record.ListRecordsNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.ListRecordsNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrListRecordsInvalidCursor returns error for when the cursor to list records from cannot be decoded
+func ErrListRecordsInvalidCursor() *record.ListRecordsBadRequest {
+	err := errors.New(InvalidCursor)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.ListRecordsBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrListRecordsVerificationFailed returns error for when data returned by trillian fails verification
+func ErrListRecordsVerificationFailed(err error) *record.ListRecordsBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.ListRecordsBadGateway{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferInvalidTarget returns error for when a record is transferred to the channel it is in
+func ErrTransferInvalidTarget() *record.TransferRecordBadRequest {
+	err := errors.New(InvalidTransfer)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.TransferRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferSchemaViolation returns error for when the record payload of a transferred record violates the schema of the target channel
+func ErrTransferSchemaViolation(err error, violations []string) *record.TransferRecordBadRequest {
+	var status = SchemaViolation
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg, Violations: violations}
+	var res = record.TransferRecordBadRequest{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferInternalServerError returns error when an internal error occurs
+func ErrTransferInternalServerError(err error) *record.TransferRecordInternalServerError {
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.TransferRecordInternalServerError{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferChannelNotFound returns error for when a channel is not found
+func ErrTransferChannelNotFound() *record.TransferRecordNotFound {
+	err := errors.New(ChannelNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.TransferRecordNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferResourceNotFound returns error for when a resource is not found
+func ErrTransferResourceNotFound() *record.TransferRecordNotFound {
+	err := errors.New(ResourceNotFound)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.TransferRecordNotFound{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferRecordConflict returns error for when the transferred record already exists in the target channel
+func ErrTransferRecordConflict() *record.TransferRecordConflict {
+	err := errors.New(ResourceExists)
+	var status = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success}
+	var res = record.TransferRecordConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferInvalidTransition returns error for when a transferred record is in a lifecycle state the transfer is not allowed in
+func ErrTransferInvalidTransition(err error) *record.TransferRecordConflict {
+	var status = InvalidTransition
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.TransferRecordConflict{Payload: &errRes}
+	return &res
+}
+
+//ErrTransferVerificationFailed returns error for when data returned by trillian fails verification
+func ErrTransferVerificationFailed(err error) *record.TransferRecordBadGateway {
+	var status = VerificationFailed
+	var errMsg = err.Error()
+	log.Err(err).Msg(status)
+	var success = false
+	var errRes = models.ErrorResponseDefinition{Status: &status, Success: &success, Error: errMsg}
+	var res = record.TransferRecordBadGateway{Payload: &errRes}
+	return &res
+}
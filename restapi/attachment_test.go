@@ -0,0 +1,142 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var stagedAttachments []string
+
+func attachmentRecord(recordID string, parentID string, childID string) *models.RecordDefinition {
+	return &models.RecordDefinition{
+		RecordID:        &recordID,
+		RecordIDPayload: map[string]interface{}{"test": "test"},
+		Attachment:      &models.AttachmentDefinition{ParentRecordID: &parentID, ChildRecordID: &childID},
+	}
+}
+
+func serveAttachment(t *testing.T, commitType string, record *models.RecordDefinition) *httptest.ResponseRecorder {
+	stagedAttachments = nil
+	reqBody, _ := record.MarshalBinary()
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = GetRecordMock
+		attachRecord = attachRecordMock
+		detachRecord = detachRecordMock
+		addLeaves = addLeavesMock
+	}, "POST", "/channels/test-channel/records", map[string]string{"commit-type": commitType}, reqBody)
+}
+
+//TestAttachRecord tests attaching a record to a parent from either record
+func TestAttachRecord(t *testing.T) {
+	rr := serveAttachment(t, "ATTACH", attachmentRecord("other-record", "test-record", "other-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"ATTACH test-record other-record"}, stagedAttachments)
+
+	rr = serveAttachment(t, "ATTACH", attachmentRecord("test-record", "test-record", "other-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+//TestDetachRecord tests detaching a record from its parent
+func TestDetachRecord(t *testing.T) {
+	rr := serveAttachment(t, "DETACH", attachmentRecord("attached-record", "test-record", "attached-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"DETACH test-record attached-record"}, stagedAttachments)
+}
+
+//TestAttachRecordConflict tests attaching a record that is already attached and detaching one that is not
+func TestAttachRecordConflict(t *testing.T) {
+	rr := serveAttachment(t, "ATTACH", attachmentRecord("attached-record", "other-record", "attached-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Contains(t, res.Error, "ATTACH is not allowed on attached-record in state attached")
+
+	rr = serveAttachment(t, "DETACH", attachmentRecord("other-record", "test-record", "other-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, res.Error, "DETACH is not allowed on other-record in state active")
+
+	rr = serveAttachment(t, "DETACH", attachmentRecord("attached-record", "other-record", "attached-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Empty(t, stagedAttachments)
+}
+
+//TestAttachRecordInvalid tests ATTACH and DETACH commits without a valid attachment
+func TestAttachRecordInvalid(t *testing.T) {
+	recordID := "test-record"
+	rr := serveAttachment(t, "ATTACH", &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{}})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveAttachment(t, "DETACH", attachmentRecord("random-record", "test-record", "other-record"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveAttachment(t, "ATTACH", attachmentRecord("test-record", "test-record", "test-record"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+//TestAttachRecordErrors tests attaching missing records and errors reading them
+func TestAttachRecordErrors(t *testing.T) {
+	rr := serveAttachment(t, "ATTACH", attachmentRecord("test-record", "test-record", "random-record"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveAttachment(t, "ATTACH", attachmentRecord("test-record", "test-record", "error-record"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveAttachment(t, "ATTACH", attachmentRecord("test-record", "unverified-record", "test-record"))
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+
+	rr = serveAttachment(t, "ATTACH", attachmentRecord("test-record", "test-record", "new-record-error"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestAttachTransaction tests attachments committed in a transaction, which cannot commit the same record twice but may change the parent of an attachment
+func TestAttachTransaction(t *testing.T) {
+	attach := &models.TransactionOperationDefinition{CommitType: &ATTACHType, Record: attachmentRecord("other-record", "test-record", "other-record")}
+	rr := serveTransaction(t, "test-channel", attach)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serveTransaction(t, "test-channel", attach, transactionOperation("UPDATE", "test-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serveTransaction(t, "test-channel", attach, transactionOperation("UPDATE", "other-record"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	conflict := &models.TransactionOperationDefinition{CommitType: &ATTACHType, Record: attachmentRecord("attached-record", "other-record", "attached-record")}
+	rr = serveTransaction(t, "test-channel", conflict)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	missing := &models.TransactionOperationDefinition{CommitType: &ATTACHType, Record: attachmentRecord("other-record", "random-record", "other-record")}
+	rr = serveTransaction(t, "test-channel", missing)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "random-record", res.Error)
+}
+
+var ATTACHType = ATTACH
+
+func attachRecordMock(ctx context.Context, batch *dbom.Batch, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+	if *recordDef.Attachment.ChildRecordID == "new-record-error" {
+		return errors.New("test-error")
+	}
+	stagedAttachments = append(stagedAttachments, commitType+" "+*recordDef.Attachment.ParentRecordID+" "+*recordDef.Attachment.ChildRecordID)
+	return nil
+}
+
+func detachRecordMock(ctx context.Context, batch *dbom.Batch, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+	stagedAttachments = append(stagedAttachments, commitType+" "+*recordDef.Attachment.ParentRecordID+" "+*recordDef.Attachment.ChildRecordID)
+	return nil
+}
@@ -0,0 +1,400 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package restapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/opentracing/opentracing-go"
+)
+
+var auditLogger = logger.GetLogger("Restapi:Audit")
+
+// auditOldestFirst is the order of a history returning the first revision of a record first
+const auditOldestFirst = "oldest-first"
+
+// auditPosition is a revision of a record in one of the channels its history went through
+type auditPosition struct {
+	channelID string
+	revision  int64
+}
+
+// errInvalidAuditCursor is returned when a cursor to page through the history of a record from cannot be decoded or does not name a revision of the record
+var errInvalidAuditCursor = errors.New(responses.InvalidCursor)
+
+// auditPage reads a page of the history of a record, keeping the revisions matching the filters of the request.
+// The cursor of a page names the last revision it returns, so that pages do not move while new revisions are committed
+func auditPage(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.AuditRecordParams, mapClient *client.MapClient) (*models.AuditResponseDefinition, middleware.Responder) {
+	var after *auditPosition
+	if params.Cursor != nil {
+		position, err := decodeAuditCursor(*params.Cursor)
+		if err != nil {
+			tracing.LogAndTraceErr(auditLogger, span, err, responses.InvalidCursor)
+			return nil, responses.ErrAuditInvalidCursor()
+		}
+		after = &position
+	}
+	limit := int(*params.Limit)
+	matches := func(entry *models.AuditDefinition) bool {
+		return matchesAudit(entry, params.EventType, params.ChangedSince, params.ChangedBefore)
+	}
+
+	var history []*models.AuditDefinition
+	var errRes middleware.Responder
+	if *params.Order == auditOldestFirst {
+		history, errRes = historyOldestFirst(ctx, span, tracer, mapClient, params.RecordID, after, limit+1, matches)
+	} else {
+		history, errRes = historyNewestFirst(ctx, span, tracer, mapClient, params.RecordID, after, limit+1, matches)
+	}
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	result := models.AuditResponseDefinition{History: history}
+	if len(history) > limit {
+		result.History = history[:limit]
+		result.NextCursor = encodeAuditCursor(positionOf(history[limit-1]))
+	}
+	return &result, nil
+}
+
+// historyNewestFirst collects up to limit matching revisions of a record from its latest revision, or from the revision before the position after
+func historyNewestFirst(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, mapClient *client.MapClient, recordID string, after *auditPosition, limit int, matches func(entry *models.AuditDefinition) bool) ([]*models.AuditDefinition, middleware.Responder) {
+	rev := int64(-1)
+	if after != nil {
+		var err error
+		_, mapClient, err = openCommitChannel(ctx, after.channelID, false, tracer)
+		if errors.Is(err, errChannelNotFound) {
+			tracing.LogAndTraceErr(auditLogger, span, err, responses.InvalidCursor)
+			return nil, responses.ErrAuditInvalidCursor()
+		} else if err != nil {
+			tracing.LogAndTraceErr(auditLogger, span, err, responses.InternalError)
+			if client.IsVerificationError(err) {
+				return nil, responses.ErrAuditVerificationFailed(err)
+			}
+			return nil, responses.ErrAuditInternalServerError(err)
+		}
+		rev = after.revision
+	}
+
+	var history []*models.AuditDefinition
+	var invalid bool
+	chunk := int64(limit)
+	if after != nil {
+		chunk++
+	}
+	errRes := walkHistory(ctx, span, tracer, mapClient, recordID, rev, chunk, func(entry *models.AuditDefinition) bool {
+		if after != nil {
+			invalid = positionOf(entry) != *after
+			after = nil
+			return !invalid
+		}
+		if matches(entry) {
+			history = append(history, entry)
+		}
+		return len(history) < limit
+	})
+	if errRes != nil {
+		return nil, errRes
+	} else if invalid {
+		tracing.LogAndTraceErr(auditLogger, span, errInvalidAuditCursor, responses.InvalidCursor)
+		return nil, responses.ErrAuditInvalidCursor()
+	}
+	return history, nil
+}
+
+// historyOldestFirst collects up to limit matching revisions of a record from its first revision, or from the revision after the position after.
+// The segments of the history are found from the latest revision, then only the revisions of the page are read from the history leaves by their position
+func historyOldestFirst(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, mapClient *client.MapClient, recordID string, after *auditPosition, limit int, matches func(entry *models.AuditDefinition) bool) ([]*models.AuditDefinition, middleware.Responder) {
+	segments, errRes := historySegments(ctx, span, tracer, mapClient, recordID)
+	if errRes != nil {
+		return nil, errRes
+	}
+
+	i, from := len(segments)-1, int64(0)
+	if after != nil {
+		i = -1
+		for j, segment := range segments {
+			if *segment.latest.ChannelID == after.channelID && segment.oldest.Revision <= after.revision && after.revision <= segment.latest.Revision {
+				i = j
+			}
+		}
+		var entries []*models.Record
+		var err error
+		if i >= 0 {
+			entries, err = getRecordHistory(ctx, segments[i].mapClient, recordID, after.revision, 1, tracer)
+		}
+		if err != nil {
+			return nil, auditReadError(span, err)
+		} else if len(entries) == 0 || entries[0].Revision != after.revision {
+			tracing.LogAndTraceErr(auditLogger, span, errInvalidAuditCursor, responses.InvalidCursor)
+			return nil, responses.ErrAuditInvalidCursor()
+		}
+		from = historyPosition(entries[0]) + 1
+	}
+
+	var history []*models.AuditDefinition
+	for ; i >= 0 && len(history) < limit; i, from = i-1, 0 {
+		segment := segments[i]
+		length := historyPosition(segment.latest) + 1
+		for from < length && len(history) < limit {
+			to := from + int64(limit-len(history))
+			if to > length {
+				to = length
+			}
+			entries, err := segment.revisions(ctx, tracer, recordID, from, to)
+			if err != nil {
+				return nil, auditReadError(span, err)
+			}
+			for _, entry := range entries {
+				if entry := auditEntry(entry); matches(entry) {
+					history = append(history, entry)
+				}
+			}
+			from = to
+		}
+	}
+	return history, nil
+}
+
+// historySegment is the part of the history of a record held by the history leaves of a channel, from its revision oldest to its revision latest.
+// A history goes through several segments when the record was transferred, or was last committed before the history leaves
+type historySegment struct {
+	mapClient *client.MapClient
+	oldest    *models.Record
+	latest    *models.Record
+}
+
+// revisions reads the revisions of the segment at the positions from up to to, excluded, oldest first. The latest revision is not read again
+func (segment historySegment) revisions(ctx context.Context, tracer opentracing.Tracer, recordID string, from int64, to int64) ([]*models.Record, error) {
+	last := historyPosition(segment.latest)
+	var entries []*models.Record
+	if from < last {
+		end := to
+		if end > last {
+			end = last
+		}
+		var err error
+		if entries, err = getRecordHistoryRange(ctx, segment.mapClient, recordID, segment.latest.Revision, from, end, tracer); err != nil {
+			return nil, err
+		}
+	}
+	if to > last {
+		entries = append(entries, segment.latest)
+	}
+	return entries, nil
+}
+
+// historySegments finds the segments of the history of a record from its latest revision, newest first, reading the latest and the oldest revision of each segment
+func historySegments(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, mapClient *client.MapClient, recordID string) ([]historySegment, middleware.Responder) {
+	var segments []historySegment
+	rev := int64(-1)
+	for {
+		latest, err := getRecordHistory(ctx, mapClient, recordID, rev, 1, tracer)
+		if err != nil {
+			return nil, auditReadError(span, err)
+		} else if len(latest) == 0 {
+			tracing.LogAndTraceErr(auditLogger, span, nil, responses.ResourceNotFound)
+			return nil, responses.ErrAuditResourceNotFound()
+		}
+		segment := historySegment{mapClient: mapClient, oldest: latest[0], latest: latest[0]}
+		if historyPosition(segment.latest) > 0 {
+			oldest, err := getRecordHistoryRange(ctx, mapClient, recordID, segment.latest.Revision, 0, 1, tracer)
+			if err != nil {
+				return nil, auditReadError(span, err)
+			}
+			segment.oldest = oldest[0]
+		}
+		segments = append(segments, segment)
+
+		rev = segment.oldest.PreviousRevision
+		if rev > 0 {
+			continue
+		} else if segment.oldest.TransferSource == nil {
+			return segments, nil
+		}
+		sourceClient, errRes := followTransfer(ctx, span, tracer, recordID, segment.oldest.TransferSource)
+		if errRes != nil {
+			return nil, errRes
+		} else if sourceClient == nil {
+			return segments, nil
+		}
+		mapClient = sourceClient
+		rev = *segment.oldest.TransferSource.Revision
+	}
+}
+
+// historyPosition returns the position of a revision in the history leaves of its record, which is 0 for a revision committed before them
+func historyPosition(result *models.Record) int64 {
+	if result.HistoryLength == nil {
+		return 0
+	}
+	return *result.HistoryLength - 1
+}
+
+// walkHistory visits the revisions of a record from the revision rev of the channel of mapClient back to its first revision, until visit returns false.
+// Revisions are read chunk at a time from the history of the record in each channel. The history of a record transferred in from another channel continues with its revisions in the source channel
+func walkHistory(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, mapClient *client.MapClient, recordID string, rev int64, chunk int64, visit func(entry *models.AuditDefinition) bool) middleware.Responder {
+	for {
+		history, err := getRecordHistory(ctx, mapClient, recordID, rev, chunk, tracer)
+		if err != nil {
+			return auditReadError(span, err)
+		} else if len(history) == 0 {
+			tracing.LogAndTraceErr(auditLogger, span, nil, responses.ResourceNotFound)
+			return responses.ErrAuditResourceNotFound()
+		}
+		for _, result := range history {
+			if !visit(auditEntry(result)) {
+				return nil
+			}
+		}
+		oldest := history[len(history)-1]
+		rev = oldest.PreviousRevision
+		if rev > 0 {
+			continue
+		} else if oldest.TransferSource == nil {
+			return nil
+		}
+
+		sourceClient, errRes := followTransfer(ctx, span, tracer, recordID, oldest.TransferSource)
+		if errRes != nil {
+			return errRes
+		} else if sourceClient == nil {
+			return nil
+		}
+		mapClient = sourceClient
+		rev = *oldest.TransferSource.Revision
+	}
+}
+
+// auditEntry returns the audit entry of a revision of a record
+func auditEntry(result *models.Record) *models.AuditDefinition {
+	var auditRecord = result.AuditDefinition
+	auditRecord.ID = &result.Revision
+	return &auditRecord
+}
+
+// auditReadError returns the response to an error reading the history of a record
+func auditReadError(span opentracing.Span, err error) middleware.Responder {
+	tracing.LogAndTraceErr(auditLogger, span, err, responses.InternalError)
+	if client.IsVerificationError(err) {
+		return responses.ErrAuditVerificationFailed(err)
+	}
+	return responses.ErrAuditInternalServerError(err)
+}
+
+// positionOf returns the position of a revision in the history of its record
+func positionOf(entry *models.AuditDefinition) auditPosition {
+	return auditPosition{channelID: *entry.ChannelID, revision: *entry.ID}
+}
+
+// matchesAudit reports whether a revision of a record was committed with eventType in [changedSince, changedBefore). Filters left empty match every revision
+func matchesAudit(entry *models.AuditDefinition, eventType *string, changedSince *strfmt.DateTime, changedBefore *strfmt.DateTime) bool {
+	if eventType != nil && *entry.EventType != *eventType {
+		return false
+	}
+	if changedSince == nil && changedBefore == nil {
+		return true
+	} else if entry.Timestamp == nil {
+		return false
+	}
+	timestamp := time.Time(*entry.Timestamp)
+	if changedSince != nil && timestamp.Before(time.Time(*changedSince)) {
+		return false
+	}
+	if changedBefore != nil && !timestamp.Before(time.Time(*changedBefore)) {
+		return false
+	}
+	return true
+}
+
+// encodeAuditCursor encodes the position of the last revision of a page of history
+func encodeAuditCursor(position auditPosition) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%v", position.revision, position.channelID)))
+}
+
+// decodeAuditCursor decodes the position encoded in a cursor
+func decodeAuditCursor(cursor string) (auditPosition, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return auditPosition{}, errInvalidAuditCursor
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return auditPosition{}, errInvalidAuditCursor
+	}
+	revision, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || revision < 1 {
+		return auditPosition{}, errInvalidAuditCursor
+	}
+	position := auditPosition{channelID: parts[1], revision: revision}
+	if encodeAuditCursor(position) != cursor {
+		return auditPosition{}, errInvalidAuditCursor
+	}
+	return position, nil
+}
+
+// followTransfer opens the source channel of the TRANSFER-IN commit of a record once the root hash of the source map at the TRANSFER-OUT commit is checked against the one the TRANSFER-IN commit carries.
+// It returns no map client if the source channel has been deleted, so that the history ends with the TRANSFER-IN commit naming it
+func followTransfer(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, recordID string, link *models.TransferSourceDefinition) (*client.MapClient, middleware.Responder) {
+	_, sourceClient, err := openCommitChannel(ctx, *link.ChannelID, false, tracer)
+	if errors.Is(err, errChannelNotFound) {
+		auditLogger.Debug().Msgf("Source channel %v of the transfer of %v does not exist", *link.ChannelID, recordID)
+		return nil, nil
+	} else if err != nil {
+		tracing.LogAndTraceErr(auditLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return nil, responses.ErrAuditVerificationFailed(err)
+		}
+		return nil, responses.ErrAuditInternalServerError(err)
+	}
+
+	source, _, err := getTransferSource(ctx, sourceClient, *link.ChannelID, recordID, *link.Revision, tracer)
+	if err != nil && !errors.Is(err, dbom.ErrNotTransferredOut) {
+		tracing.LogAndTraceErr(auditLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return nil, responses.ErrAuditVerificationFailed(err)
+		}
+		return nil, responses.ErrAuditInternalServerError(err)
+	} else if err == nil && source == nil {
+		err = fmt.Errorf("%v: %v in %v", responses.ResourceNotFound, recordID, *link.ChannelID)
+	} else if err == nil && !bytes.Equal(*source.MapRootHash, *link.MapRootHash) {
+		err = fmt.Errorf("root hash of %v at revision %v does not match the transfer of %v", *link.ChannelID, *link.Revision, recordID)
+	}
+	if err != nil {
+		tracing.LogAndTraceErr(auditLogger, span, err, responses.VerificationFailed)
+		return nil, responses.ErrAuditVerificationFailed(err)
+	}
+	return sourceClient, nil
+}
@@ -0,0 +1,193 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+// historyLatest is the latest revision of history-record
+var historyLatest int64 = 7
+
+// historyChunks holds the number of revisions asked for by each read of the history of a record
+var historyChunks []int64
+
+// historyRanges holds the positions asked for by each read of a range of the history of a record
+var historyRanges [][2]int64
+
+func serveAudit(t *testing.T, url string) (int, *models.AuditResponseDefinition) {
+	historyChunks, historyRanges = nil, nil
+	rr := serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = getHistoryRecordMock
+		getRecordHistory = func(ctx context.Context, client *client.MapClient, recordID string, revision int64, limit int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+			historyChunks = append(historyChunks, limit)
+			return GetRecordHistoryMock(ctx, client, recordID, revision, limit, tracer)
+		}
+		getRecordHistoryRange = func(ctx context.Context, client *client.MapClient, recordID string, revision int64, from int64, to int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+			historyRanges = append(historyRanges, [2]int64{from, to})
+			return GetRecordHistoryRangeMock(ctx, client, recordID, revision, from, to, tracer)
+		}
+	}, "GET", url, nil, nil)
+	var res models.AuditResponseDefinition
+	if rr.Code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	}
+	return rr.Code, &res
+}
+
+func historyRevisions(res *models.AuditResponseDefinition) []int64 {
+	revisions := make([]int64, len(res.History))
+	for i, entry := range res.History {
+		revisions[i] = *entry.ID
+	}
+	return revisions
+}
+
+//TestAuditRecordPages tests paging through the history of a record from its latest revision
+func TestAuditRecordPages(t *testing.T) {
+	historyLatest = 7
+	code, res := serveAudit(t, "/channels/test-channel/records/history-record/audit?limit=3")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{7, 6, 5}, historyRevisions(res))
+	assert.NotEmpty(t, res.NextCursor)
+
+	historyLatest = 8
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?limit=3&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{4, 3, 2}, historyRevisions(res))
+
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?limit=3&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{1}, historyRevisions(res))
+	assert.Empty(t, res.NextCursor)
+
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 8, len(res.History))
+	assert.Empty(t, res.NextCursor)
+}
+
+//TestAuditRecordOldestFirst tests paging through the history of a record from its first revision
+func TestAuditRecordOldestFirst(t *testing.T) {
+	historyLatest = 7
+	code, res := serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&limit=3")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{1, 2, 3}, historyRevisions(res))
+
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&limit=3&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{4, 5, 6}, historyRevisions(res))
+
+	historyLatest = 8
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&limit=3&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{7, 8}, historyRevisions(res))
+	assert.Empty(t, res.NextCursor)
+}
+
+//TestAuditRecordFilters tests keeping the revisions of a history committed with a commit type or in a time range
+func TestAuditRecordFilters(t *testing.T) {
+	historyLatest = 7
+	code, res := serveAudit(t, "/channels/test-channel/records/history-record/audit?eventType=DETACH")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{5}, historyRevisions(res))
+
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?eventType=UPDATE&limit=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{7, 6}, historyRevisions(res))
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?eventType=UPDATE&limit=2&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{4, 3}, historyRevisions(res))
+
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&changedSince=2020-10-03T00:00:00Z&changedBefore=2020-10-05T00:00:00Z")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{2, 3}, historyRevisions(res))
+}
+
+//TestAuditRecordInvalidCursor tests paging through a history with cursors that cannot be decoded or do not name a revision of the record
+func TestAuditRecordInvalidCursor(t *testing.T) {
+	historyLatest = 7
+	for _, cursor := range []string{"invalid", encodeAuditCursor(auditPosition{channelID: "test-channel", revision: 9}), encodeAuditCursor(auditPosition{channelID: "random-channel", revision: 3})} {
+		code, _ := serveAudit(t, "/channels/test-channel/records/history-record/audit?cursor="+cursor)
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&cursor="+cursor)
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+}
+
+//TestAuditRecordChunks tests that a page of history is read with one read of the history of the record, and that a page from the first revision only reads the revisions it returns
+func TestAuditRecordChunks(t *testing.T) {
+	historyLatest = 7
+	code, res := serveAudit(t, "/channels/test-channel/records/history-record/audit?limit=3")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{4}, historyChunks)
+
+	code, _ = serveAudit(t, "/channels/test-channel/records/history-record/audit?limit=3&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{5}, historyChunks)
+
+	code, res = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&limit=3")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{1}, historyChunks)
+	assert.Equal(t, [][2]int64{{0, 1}, {0, 4}}, historyRanges)
+
+	code, _ = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&limit=3&cursor="+res.NextCursor)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []int64{1, 1}, historyChunks)
+	assert.Equal(t, [][2]int64{{0, 1}, {3, 6}}, historyRanges)
+
+	code, _ = serveAudit(t, "/channels/test-channel/records/history-record/audit?order=oldest-first&eventType=DETACH")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, [][2]int64{{0, 1}, {0, 6}}, historyRanges)
+}
+
+//TestAuditTransferredRecordPages tests that pages of the history of a transferred record follow it back into the source channel
+func TestAuditTransferredRecordPages(t *testing.T) {
+	rr := serveTransfer(t, "GET", "/channels/target-channel/records/transferred-in/audit?limit=1", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.AuditResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "target-channel", *res.History[0].ChannelID)
+
+	rr = serveTransfer(t, "GET", "/channels/target-channel/records/transferred-in/audit?limit=1&cursor="+res.NextCursor, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "test-channel", *res.History[0].ChannelID)
+	assert.Equal(t, int64(5), *res.History[0].ID)
+
+	rr = serveTransfer(t, "GET", "/channels/target-channel/records/transferred-in/audit?limit=1&order=oldest-first", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "test-channel", *res.History[0].ChannelID)
+	assert.Equal(t, int64(2), *res.History[0].ID)
+}
+
+// getHistoryRecordMock reads history-record, created at revision 1 and changed once per revision up to historyLatest, one day apart, with each revision at its own position of the history
+func getHistoryRecordMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.Record, error) {
+	if recordID != "history-record" {
+		return GetRecordMock(ctx, client, recordID, revision, tracer)
+	}
+	if revision < 0 || revision > historyLatest {
+		revision = historyLatest
+	}
+	channelID, eventType := "test-channel", UPDATE
+	if revision == 1 {
+		eventType = CREATE
+	} else if revision == 5 {
+		eventType = DETACH
+	}
+	timestamp := strfmt.DateTime(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, int(revision)))
+	audit := models.AuditDefinition{ChannelID: &channelID, ResourceID: &recordID, EventType: &eventType, Payload: map[string]interface{}{"recordIDPayload": map[string]interface{}{"revision": revision}}, Timestamp: &timestamp}
+	return &models.Record{AuditDefinition: audit, Revision: revision, PreviousRevision: revision - 1, HistoryLength: &revision}, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/logger"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+)
+
+var catalogLogger = logger.GetLogger("Restapi:Catalog")
+
+// listChannelRecords pages through the record catalog of a channel, keeping the records matching the filters of the request
+func listChannelRecords(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.ListRecordsParams) middleware.Responder {
+	_, mapClient, err := openCommitChannel(ctx, params.ChannelID, false, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(catalogLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrListRecordsChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrListRecordsVerificationFailed(err)
+		}
+		return responses.ErrListRecordsInternalServerError(err)
+	}
+
+	var cursor, eventType string
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+	if params.EventType != nil {
+		eventType = *params.EventType
+	}
+	result, err := listRecords(ctx, mapClient, cursor, *params.Limit, eventType, params.ChangedSince, params.ChangedBefore, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(catalogLogger, span, err, responses.InternalError)
+		if errors.Is(err, dbom.ErrInvalidCursor) {
+			return responses.ErrListRecordsInvalidCursor()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrListRecordsVerificationFailed(err)
+		}
+		return responses.ErrListRecordsInternalServerError(err)
+	}
+	var res = record.ListRecordsOK{Payload: result}
+	catalogLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
@@ -0,0 +1,105 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func serveCatalog(t *testing.T, url string) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getChannel = GetChannelMock
+		listRecords = listRecordsMock
+	}, "GET", url, nil, nil)
+}
+
+//TestListRecords tests listing the records of a channel with the default and explicit filters
+func TestListRecords(t *testing.T) {
+	rr := serveCatalog(t, "/channels/test-channel/records")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.RecordListResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, 1, len(res.Records))
+	assert.Equal(t, "test-record", *res.Records[0].RecordID)
+	assert.Equal(t, "next", res.NextCursor)
+
+	rr = serveCatalog(t, "/channels/test-channel/records?cursor=next&limit=5&eventType=UPDATE&changedSince=2020-07-01T10:00:00Z&changedBefore=2020-07-02T10:00:00Z")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	res = models.RecordListResponseDefinition{}
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, 5, len(res.Records))
+	assert.Equal(t, "UPDATE", *res.Records[0].EventType)
+	assert.Empty(t, res.NextCursor)
+}
+
+//TestListRecordsChannelNotFound tests listing the records of a missing channel
+func TestListRecordsChannelNotFound(t *testing.T) {
+	rr := serveCatalog(t, "/channels/random-channel/records")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestListRecordsInvalid tests listing records with an invalid cursor, limit or time
+func TestListRecordsInvalid(t *testing.T) {
+	rr := serveCatalog(t, "/channels/test-channel/records?cursor=bad")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveCatalog(t, "/channels/test-channel/records?limit=0")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	rr = serveCatalog(t, "/channels/test-channel/records?changedSince=yesterday")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestListRecordsErrors tests errors reading the channel and its record catalog
+func TestListRecordsErrors(t *testing.T) {
+	rr := serveCatalog(t, "/channels/error-channel/records")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveCatalog(t, "/channels/test-channel/records?cursor=error")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveCatalog(t, "/channels/test-channel/records?cursor=unverified")
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+func listRecordsMock(ctx context.Context, client *client.MapClient, cursor string, limit int64, eventType string, changedSince *strfmt.DateTime, changedBefore *strfmt.DateTime, tracer opentracing.Tracer) (*models.RecordListResponseDefinition, error) {
+	switch cursor {
+	case "bad":
+		return nil, dbom.ErrInvalidCursor
+	case "error":
+		return nil, errors.New("test-error")
+	case "unverified":
+		return nil, errVerificationMock
+	}
+	if eventType == "" {
+		eventType = "CREATE"
+	}
+	revision := int64(1654)
+	recordID := "test-record"
+	lastChanged := strfmt.DateTime(time.Now())
+	if changedSince != nil {
+		lastChanged = *changedSince
+	}
+	records := make([]*models.RecordSummaryDefinition, 0)
+	for i := int64(0); i < limit && i < 5; i++ {
+		records = append(records, &models.RecordSummaryDefinition{RecordID: &recordID, EventType: &eventType, LastChanged: &lastChanged, Revision: &revision})
+	}
+	result := &models.RecordListResponseDefinition{Records: records, Revision: &revision}
+	if cursor == "" {
+		result.Records = records[:1]
+		result.NextCursor = "next"
+	}
+	return result, nil
+}
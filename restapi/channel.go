@@ -0,0 +1,210 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/channel"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+)
+
+var channelLogger = logger.GetLogger("Restapi:Channel")
+
+// openChannelConfig loads a client for the channel config map
+func openChannelConfig(ctx context.Context, tracer opentracing.Tracer) (*client.MapClient, error) {
+	channelMapClientTree, err := getChannelClient(ctx, trillianConnection.AdminClient, trillianConnection.MapClient, channelConfigMapID, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return &client.MapClient{MapClient: channelMapClientTree}, nil
+}
+
+// createConfigChannel creates a channel and its map, unless a concurrent request already created it, and reports whether the channel was created.
+// The map is created once, outside the retried write of the channel config map, and deleted again if the channel is not written
+func createConfigChannel(ctx context.Context, channelMapClient *client.MapClient, channelID string, tracer opentracing.Tracer) (*models.Channel, bool, error) {
+	existing, err := getChannel(ctx, channelMapClient, channelID, tracer)
+	if err != nil {
+		return nil, false, err
+	} else if existing != nil {
+		return existing, false, nil
+	}
+	mapID, err := createChannelMap(ctx, trillianConnection.AdminClient, trillianConnection.MapClient, channelConfigMapID, channelID, tracer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result *models.Channel
+	var created bool
+	err = commitCoordinator.Commit(ctx, channelConfigCommitKey, func(ctx context.Context) error {
+		existing, err := getChannel(ctx, channelMapClient, channelID, tracer)
+		if err != nil {
+			return err
+		} else if existing != nil {
+			result = existing
+			return nil
+		}
+		channelRevision, err := getCurrentRevision(channelMapClient, ctx, channelConfigMapID, tracer)
+		if err != nil {
+			return err
+		}
+		err = createChannel(ctx, trillianConnection.MapWriteClient, channelMapClient, int64(channelRevision+1), channelConfigMapID, channelID, mapID, tracer)
+		if err != nil {
+			return err
+		}
+		result = &models.Channel{ChannelID: channelID, MapID: mapID}
+		created = true
+		return nil
+	}, tracer)
+	if !created {
+		if deleteErr := deleteChannelMap(ctx, trillianConnection.AdminClient, mapID, tracer); deleteErr != nil {
+			channelLogger.Err(deleteErr).Msgf("Unable to delete the unused map of channel %v", channelID)
+		}
+	}
+	return result, created, err
+}
+
+// postChannel creates a channel that does not exist yet and describes its map
+func postChannel(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.CreateChannelParams) middleware.Responder {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return responses.ErrCreateChannelInternalServerError(err)
+	}
+	newChannel, created, err := createConfigChannel(ctx, channelMapClient, *params.Body.ChannelID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrCreateChannelVerificationFailed(err)
+		}
+		return responses.ErrCreateChannelInternalServerError(err)
+	} else if !created {
+		tracing.LogAndTraceErr(channelLogger, span, nil, responses.ChannelExists)
+		return responses.ErrCreateChannelConflict()
+	}
+
+	result, err := describeChannel(ctx, trillianConnection.AdminClient, trillianConnection.MapClient, newChannel, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return responses.ErrCreateChannelInternalServerError(err)
+	}
+	var res = channel.CreateChannelOK{Payload: result}
+	channelLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// listRegisteredChannels pages through the channel registry, reading every page from the same revision of the channel config map
+func listRegisteredChannels(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.ListChannelsParams) middleware.Responder {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return responses.ErrListChannelsInternalServerError(err)
+	}
+	var revision int64 = -1
+	if params.Revision != nil {
+		revision = *params.Revision
+	}
+	result, err := listChannels(ctx, channelMapClient, *params.Offset, *params.Limit, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrListChannelsVerificationFailed(err)
+		}
+		return responses.ErrListChannelsInternalServerError(err)
+	}
+	var res = channel.ListChannelsOK{Payload: result}
+	channelLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// retrieveChannel describes the map of a channel
+func retrieveChannel(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.GetChannelParams) middleware.Responder {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return responses.ErrGetChannelInternalServerError(err)
+	}
+	found, err := getChannel(ctx, channelMapClient, params.ChannelID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrGetChannelVerificationFailed(err)
+		}
+		return responses.ErrGetChannelInternalServerError(err)
+	} else if found == nil {
+		tracing.LogAndTraceErr(channelLogger, span, nil, responses.ChannelNotFound)
+		return responses.ErrGetChannelNotFound()
+	}
+
+	result, err := describeChannel(ctx, trillianConnection.AdminClient, trillianConnection.MapClient, found, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrGetChannelVerificationFailed(err)
+		}
+		return responses.ErrGetChannelInternalServerError(err)
+	}
+	var res = channel.GetChannelOK{Payload: result}
+	channelLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// removeChannel permanently deletes the map of a channel and removes the channel and the versions of its schema from the channel config map
+func removeChannel(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.DeleteChannelParams) middleware.Responder {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		return responses.ErrDeleteChannelInternalServerError(err)
+	}
+
+	var result *models.ChannelResponseDefinition
+	err = commitCoordinator.Commit(ctx, channelConfigCommitKey, func(ctx context.Context) error {
+		found, err := getChannel(ctx, channelMapClient, params.ChannelID, tracer)
+		if err != nil {
+			return err
+		} else if found == nil {
+			return errChannelNotFound
+		}
+		channelRevision, err := getCurrentRevision(channelMapClient, ctx, channelConfigMapID, tracer)
+		if err != nil {
+			return err
+		}
+		result, err = deleteChannel(ctx, trillianConnection.AdminClient, trillianConnection.MapWriteClient, channelMapClient, int64(channelRevision+1), channelConfigMapID, found, tracer)
+		return err
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(channelLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrDeleteChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrDeleteChannelVerificationFailed(err)
+		}
+		return responses.ErrDeleteChannelInternalServerError(err)
+	}
+	var res = channel.DeleteChannelOK{Payload: result}
+	channelLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
@@ -0,0 +1,223 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var createdChannelMaps int
+var deletedChannelMaps []int64
+var createChannelRaces int
+
+func serveChannel(t *testing.T, method string, url string, body []byte) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		createChannel = CreateChannelMock
+		createChannelMap = CreateChannelMapMock
+		deleteChannelMap = DeleteChannelMapMock
+		describeChannel = describeChannelMock
+		deleteChannel = deleteChannelMock
+		listChannels = listChannelsMock
+	}, method, url, nil, body)
+}
+
+func channelBody(channelID string) []byte {
+	body, _ := (&models.ChannelDefinition{ChannelID: &channelID}).MarshalBinary()
+	return body
+}
+
+//TestCreateChannel tests creating a channel successfully
+func TestCreateChannel(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "POST", "/channels", channelBody("new-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var res models.ChannelResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "new-channel", *res.ChannelID)
+	assert.Equal(t, int64(651), *res.MapID)
+	assert.Equal(t, "ACTIVE", *res.TreeState)
+}
+
+//TestCreateChannelConflict tests creating a channel that already exists
+func TestCreateChannelConflict(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "POST", "/channels", channelBody("test-channel"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+//TestCreateChannelRetry tests that the map of a channel is created once when writing the channel is retried
+func TestCreateChannelRetry(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	createdChannelMaps, deletedChannelMaps, createChannelRaces = 0, nil, 2
+	rr := serveChannel(t, "POST", "/channels", channelBody("raced-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, createdChannelMaps)
+	assert.Empty(t, deletedChannelMaps)
+}
+
+//TestCreateChannelErrors tests errors creating a channel
+func TestCreateChannelErrors(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	createdChannelMaps, deletedChannelMaps = 0, nil
+	rr := serveChannel(t, "POST", "/channels", channelBody("new-channel-error"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, 1, createdChannelMaps)
+	assert.Equal(t, []int64{651}, deletedChannelMaps)
+
+	rr = serveChannel(t, "POST", "/channels", channelBody("error-channel"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveChannel(t, "POST", "/channels", channelBody(""))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	getChannelClient = getChannelClientError2Mock
+	rr = serveChannel(t, "POST", "/channels", channelBody("new-channel"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestGetChannel tests describing a channel successfully
+func TestGetChannel(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "GET", "/channels/test-channel", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var res models.ChannelResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1536), *res.MapID)
+	assert.Equal(t, int64(1654), res.Revision)
+}
+
+//TestGetChannelNotFound tests describing a channel that does not exist
+func TestGetChannelNotFound(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "GET", "/channels/random-channel", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestGetChannelErrors tests errors describing a channel
+func TestGetChannelErrors(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "GET", "/channels/error-channel", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveChannel(t, "GET", "/channels/test-channel-bad-map-id", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	getChannelClient = getChannelClientError2Mock
+	rr = serveChannel(t, "GET", "/channels/test-channel", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestDeleteChannel tests deleting a channel successfully
+func TestDeleteChannel(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "DELETE", "/channels/test-channel", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var res models.ChannelResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.True(t, *res.Deleted)
+}
+
+//TestDeleteChannelNotFound tests deleting a channel that does not exist
+func TestDeleteChannelNotFound(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "DELETE", "/channels/random-channel", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestDeleteChannelErrors tests errors deleting a channel
+func TestDeleteChannelErrors(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "DELETE", "/channels/error-channel", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveChannel(t, "DELETE", "/channels/test-channel-bad-map-id", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	getChannelClient = getChannelClientError2Mock
+	rr = serveChannel(t, "DELETE", "/channels/test-channel", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestListChannels tests listing the channels of the registry with the default and explicit pagination
+func TestListChannels(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "GET", "/channels", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var res models.ChannelListResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, []string{"test-channel"}, res.Channels)
+	assert.Equal(t, int64(0), *res.Offset)
+	assert.Equal(t, int64(100), *res.Total)
+	assert.Equal(t, int64(1654), *res.Revision)
+
+	rr = serveChannel(t, "GET", "/channels?offset=5&limit=10&revision=12", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(5), *res.Offset)
+	assert.Equal(t, int64(10), *res.Total)
+	assert.Equal(t, int64(12), *res.Revision)
+}
+
+//TestListChannelsErrors tests invalid pagination and errors reading the registry
+func TestListChannelsErrors(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	rr := serveChannel(t, "GET", "/channels?limit=0", nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	rr = serveChannel(t, "GET", "/channels?offset=-1", nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	rr = serveChannel(t, "GET", "/channels?revision=321", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveChannel(t, "GET", "/channels?revision=322", nil)
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+
+	getChannelClient = getChannelClientError2Mock
+	rr = serveChannel(t, "GET", "/channels", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func describeChannelMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channel *models.Channel, tracer opentracing.Tracer) (*models.ChannelResponseDefinition, error) {
+	if channel.MapID == 321 {
+		return nil, errors.New("test-error")
+	}
+	treeState := "ACTIVE"
+	deleted := false
+	return &models.ChannelResponseDefinition{ChannelID: &channel.ChannelID, MapID: &channel.MapID, Revision: 1654, TreeState: &treeState, Deleted: &deleted}, nil
+}
+
+func deleteChannelMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapWriteClient trillian.TrillianMapWriteClient, channelMapClient *client.MapClient, revision int64, channelMapID int64, channel *models.Channel, tracer opentracing.Tracer) (*models.ChannelResponseDefinition, error) {
+	if channel.MapID == 321 {
+		return nil, errors.New("test-error")
+	}
+	treeState := "ACTIVE"
+	deleted := true
+	return &models.ChannelResponseDefinition{ChannelID: &channel.ChannelID, MapID: &channel.MapID, TreeState: &treeState, Deleted: &deleted}, nil
+}
+
+func listChannelsMock(ctx context.Context, channelMapClient *client.MapClient, offset int64, limit int64, revision int64, tracer opentracing.Tracer) (*models.ChannelListResponseDefinition, error) {
+	if revision == 321 {
+		return nil, errors.New("test-error")
+	} else if revision == 322 {
+		return nil, errVerificationMock
+	} else if revision < 0 {
+		revision = 1654
+	}
+	return &models.ChannelListResponseDefinition{Channels: []string{"test-channel"}, Offset: &offset, Total: &limit, Revision: &revision}, nil
+}
@@ -0,0 +1,365 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+)
+
+var commitLogger = logger.GetLogger("Restapi:Commit")
+
+// channelConfigCommitKey is the coordinator key serializing writes to the channel config map
+const channelConfigCommitKey = "channel-config"
+
+// channelCommitKey is the coordinator key grouping the commits to a channel
+func channelCommitKey(channelID string) string {
+	return "channel:" + channelID
+}
+
+// errChannelNotFound is returned when the channel of a commit does not exist or its map cannot be loaded
+var errChannelNotFound = errors.New(responses.ChannelNotFound)
+
+// errRecordExists is returned when a commit creates a record that already exists
+var errRecordExists = errors.New(responses.ResourceExists)
+
+// errRecordNotFound is returned when a commit changes a record that does not exist
+var errRecordNotFound = errors.New(responses.ResourceNotFound)
+
+// createsRecord reports whether a commit type creates a record rather than changing an existing one
+func createsRecord(commitType string) bool {
+	return commitType == CREATE || commitType == TRANSFERIN
+}
+
+// transfersBackIn reports whether a commit transfers a record back into a channel it was transferred out of, over the revision it was transferred out at
+func transfersBackIn(commitType string, current *models.Record) bool {
+	return commitType == TRANSFERIN && recordState(current) == stateTransferredOut
+}
+
+// attachesRecords reports whether a commit type attaches or detaches records rather than changing a single record
+func attachesRecords(commitType string) bool {
+	return commitType == ATTACH || commitType == DETACH
+}
+
+// commitRecordIDs returns the IDs of the records written by a commit, which for ATTACH and DETACH commits are the parent and child of the attachment
+func commitRecordIDs(commitType string, recordDef *models.RecordDefinition) ([]string, error) {
+	if !attachesRecords(commitType) {
+		return []string{*recordDef.RecordID}, nil
+	}
+	parentID, childID, err := dbom.AttachmentRecordIDs(recordDef)
+	if err != nil {
+		return nil, err
+	}
+	return []string{parentID, childID}, nil
+}
+
+// validCommitType reports whether a commit type is supported by commits and transactions.
+// TRANSFER-OUT and TRANSFER-IN commits are only made by transfers, which link them across channels
+func validCommitType(commitType string) bool {
+	return commitType == CREATE || commitType == UPDATE || commitType == ATTACH || commitType == DETACH || commitType == RETIRE
+}
+
+// commitRecord resolves the channel of a commit, creating it if needed, and stages the commit in the next batch written to the channel
+func commitRecord(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.CommitRecordParams) middleware.Responder {
+	if !validCommitType(params.CommitType) {
+		tracing.LogAndTraceErr(commitLogger, span, nil, responses.InvalidCommitType)
+		return responses.ErrCommitInvalidCommitType()
+	}
+	recordIDs, err := commitRecordIDs(params.CommitType, params.Body)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InvalidAttachment)
+		return responses.ErrCommitInvalidAttachment(err)
+	}
+
+	channel, mapClient, err := openCommitChannel(ctx, params.ChannelID, createsRecord(params.CommitType), tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrCommitChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrCommitVerificationFailed(err)
+		}
+		return responses.ErrCommitInternalServerError(err)
+	}
+
+	var res middleware.Responder
+	indexes := make([][]byte, len(recordIDs))
+	for i, recordID := range recordIDs {
+		indexes[i] = dbom.RecordIndex(recordID)
+	}
+	if params.IdempotencyKey != nil {
+		indexes = append(indexes, dbom.IdempotencyKeyIndex(*params.IdempotencyKey))
+	}
+	err = commitCoordinator.Stage(ctx, channelCommitKey(params.ChannelID), channelMapWriter(mapClient, channel.MapID, tracer), indexes, func(ctx context.Context, batch *dbom.Batch) error {
+		var stageErr error
+		res, stageErr = stageRecord(ctx, span, tracer, params, channel, mapClient, batch)
+		return stageErr
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		return responses.ErrCommitInternalServerError(err)
+	}
+	return res
+}
+
+// openCommitChannel loads the channel a commit is written to and a client for its map, creating the channel if create is set
+func openCommitChannel(ctx context.Context, channelID string, create bool, tracer opentracing.Tracer) (*models.Channel, *client.MapClient, error) {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		commitLogger.Err(err).Msg("Unable to load the channel config map")
+		return nil, nil, errChannelNotFound
+	}
+	channel, err := getChannel(ctx, channelMapClient, channelID, tracer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if channel == nil {
+		if !create {
+			return nil, nil, errChannelNotFound
+		}
+		channel, _, err = createConfigChannel(ctx, channelMapClient, channelID, tracer)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	mapClientTree, err := getChannelClient(ctx, trillianConnection.AdminClient, trillianConnection.MapClient, channel.MapID, tracer)
+	if err != nil {
+		commitLogger.Err(err).Msgf("Unable to load the map of channel %v", channelID)
+		return nil, nil, errChannelNotFound
+	}
+	return channel, &client.MapClient{MapClient: mapClientTree}, nil
+}
+
+// checkCommit validates a commit against the latest revision of the channel and returns the revision of the record it follows.
+// A commit changing a record must be allowed in the lifecycle state of the record by the lifecycle of the channel
+func checkCommit(ctx context.Context, batch *dbom.Batch, mapClient *client.MapClient, channel *models.Channel, commitType string, recordID string, tracer opentracing.Tracer) (int64, error) {
+	current, err := stagedRecord(ctx, batch, mapClient, recordID, tracer)
+	if err != nil {
+		return 0, err
+	}
+	if createsRecord(commitType) {
+		if current != nil && !transfersBackIn(commitType, current) {
+			return 0, errRecordExists
+		}
+		return 0, nil
+	}
+	if current == nil {
+		return 0, errRecordNotFound
+	} else if !attachesRecords(commitType) {
+		if err := checkTransition(channel, commitType, recordID, current); err != nil {
+			return 0, err
+		}
+	}
+	if current.Revision == batch.Revision() {
+		return current.PreviousRevision, nil
+	}
+	return current.Revision, nil
+}
+
+// checkAttachment validates an ATTACH or DETACH commit against the latest revision of the parent and child of its attachment, and returns the ID of the record an error is about.
+// The lifecycle of the channel must allow the commit on the child, and the parent must not be in a final lifecycle state
+func checkAttachment(ctx context.Context, batch *dbom.Batch, mapClient *client.MapClient, channel *models.Channel, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) (string, error) {
+	parentID, childID, err := dbom.AttachmentRecordIDs(recordDef)
+	if err != nil {
+		return *recordDef.RecordID, err
+	}
+	records := make([]*models.Record, 2)
+	for i, recordID := range []string{parentID, childID} {
+		current, err := stagedRecord(ctx, batch, mapClient, recordID, tracer)
+		if err != nil {
+			return recordID, err
+		} else if current == nil {
+			return recordID, errRecordNotFound
+		}
+		records[i] = current
+	}
+	parent, child := records[0], records[1]
+	if err := checkParentTransition(commitType, parentID, parent); err != nil {
+		return parentID, err
+	} else if err := checkTransition(channel, commitType, childID, child); err != nil {
+		return childID, err
+	}
+	return childID, dbom.CheckAttachment(parentID, childID, child, commitType == ATTACH)
+}
+
+// stagedRecord reads the revision of a record staged by an earlier commit of the batch, or else its latest revision
+func stagedRecord(ctx context.Context, batch *dbom.Batch, mapClient *client.MapClient, recordID string, tracer opentracing.Tracer) (*models.Record, error) {
+	value, ok := batch.Staged(dbom.RecordIndex(recordID))
+	if !ok {
+		return getRecord(ctx, mapClient, recordID, -1, tracer)
+	}
+	var staged models.Record
+	if err := staged.UnmarshalBinary(value); err != nil {
+		return nil, err
+	}
+	return &staged, nil
+}
+
+// isAttachmentConflict reports whether err rejects attaching a record that is already attached or detaching one that is not
+func isAttachmentConflict(err error) bool {
+	return errors.Is(err, dbom.ErrAlreadyAttached) || errors.Is(err, dbom.ErrNotAttached)
+}
+
+// storedRecord returns the record definition of a commit as it is stored, without the revision the commit expects and with the version of the schema its record payload was validated against
+func storedRecord(recordDef *models.RecordDefinition, schemaVersion int64) *models.RecordDefinition {
+	stored := *recordDef
+	stored.ExpectedRevision = 0
+	stored.SchemaVersion = schemaVersion
+	return &stored
+}
+
+// stageCommit stages the records written by a commit in the batch
+func stageCommit(ctx context.Context, batch *dbom.Batch, prevRevision int64, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+	switch commitType {
+	case ATTACH:
+		return attachRecord(ctx, batch, channelID, commitType, recordDef, tracer)
+	case DETACH:
+		return detachRecord(ctx, batch, channelID, commitType, recordDef, tracer)
+	}
+	return createRecord(ctx, batch, prevRevision, channelID, commitType, recordDef, tracer)
+}
+
+// stageRecord validates a commit against the latest revision of the channel and stages the new revision of the record
+// A commit retried with the idempotency key of a commit already written is not staged again, and gets the result of the one written
+func stageRecord(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.CommitRecordParams, channel *models.Channel, mapClient *client.MapClient, batch *dbom.Batch) (middleware.Responder, error) {
+	var bodyHash string
+	if params.IdempotencyKey != nil {
+		var err error
+		bodyHash, err = commitBodyHash(params.CommitType, params.Body)
+		if err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+			return responses.ErrCommitInternalServerError(err), nil
+		}
+		revision, err := replayCommit(ctx, batch, *params.IdempotencyKey, bodyHash)
+		if err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+			if errors.Is(err, errIdempotencyKeyReused) {
+				return responses.ErrCommitIdempotencyKeyReused(err), nil
+			} else if client.IsVerificationError(err) {
+				return responses.ErrCommitVerificationFailed(err), nil
+			}
+			return responses.ErrCommitInternalServerError(err), nil
+		} else if revision > 0 {
+			commitLogger.Debug().Msgf("Replaying commit %v written at revision %v", *params.IdempotencyKey, revision)
+			return commitResult(revision), nil
+		}
+	}
+	prevRevision, err := checkCommit(ctx, batch, mapClient, channel, params.CommitType, *params.Body.RecordID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		if errors.Is(err, errRecordExists) {
+			return responses.ErrCommitRecordConflict(), nil
+		} else if errors.Is(err, errInvalidTransition) {
+			return responses.ErrCommitInvalidTransition(err), nil
+		} else if errors.Is(err, errRecordNotFound) {
+			return responses.ErrCommitResourceNotFound(), nil
+		} else if client.IsVerificationError(err) {
+			return responses.ErrCommitVerificationFailed(err), nil
+		}
+		return responses.ErrCommitInternalServerError(err), nil
+	}
+	if err := checkPrecondition(params.IfMatch, params.Body.ExpectedRevision, *params.Body.RecordID, prevRevision); err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.PreconditionFailed)
+		return responses.ErrCommitPreconditionFailed(err), nil
+	}
+	var schemaVersion int64
+	if validatesPayload(params.CommitType) {
+		schemaVersion, err = checkPayload(channel, *params.Body.RecordID, params.Body.RecordIDPayload)
+		if err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.SchemaViolation)
+			if errors.Is(err, errSchemaViolation) {
+				return responses.ErrCommitSchemaViolation(err, schemaViolations(err)), nil
+			}
+			return responses.ErrCommitInternalServerError(err), nil
+		}
+	}
+	if attachesRecords(params.CommitType) {
+		if _, err := checkAttachment(ctx, batch, mapClient, channel, params.CommitType, params.Body, tracer); err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+			if errors.Is(err, errRecordNotFound) {
+				return responses.ErrCommitResourceNotFound(), nil
+			} else if errors.Is(err, errInvalidTransition) {
+				return responses.ErrCommitInvalidTransition(err), nil
+			} else if isAttachmentConflict(err) {
+				return responses.ErrCommitAttachmentConflict(err), nil
+			} else if client.IsVerificationError(err) {
+				return responses.ErrCommitVerificationFailed(err), nil
+			}
+			return responses.ErrCommitInternalServerError(err), nil
+		}
+	}
+
+	err = stageCommit(ctx, batch, prevRevision, params.ChannelID, params.CommitType, storedRecord(params.Body, schemaVersion), tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		return responses.ErrCommitInternalServerError(err), err
+	}
+	if params.IdempotencyKey != nil {
+		if err := stageIdempotencyKey(batch, *params.IdempotencyKey, bodyHash, *params.Body.RecordID); err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+			return responses.ErrCommitInternalServerError(err), err
+		}
+	}
+	return commitResult(batch.Revision()), nil
+}
+
+// commitResult returns the response to a commit written at a map revision
+func commitResult(revision int64) middleware.Responder {
+	var success = true
+	var resDef = models.CreateRecordResponseDefinition{Success: &success, Revision: revision}
+	var res = record.CommitRecordOK{Payload: &resDef}
+	commitLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// channelMapWriter reads and writes the revisions of a channel map for the commit coordinator, verifying the leaves it reads
+func channelMapWriter(mapClient *client.MapClient, mapID int64, tracer opentracing.Tracer) dbom.MapWriter {
+	return dbom.MapWriter{
+		CurrentRevision: func(ctx context.Context) (int64, error) {
+			revision, err := getCurrentRevision(mapClient, ctx, mapID, tracer)
+			return int64(revision), err
+		},
+		Read: func(ctx context.Context, indexes [][]byte, revision int64) ([]*trillian.MapLeaf, error) {
+			inclusions, _, err := getLeavesByRevision(mapClient, ctx, indexes, revision, tracer)
+			if err != nil {
+				return nil, err
+			}
+			leaves := make([]*trillian.MapLeaf, len(inclusions))
+			for i, inclusion := range inclusions {
+				leaves[i] = inclusion.GetLeaf()
+			}
+			return leaves, nil
+		},
+		Write: func(ctx context.Context, leaves []*trillian.MapLeaf, revision int64) error {
+			return addLeaves(client.NewClient(trillianConnection.MapWriteClient, mapID), ctx, leaves, revision, tracer)
+		},
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"crypto/tls"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 	dbom "trillian-agent/dbom"
 	"trillian-agent/helpers"
 	"trillian-agent/logger"
@@ -37,13 +39,11 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/opentracing/opentracing-go"
 
-	"trillian-agent/models"
 	"trillian-agent/restapi/operations"
+	"trillian-agent/restapi/operations/channel"
 	"trillian-agent/restapi/operations/record"
 
 	chiMiddleware "github.com/go-chi/chi/middleware"
-	"github.com/google/trillian"
-	"google.golang.org/grpc"
 )
 
 //go:generate swagger generate server --target ../../trillian-agent --name TrillianAgent --spec ../../../../api-specs/agent/v2/agent.json --principal interface{}
@@ -53,14 +53,54 @@ var getChannelClient = dbom.GetChannelClient
 var getCurrentRevision = (*client.MapClient).GetCurrentRevision
 var getChannel = dbom.GetChannel
 var getRecord = dbom.GetRecord
+var getRecordHistory = dbom.GetRecordHistory
+var getRecordHistoryRange = dbom.GetRecordHistoryRange
+var getChannelTree = dbom.GetChannelTree
+var getRecordProof = dbom.GetRecordProof
+var resolveRecordTree = dbom.ResolveRecordTree
+var getWhereUsed = dbom.GetWhereUsed
+var transferOutRecord = dbom.TransferOutRecord
+var getTransferSource = dbom.GetTransferSource
+var transferInRecord = dbom.TransferInRecord
 var createRecord = dbom.CreateRecord
+var patchRecord = dbom.PatchRecord
+var getIdempotencyKey = dbom.GetIdempotencyKey
+var stageIdempotencyKey = dbom.StageIdempotencyKey
+var attachRecord = dbom.AttachRecord
+var detachRecord = dbom.DetachRecord
 var createChannel = dbom.CreateChannel
+var createChannelMap = dbom.CreateChannelMap
+var deleteChannelMap = dbom.DeleteChannelMap
+var describeChannel = dbom.DescribeChannel
+var deleteChannel = dbom.DeleteChannel
+var updateChannel = dbom.UpdateChannel
+var updateChannelSchema = dbom.UpdateChannelSchema
+var getChannelSchema = dbom.GetChannelSchema
+var listChannels = dbom.ListChannels
+var listRecords = dbom.ListRecords
+var getLeavesByRevision = (*client.MapClient).GetByRevision
+var addLeaves = (*client.Client).Add
 
 var (
-	trillianEndpoint      = helpers.GetEnv("TRILLIAN_ENDPOINT", "localhost:8091")
-	channelConfigMapID, _ = strconv.ParseInt(helpers.GetEnv("CHANNEL_CONFIG_MAP_ID", "-1"), 10, 64)
+	trillianEndpoint         = helpers.GetEnv("TRILLIAN_ENDPOINT", "localhost:8091")
+	trillianKeepaliveTime    = helpers.GetEnvDuration("TRILLIAN_KEEPALIVE_TIME", 30*time.Second)
+	trillianKeepaliveTimeout = helpers.GetEnvDuration("TRILLIAN_KEEPALIVE_TIMEOUT", 10*time.Second)
+	trillianBackoffMaxDelay  = helpers.GetEnvDuration("TRILLIAN_BACKOFF_MAX_DELAY", 30*time.Second)
+	trillianConnectTimeout   = helpers.GetEnvDuration("TRILLIAN_CONNECT_TIMEOUT", 10*time.Second)
+	trillianTLSCACert        = helpers.GetEnv("TRILLIAN_TLS_CA_CERT", "")
+	trillianTLSClientCert    = helpers.GetEnv("TRILLIAN_TLS_CLIENT_CERT", "")
+	trillianTLSClientKey     = helpers.GetEnv("TRILLIAN_TLS_CLIENT_KEY", "")
+	trillianTLSServerName    = helpers.GetEnv("TRILLIAN_TLS_SERVER_NAME", "")
+	commitMaxRetries, _      = strconv.Atoi(helpers.GetEnv("COMMIT_MAX_RETRIES", "5"))
+	commitBatchWindow        = helpers.GetEnvDuration("COMMIT_BATCH_WINDOW", 10*time.Millisecond)
+	commitBatchMaxSize, _    = strconv.Atoi(helpers.GetEnv("COMMIT_BATCH_MAX_SIZE", "100"))
+	channelConfigMapID, _    = strconv.ParseInt(helpers.GetEnv("CHANNEL_CONFIG_MAP_ID", "-1"), 10, 64)
 )
 
+var trillianConnection *client.Connection
+var commitCoordinator = dbom.NewCoordinator(commitMaxRetries, commitBatchWindow, commitBatchMaxSize)
+var checkConnectivity sync.Once
+
 const (
 	//CREATE commit type
 	CREATE = "CREATE"
@@ -74,6 +114,8 @@ const (
 	TRANSFERIN = "TRANSFER-IN"
 	//TRANSFEROUT commit type
 	TRANSFEROUT = "TRANSFER-OUT"
+	//RETIRE commit type
+	RETIRE = "RETIRE"
 )
 
 func configureFlags(api *operations.TrillianAgentAPI) {
@@ -100,28 +142,190 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
-	api.RecordAuditRecordHandler = record.AuditRecordHandlerFunc(func(params record.AuditRecordParams) middleware.Responder {
+	conn, err := client.NewConnection(client.ConnectionConfig{
+		Endpoint:         trillianEndpoint,
+		KeepaliveTime:    trillianKeepaliveTime,
+		KeepaliveTimeout: trillianKeepaliveTimeout,
+		BackoffMaxDelay:  trillianBackoffMaxDelay,
+		TLS: client.TLSConfig{
+			CACertFile:     trillianTLSCACert,
+			ClientCertFile: trillianTLSClientCert,
+			ClientKeyFile:  trillianTLSClientKey,
+			ServerName:     trillianTLSServerName,
+		},
+	})
+	if err != nil {
+		apiLogger.Fatal().Err(err).Msg("Unable to create the trillian connection")
+	}
+	trillianConnection = conn
+
+	api.ChannelCreateChannelHandler = channel.CreateChannelHandlerFunc(func(params channel.CreateChannelParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelCreateChannelHandler] Entered")
 		tracer, closer, err := tracing.SetupGlobalTracer()
 		if err != nil {
 			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
 		} else {
 			defer closer.Close()
 		}
-		configLogger.Info().Msg("[Restapi:RecordAuditRecordHandler] Entered")
-		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordAuditRecordHandler")
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelCreateChannelHandler")
 		defer span.Finish()
-		conn, err := grpc.Dial(trillianEndpoint, grpc.WithInsecure())
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := postChannel(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelCreateChannelHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelListChannelsHandler = channel.ListChannelsHandlerFunc(func(params channel.ListChannelsParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelListChannelsHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
 		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrAuditInternalServerError(err)
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelListChannelsHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
 		}
-		defer conn.Close()
 
+		res := listRegisteredChannels(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelListChannelsHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelGetChannelHandler = channel.GetChannelHandlerFunc(func(params channel.GetChannelParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelGetChannelHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelGetChannelHandler")
+		defer span.Finish()
 		if ctx == nil {
 			ctx = context.Background()
 		}
-		trillMapClient := trillian.NewTrillianMapClient(conn)
-		trillAdminClient := trillian.NewTrillianAdminClient(conn)
+
+		res := retrieveChannel(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelGetChannelHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelDeleteChannelHandler = channel.DeleteChannelHandlerFunc(func(params channel.DeleteChannelParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelDeleteChannelHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelDeleteChannelHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := removeChannel(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelDeleteChannelHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelGetChannelLifecycleHandler = channel.GetChannelLifecycleHandlerFunc(func(params channel.GetChannelLifecycleParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelGetChannelLifecycleHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelGetChannelLifecycleHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := retrieveChannelLifecycle(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelGetChannelLifecycleHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelUpdateChannelLifecycleHandler = channel.UpdateChannelLifecycleHandlerFunc(func(params channel.UpdateChannelLifecycleParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelUpdateChannelLifecycleHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelUpdateChannelLifecycleHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := updateChannelLifecycle(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelUpdateChannelLifecycleHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelGetChannelSchemaHandler = channel.GetChannelSchemaHandlerFunc(func(params channel.GetChannelSchemaParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelGetChannelSchemaHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelGetChannelSchemaHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := retrieveChannelSchema(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelGetChannelSchemaHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.ChannelUpdateChannelSchemaHandler = channel.UpdateChannelSchemaHandlerFunc(func(params channel.UpdateChannelSchemaParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:ChannelUpdateChannelSchemaHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "ChannelUpdateChannelSchemaHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := setChannelSchema(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:ChannelUpdateChannelSchemaHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordAuditRecordHandler = record.AuditRecordHandlerFunc(func(params record.AuditRecordParams) middleware.Responder {
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		configLogger.Info().Msg("[Restapi:RecordAuditRecordHandler] Entered")
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordAuditRecordHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		trillMapClient := trillianConnection.MapClient
+		trillAdminClient := trillianConnection.AdminClient
 		channelMapClientTree, channelErr := getChannelClient(ctx, trillAdminClient, trillMapClient, channelConfigMapID, tracer)
 		if channelErr != nil {
 			tracing.LogAndTraceErr(apiLogger, span, channelErr, responses.InternalError)
@@ -132,6 +336,9 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 		channel, err := getChannel(ctx, &channelMapClient, params.ChannelID, tracer)
 		if err != nil {
 			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
+			if client.IsVerificationError(err) {
+				return responses.ErrAuditVerificationFailed(err)
+			}
 			return responses.ErrRetrieveRecordInternalServerError(err)
 		} else if channel == nil {
 			tracing.LogAndTraceErr(apiLogger, span, nil, responses.ChannelNotFound)
@@ -143,38 +350,12 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 			return responses.ErrAuditResourceNotFound()
 		}
 		mapClient := client.MapClient{MapClient: mapClientTree}
-		var recordList []*models.AuditDefinition
-		result, err := getRecord(ctx, &mapClient, params.RecordID, -1, tracer)
-		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrAuditInternalServerError(err)
-		} else if result == nil {
-			tracing.LogAndTraceErr(apiLogger, span, nil, responses.ResourceNotFound)
-			return responses.ErrAuditResourceNotFound()
-		}
-		var auditRecord = result.AuditDefinition
-		auditRecord.ID = &result.Revision
-		recordList = append(recordList, &auditRecord)
-		rev := result.PreviousRevision
-		for rev > 0 {
-			result, err := getRecord(ctx, &mapClient, params.RecordID, rev, tracer)
-			if err != nil {
-				tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-				return responses.ErrAuditInternalServerError(err)
-			} else if result == nil {
-				tracing.LogAndTraceErr(apiLogger, span, nil, responses.ResourceNotFound)
-				return responses.ErrAuditResourceNotFound()
-			}
-			var auditRecord = result.AuditDefinition
-			auditRecord.ID = &result.Revision
-			recordList = append(recordList, &auditRecord)
-			rev = result.PreviousRevision
+		payload, errRes := auditPage(ctx, span, tracer, params, &mapClient)
+		if errRes != nil {
+			return errRes
 		}
 
-		var payload = models.AuditResponseDefinition{
-			History: recordList,
-		}
-		var res = record.AuditRecordOK{Payload: &payload}
+		var res = record.AuditRecordOK{Payload: payload}
 		//configLogger.Debug().Msgf("%v",res.Payload)
 		configLogger.Debug().Msgf("%v", res.Payload)
 		configLogger.Info().Msg("[Restapi:RecordAuditRecordHandler] Finished")
@@ -192,132 +373,52 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 		}
 		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "CommitRecordHandlerFunc")
 		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
 
-		conn, err := grpc.Dial(trillianEndpoint, grpc.WithInsecure())
+		res := commitRecord(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:CommitRecordHandlerFunc] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordCommitTransactionHandler = record.CommitTransactionHandlerFunc(func(params record.CommitTransactionParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:CommitTransactionHandlerFunc] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
 		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrCommitInternalServerError(err)
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
 		}
-		defer conn.Close()
-
-		trillMapWriteClient := trillian.NewTrillianMapWriteClient(conn)
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "CommitTransactionHandlerFunc")
+		defer span.Finish()
 		if ctx == nil {
 			ctx = context.Background()
 		}
-		trillMapClient := trillian.NewTrillianMapClient(conn)
-		trillAdminClient := trillian.NewTrillianAdminClient(conn)
-
-		channelMapClientTree, channelErr := getChannelClient(ctx, trillAdminClient, trillMapClient, channelConfigMapID, tracer)
-		if channelErr != nil {
-			tracing.LogAndTraceErr(apiLogger, span, channelErr, responses.InternalError)
-			return responses.ErrCommitChannelNotFound()
-		}
 
-		var channelMapID = int64(0)
-		channelMapClient := client.MapClient{MapClient: channelMapClientTree}
-		channelRevision, err := getCurrentRevision(&channelMapClient, ctx, channelConfigMapID, tracer)
-		channelRevision++
+		res := commitTransaction(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:CommitTransactionHandlerFunc] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordListRecordsHandler = record.ListRecordsHandlerFunc(func(params record.ListRecordsParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordListRecordsHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
 		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrCommitInternalServerError(err)
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordListRecordsHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
 		}
-		channel, err := getChannel(ctx, &channelMapClient, params.ChannelID, tracer)
-		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrCommitInternalServerError(err)
-		}
-
-		if params.CommitType == CREATE || params.CommitType == TRANSFERIN {
-
-			revision := uint64(1)
-			err := error(nil)
-			if channel == nil {
-				channelMapID, err = createChannel(ctx, trillAdminClient, trillMapClient, trillMapWriteClient, int64(channelRevision), channelConfigMapID, params.ChannelID, tracer)
-				if err != nil {
-					tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-					return responses.ErrCommitInternalServerError(err)
-				}
-			} else {
-				channelMapID = channel.MapID
-				mapClientTree, err := getChannelClient(ctx, trillAdminClient, trillMapClient, channel.MapID, tracer)
-				if err != nil {
-					tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-					return responses.ErrCommitChannelNotFound()
-				}
-				mapClient := client.MapClient{MapClient: mapClientTree}
-				revision, err = getCurrentRevision(&mapClient, ctx, channelMapID, tracer)
-				if err != nil {
-					tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-					return responses.ErrCommitInternalServerError(err)
-				}
-
-				result, err := getRecord(ctx, &mapClient, *params.Body.RecordID, -1, tracer)
-				if err != nil {
-					tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-					return responses.ErrCommitInternalServerError(err)
-				} else if result != nil {
-					tracing.LogAndTraceErr(apiLogger, span, nil, responses.ResourceExists)
-					return responses.ErrCommitRecordConflict()
-				}
-
-				revision++
-			}
-
-			mapWriteClient := client.NewClient(trillMapWriteClient, channelMapID)
 
-			err = createRecord(ctx, mapWriteClient, int64(revision), 0, params.ChannelID, params.CommitType, params.Body, tracer)
-			if err != nil {
-				tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-				return responses.ErrCommitInternalServerError(err)
-			}
-			var success = true
-			var resDef = models.CreateRecordResponseDefinition{Success: &success}
-			var res = record.CommitRecordOK{Payload: &resDef}
-			configLogger.Debug().Msgf("%v", res.Payload)
-			configLogger.Info().Msg("[Restapi:CommitRecordHandlerFunc] Finished")
-			span.Finish()
-			return &res
-		} else if params.CommitType == UPDATE || params.CommitType == ATTACH || params.CommitType == DETACH || params.CommitType == TRANSFEROUT {
-			if channel == nil {
-				tracing.LogAndTraceErr(apiLogger, span, nil, responses.ChannelNotFound)
-				return responses.ErrRetrieveChannelNotFound()
-			}
-			mapClientTree, err := getChannelClient(ctx, trillAdminClient, trillMapClient, channel.MapID, tracer)
-			if err != nil {
-				tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-				return responses.ErrCommitChannelNotFound()
-			}
-			mapClient := client.MapClient{MapClient: mapClientTree}
-			revision, err := getCurrentRevision(&mapClient, ctx, channel.MapID, tracer)
-			if err != nil {
-				tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-				return responses.ErrCommitInternalServerError(err)
-			}
-			updateResult, err := getRecord(ctx, &mapClient, *params.Body.RecordID, -1, tracer)
-			if err != nil {
-				tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-				return responses.ErrCommitInternalServerError(err)
-			} else if updateResult == nil {
-				tracing.LogAndTraceErr(apiLogger, span, nil, responses.ResourceNotFound)
-				return responses.ErrCommitResourceNotFound()
-			}
-			revision++
-			mapWriteClient := client.NewClient(trillMapWriteClient, channel.MapID)
-			err = createRecord(ctx, mapWriteClient, int64(revision), updateResult.Revision, params.ChannelID, params.CommitType, params.Body, tracer)
-			if err != nil {
-				tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-				return responses.ErrCommitInternalServerError(err)
-			}
-			var success = true
-			var resDef = models.CreateRecordResponseDefinition{Success: &success}
-			var res = record.CommitRecordOK{Payload: &resDef}
-			configLogger.Debug().Msgf("%v", res.Payload)
-			configLogger.Info().Msg("[Restapi:CommitRecordHandlerFunc] Finished")
-			span.Finish()
-			return &res
-		}
-		tracing.LogAndTraceErr(apiLogger, span, nil, responses.InvalidCommitType)
-		return responses.ErrCommitInvalidCommitType()
+		res := listChannelRecords(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordListRecordsHandler] Finished")
+		span.Finish()
+		return res
 	})
 	api.RecordRetrieveRecordHandler = record.RetrieveRecordHandlerFunc(func(params record.RetrieveRecordParams) middleware.Responder {
 		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordHandler] Entered")
@@ -329,17 +430,11 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 		}
 		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordRetrieveRecordHandler")
 		defer span.Finish()
-		conn, err := grpc.Dial(trillianEndpoint, grpc.WithInsecure())
-		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrRetrieveRecordInternalServerError(err)
-		}
-		defer conn.Close()
 		if ctx == nil {
 			ctx = context.Background()
 		}
-		trillMapClient := trillian.NewTrillianMapClient(conn)
-		trillAdminClient := trillian.NewTrillianAdminClient(conn)
+		trillMapClient := trillianConnection.MapClient
+		trillAdminClient := trillianConnection.AdminClient
 		channelMapClientTree, channelErr := getChannelClient(ctx, trillAdminClient, trillMapClient, channelConfigMapID, tracer)
 		if channelErr != nil {
 			tracing.LogAndTraceErr(apiLogger, span, channelErr, responses.InternalError)
@@ -349,6 +444,9 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 		channel, err := getChannel(ctx, &channelMapClient, params.ChannelID, tracer)
 		if err != nil {
 			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
+			if client.IsVerificationError(err) {
+				return responses.ErrRetrieveVerificationFailed(err)
+			}
 			return responses.ErrRetrieveRecordInternalServerError(err)
 		} else if channel == nil {
 			tracing.LogAndTraceErr(apiLogger, span, nil, responses.ChannelNotFound)
@@ -360,23 +458,140 @@ func configureAPI(api *operations.TrillianAgentAPI) http.Handler {
 			return responses.ErrRetrieveResourceNotFound()
 		}
 		mapClient := client.MapClient{MapClient: mapClientTree}
-		result, err := getRecord(ctx, &mapClient, params.RecordID, -1, tracer)
-		if err != nil {
-			tracing.LogAndTraceErr(apiLogger, span, err, responses.InternalError)
-			return responses.ErrRetrieveRecordInternalServerError(err)
-		} else if result == nil {
-			tracing.LogAndTraceErr(apiLogger, span, nil, responses.ResourceNotFound)
-			return responses.ErrRetrieveResourceNotFound()
+		result, errRes := retrieveRecordAt(ctx, span, tracer, params, &mapClient)
+		if errRes != nil {
+			return errRes
+		} else if recordState(result) == stateRetired {
+			tracing.LogAndTraceErr(apiLogger, span, nil, responses.Retired)
+			return responses.ErrRetrieveRecordRetired(retirementDefinition(result))
 		}
 		rec := result.Payload.(map[string]interface{})
-		var res = record.RetrieveRecordOK{Payload: rec["recordIDPayload"]}
+		var res = record.RetrieveRecordOK{ETag: recordETag(result.Revision), Payload: rec["recordIDPayload"]}
 		configLogger.Debug().Msgf("%v", res.Payload)
 		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordHandler] Finished")
 		span.Finish()
 		return &res
 	})
+	api.RecordRetrieveRecordProofHandler = record.RetrieveRecordProofHandlerFunc(func(params record.RetrieveRecordProofParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordProofHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordRetrieveRecordProofHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := retrieveRecordProof(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordProofHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordRetrieveRecordTreeHandler = record.RetrieveRecordTreeHandlerFunc(func(params record.RetrieveRecordTreeParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordTreeHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordRetrieveRecordTreeHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := retrieveRecordTree(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordTreeHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordRetrieveWhereUsedHandler = record.RetrieveWhereUsedHandlerFunc(func(params record.RetrieveWhereUsedParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordRetrieveWhereUsedHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordRetrieveWhereUsedHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := retrieveWhereUsed(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordRetrieveWhereUsedHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordPatchRecordHandler = record.PatchRecordHandlerFunc(func(params record.PatchRecordParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordPatchRecordHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordPatchRecordHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := commitPatch(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordPatchRecordHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordRetrieveRecordDiffHandler = record.RetrieveRecordDiffHandlerFunc(func(params record.RetrieveRecordDiffParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordDiffHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordRetrieveRecordDiffHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := retrieveRecordDiff(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordRetrieveRecordDiffHandler] Finished")
+		span.Finish()
+		return res
+	})
+	api.RecordTransferRecordHandler = record.TransferRecordHandlerFunc(func(params record.TransferRecordParams) middleware.Responder {
+		configLogger.Info().Msg("[Restapi:RecordTransferRecordHandler] Entered")
+		tracer, closer, err := tracing.SetupGlobalTracer()
+		if err != nil {
+			configLogger.Err(err).Msg("Unable to initialize Jaeger tracer. Falling back to the NoopTracer")
+		} else {
+			defer closer.Close()
+		}
+		span, ctx := opentracing.StartSpanFromContextWithTracer(params.HTTPRequest.Context(), tracer, "RecordTransferRecordHandler")
+		defer span.Finish()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := transferRecord(ctx, span, tracer, params)
+		configLogger.Info().Msg("[Restapi:RecordTransferRecordHandler] Finished")
+		span.Finish()
+		return res
+	})
 	api.PreServerShutdown = func() {}
-	api.ServerShutdown = func() {}
+	api.ServerShutdown = func() {
+		if err := conn.Close(); err != nil {
+			apiLogger.Err(err).Msg("Unable to close the trillian connection")
+		}
+	}
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
@@ -391,6 +606,13 @@ func configureTLS(tlsConfig *tls.Config) {
 // This function can be called multiple times, depending on the number of serving schemes.
 // scheme value will be set accordingly: "http", "https" or "unix".
 func configureServer(s *http.Server, scheme, addr string) {
+	checkConnectivity.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), trillianConnectTimeout)
+		defer cancel()
+		if err := trillianConnection.CheckConnectivity(ctx); err != nil {
+			configLogger.Err(err).Msgf("Trillian is not reachable at %v, requests will fail until it is", trillianEndpoint)
+		}
+	})
 }
 
 // The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
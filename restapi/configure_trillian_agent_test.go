@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sync"
 	"testing"
+	dbom "trillian-agent/dbom"
 	"trillian-agent/mock"
 	"trillian-agent/models"
 	"trillian-agent/restapi/operations"
@@ -16,8 +21,11 @@ import (
 	"github.com/go-openapi/loads"
 	"github.com/google/trillian"
 	tclient "github.com/google/trillian/client"
+	"github.com/google/trillian/types"
 	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //TestAddRecord tests successfully creating a record
@@ -56,6 +64,53 @@ func TestAddRecord(t *testing.T) {
 	}
 }
 
+//TestAddRecordBatched tests that concurrent commits to a channel are written together in one revision
+func TestAddRecordBatched(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	getCurrentRevision = getCurrentRevisionMock
+	getChannel = GetChannelMock
+	getRecord = GetRecordMock
+	createRecord = dbom.CreateRecord
+	addLeaves = addLeavesMock
+	getLeavesByRevision = getLeavesByRevisionMock
+	defer func() { createRecord = CreateRecordMock }()
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	api := operations.NewTrillianAgentAPI(swaggerSpec)
+	handler := configureAPI(api)
+	writtenLeaves = nil
+
+	const commits = 10
+	var wg sync.WaitGroup
+	for i := 0; i < commits; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recordID := fmt.Sprintf("new-record-%v", i)
+			record := models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{"test": "test"}}
+			reqBody, _ := record.MarshalBinary()
+			req, _ := http.NewRequest("POST", "/channels/test-channel/records", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			req.Header.Set("commit-type", "CREATE")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, http.StatusOK, rr.Code)
+		}(i)
+	}
+	wg.Wait()
+
+	writtenLeavesMu.Lock()
+	defer writtenLeavesMu.Unlock()
+	assert.True(t, len(writtenLeaves) < commits)
+	written := 0
+	for _, leaves := range writtenLeaves {
+		written += len(leaves)
+	}
+	assert.Equal(t, 2*commits+2*len(writtenLeaves), written)
+}
+
 //TestAddRecordInvalidType tests invalid commit type
 func TestAddRecordInvalidType(t *testing.T) {
 	getChannelClient = getChannelClientMock
@@ -200,7 +255,7 @@ func TestAddRecordConflictError(t *testing.T) {
 	}
 }
 
-//TestAddRecordRevError tests a get revision error when creating a record
+//TestAddRecordRevError tests a get revision error when creating the channel of a record
 func TestAddRecordRevError(t *testing.T) {
 	getChannelClient = getChannelClientMock
 	getCurrentRevision = getCurrentRevisionMock
@@ -221,7 +276,7 @@ func TestAddRecordRevError(t *testing.T) {
 	record := models.RecordDefinition{RecordID: &recordID, RecordIDPayload: payload}
 	channelConfigMapID = -2
 	reqBody, _ := record.MarshalBinary()
-	req, err := http.NewRequest("POST", "/channels/test-channel/records", bytes.NewBuffer(reqBody))
+	req, err := http.NewRequest("POST", "/channels/new-channel/records", bytes.NewBuffer(reqBody))
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 	req.Header.Set("commit-type", "CREATE")
 	if err != nil {
@@ -386,6 +441,8 @@ func TestAddRecordCreateChannel(t *testing.T) {
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
 	createChannel = CreateChannelMock
+	createChannelMap = CreateChannelMapMock
+	deleteChannelMap = DeleteChannelMapMock
 	createRecord = CreateRecordMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
@@ -423,6 +480,8 @@ func TestAddRecordCreateChannelError(t *testing.T) {
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
 	createChannel = CreateChannelMock
+	createChannelMap = CreateChannelMapMock
+	deleteChannelMap = DeleteChannelMapMock
 	createRecord = CreateRecordMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
@@ -489,6 +548,41 @@ func TestUpdateRecord(t *testing.T) {
 	}
 }
 
+//TestUpdateRecordVerificationError tests a verification error while updating a record
+func TestUpdateRecordVerificationError(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	getCurrentRevision = getCurrentRevisionMock
+	getChannel = GetChannelMock
+	getRecord = GetRecordMock
+	createRecord = CreateRecordMock
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	api := operations.NewTrillianAgentAPI(swaggerSpec)
+	handler := configureAPI(api)
+	payload := map[string]interface{}{
+		"test": "test",
+	}
+	recordID := "unverified-record"
+	record := models.RecordDefinition{RecordID: &recordID, RecordIDPayload: payload}
+	reqBody, _ := record.MarshalBinary()
+	req, err := http.NewRequest("POST", "/channels/test-channel/records", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("commit-type", "UPDATE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusBadGateway {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusBadGateway)
+	}
+}
+
 //TestUpdateRecordError tests an error while updating a record
 func TestUpdateRecordError(t *testing.T) {
 	getChannelClient = getChannelClientMock
@@ -731,6 +825,32 @@ func TestGetRecord(t *testing.T) {
 	}
 }
 
+//TestGetRecordVerificationError tests a verification error while getting a record
+func TestGetRecordVerificationError(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	getCurrentRevision = getCurrentRevisionMock
+	getChannel = GetChannelMock
+	getRecord = GetRecordMock
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	api := operations.NewTrillianAgentAPI(swaggerSpec)
+	handler := configureAPI(api)
+	req, err := http.NewRequest("GET", "/channels/test-channel/records/unverified-record", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusBadGateway {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusBadGateway)
+	}
+}
+
 //TestGetRecordChannelError tests a get channel error while getting a record
 func TestGetRecordChannelError(t *testing.T) {
 	getChannelClient = getChannelClientMock
@@ -899,6 +1019,7 @@ func TestAuditRecord(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -920,12 +1041,40 @@ func TestAuditRecord(t *testing.T) {
 	}
 }
 
+//TestAuditRecordVerificationError tests a verification error while auditing a record
+func TestAuditRecordVerificationError(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	getCurrentRevision = getCurrentRevisionMock
+	getChannel = GetChannelMock
+	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	api := operations.NewTrillianAgentAPI(swaggerSpec)
+	handler := configureAPI(api)
+	req, err := http.NewRequest("GET", "/channels/test-channel/records/unverified-record/audit", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusBadGateway {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusBadGateway)
+	}
+}
+
 //TestAuditRecordChannelError tests a get channel error auditing a record
 func TestAuditRecordChannelError(t *testing.T) {
 	getChannelClient = getChannelClientMock
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -953,6 +1102,7 @@ func TestAuditRecordChannelNotFound(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -980,6 +1130,7 @@ func TestAuditRecordError(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -1007,6 +1158,7 @@ func TestAuditRecordNotFound(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -1034,6 +1186,7 @@ func TestAuditRecord2Error(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock2
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -1061,6 +1214,7 @@ func TestAuditRecordNotFound2(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock2
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -1088,6 +1242,7 @@ func TestAuditRecordGetClientError(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -1115,6 +1270,7 @@ func TestAuditRecordGetClientError2(t *testing.T) {
 	getCurrentRevision = getCurrentRevisionMock
 	getChannel = GetChannelMock
 	getRecord = GetRecordMock
+	getRecordHistory = GetRecordHistoryMock
 	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
 	if err != nil {
 		log.Fatalln(err)
@@ -1135,6 +1291,52 @@ func TestAuditRecordGetClientError2(t *testing.T) {
 			status, http.StatusNotFound)
 	}
 }
+// mockableFunctions are the package-level functions tests replace with mocks
+var mockableFunctions = []interface{}{
+	&getChannelClient, &getCurrentRevision, &getChannel, &getRecord, &getRecordHistory, &getRecordHistoryRange, &getChannelTree, &getRecordProof,
+	&resolveRecordTree, &getWhereUsed, &transferOutRecord, &getTransferSource, &transferInRecord, &createRecord, &patchRecord,
+	&getIdempotencyKey, &stageIdempotencyKey, &attachRecord, &detachRecord, &createChannel, &createChannelMap, &deleteChannelMap,
+	&describeChannel, &deleteChannel, &updateChannel, &updateChannelSchema, &getChannelSchema, &listChannels, &listRecords,
+	&getLeavesByRevision, &addLeaves,
+}
+
+//serveAPI replaces package-level functions with mocks, configures the API and serves a request with a JSON body and headers to it.
+//The functions are restored and the trillian connection of the API is closed when the test ends
+func serveAPI(t *testing.T, mocks func(), method string, url string, headers map[string]string, body []byte) *httptest.ResponseRecorder {
+	saved := make([]reflect.Value, len(mockableFunctions))
+	for i, f := range mockableFunctions {
+		saved[i] = reflect.ValueOf(reflect.ValueOf(f).Elem().Interface())
+	}
+	t.Cleanup(func() {
+		for i, f := range mockableFunctions {
+			reflect.ValueOf(f).Elem().Set(saved[i])
+		}
+	})
+	mocks()
+
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := operations.NewTrillianAgentAPI(swaggerSpec)
+	handler := configureAPI(api)
+	t.Cleanup(api.ServerShutdown)
+
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+var errVerificationMock = &client.VerificationError{Err: errors.New("test-error")}
+
 func getChannelClientMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channelMapID int64, tracer opentracing.Tracer) (*tclient.MapClient, error) {
 	return &tclient.MapClient{Conn: mock.NewTrillianMapMockClient(nil, false, false, false)}, nil
 }
@@ -1178,11 +1380,50 @@ func GetRecordMock(ctx context.Context, client *client.MapClient, recordID strin
 			return &models.Record{Revision: 1, PreviousRevision: 0, AuditDefinition: models.AuditDefinition{Payload: payload}}, nil
 		}
 		return &models.Record{Revision: 2, PreviousRevision: 1, AuditDefinition: models.AuditDefinition{Payload: payload2}}, nil
+	} else if recordID == "attached-record" {
+		return &models.Record{Revision: 2, PreviousRevision: 1, AuditDefinition: models.AuditDefinition{Payload: payload}, ParentRecordID: "test-record"}, nil
+	} else if recordID == "other-record" {
+		return &models.Record{Revision: 2, PreviousRevision: 1, AuditDefinition: models.AuditDefinition{Payload: payload}}, nil
 	} else if recordID == "error-record" {
 		return nil, errors.New("test-error")
+	} else if recordID == "unverified-record" {
+		return nil, errVerificationMock
 	}
 	return nil, nil
 }
+func GetRecordHistoryMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, limit int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+	var history []*models.Record
+	for int64(len(history)) < limit {
+		result, err := getRecord(ctx, client, recordID, revision, tracer)
+		if err != nil {
+			return nil, err
+		} else if result == nil {
+			break
+		}
+		history = append(history, result)
+		revision = result.PreviousRevision
+		if revision <= 0 {
+			break
+		}
+	}
+	return history, nil
+}
+func GetRecordHistoryRangeMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, from int64, to int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+	newest, err := GetRecordHistoryMock(ctx, client, recordID, revision, math.MaxInt64, tracer)
+	if err != nil {
+		return nil, err
+	}
+	var history []*models.Record
+	for i := len(newest) - 1; i >= 0; i-- {
+		if length := newest[i].HistoryLength; length != nil && *length > from && *length <= to {
+			history = append(history, newest[i])
+		}
+	}
+	if int64(len(history)) != to-from {
+		return nil, fmt.Errorf("revisions %v to %v of the history of %v are missing", from, to, recordID)
+	}
+	return history, nil
+}
 func GetRecordMock2(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.Record, error) {
 	payload := map[string]interface{}{
 		"test": "test",
@@ -1206,15 +1447,49 @@ func GetRecordMock2(ctx context.Context, client *client.MapClient, recordID stri
 	}
 	return nil, nil
 }
-func CreateRecordMock(ctx context.Context, client *client.Client, revision int64, prevRevision int64, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+func CreateRecordMock(ctx context.Context, batch *dbom.Batch, prevRevision int64, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
 	if *recordDef.RecordID == "new-record-error" || *recordDef.RecordID == "update-record-error" {
 		return errors.New("create-channel-error")
 	}
 	return nil
 }
-func CreateChannelMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, trillMapWriteClient trillian.TrillianMapWriteClient, revision int64, channelMapID int64, channelID string, tracer opentracing.Tracer) (int64, error) {
+var writtenLeavesMu sync.Mutex
+var writtenLeaves [][]*trillian.MapLeaf
+
+func addLeavesMock(c *client.Client, ctx context.Context, leaves []*trillian.MapLeaf, revision int64, tracer opentracing.Tracer) error {
+	writtenLeavesMu.Lock()
+	defer writtenLeavesMu.Unlock()
+	writtenLeaves = append(writtenLeaves, leaves)
+	return nil
+}
+func getLeavesByRevisionMock(c *client.MapClient, ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
+	inclusions := make([]*trillian.MapLeafInclusion, len(indexes))
+	for i, index := range indexes {
+		leaf := &trillian.MapLeaf{Index: index}
+		if bytes.Equal(index, dbom.RecordIndex("test-record")) || bytes.Equal(index, dbom.RecordIndex("other-record")) {
+			leaf.LeafValue, _ = (&models.Record{Revision: 2}).MarshalBinary()
+		}
+		inclusions[i] = &trillian.MapLeafInclusion{Leaf: leaf}
+	}
+	return inclusions, &types.MapRootV1{Revision: uint64(revision)}, nil
+}
+
+func CreateChannelMock(ctx context.Context, trillMapWriteClient trillian.TrillianMapWriteClient, channelMapClient *client.MapClient, revision int64, channelMapID int64, channelID string, mapID int64, tracer opentracing.Tracer) error {
 	if channelID == "new-channel-error" {
-		return -1, errors.New("create-channel-error")
+		return errors.New("create-channel-error")
+	} else if channelID == "raced-channel" && createChannelRaces > 0 {
+		createChannelRaces--
+		return status.Error(codes.FailedPrecondition, "revision mismatch")
 	}
+	return nil
+}
+
+func CreateChannelMapMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channelMapID int64, channelID string, tracer opentracing.Tracer) (int64, error) {
+	createdChannelMaps++
 	return 651, nil
 }
+
+func DeleteChannelMapMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, mapID int64, tracer opentracing.Tracer) error {
+	deletedChannelMaps = append(deletedChannelMaps, mapID)
+	return nil
+}
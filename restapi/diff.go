@@ -0,0 +1,124 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/patch"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var diffLogger = logger.GetLogger("Restapi:Diff")
+
+// retrieveRecordDiff compares the record payloads of a record at two revisions of its channel as an RFC 6902 JSON Patch described operation by operation
+func retrieveRecordDiff(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.RetrieveRecordDiffParams) middleware.Responder {
+	_, mapClient, err := openCommitChannel(ctx, params.ChannelID, false, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(diffLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrRetrieveRecordDiffChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrRetrieveRecordDiffVerificationFailed(err)
+		}
+		return responses.ErrRetrieveRecordDiffInternalServerError(err)
+	}
+
+	to := int64(-1)
+	if params.To != nil {
+		to = *params.To
+	}
+	fromRecord, errRes := readDiffRevision(ctx, span, tracer, mapClient, params.RecordID, params.From)
+	if errRes != nil {
+		return errRes
+	}
+	toRecord, errRes := readDiffRevision(ctx, span, tracer, mapClient, params.RecordID, to)
+	if errRes != nil {
+		return errRes
+	}
+	ops, err := patch.Diff(recordPayload(fromRecord), recordPayload(toRecord))
+	if err != nil {
+		tracing.LogAndTraceErr(diffLogger, span, err, responses.InternalError)
+		return responses.ErrRetrieveRecordDiffInternalServerError(err)
+	}
+
+	result := models.RecordDiffDefinition{
+		RecordID:  &params.RecordID,
+		ChannelID: &params.ChannelID,
+		From:      revisionSummary(fromRecord),
+		To:        revisionSummary(toRecord),
+		Patch:     make([]*models.JSONPatchOperationDefinition, len(ops)),
+		Summary:   make([]string, len(ops)),
+	}
+	for i, op := range ops {
+		result.Patch[i] = patchOperation(op)
+		result.Summary[i] = op.Summary()
+	}
+	var res = record.RetrieveRecordDiffOK{Payload: &result}
+	diffLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// readDiffRevision reads a record at a revision of its channel, or at the latest one if revision is not positive
+func readDiffRevision(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, mapClient *client.MapClient, recordID string, revision int64) (*models.Record, middleware.Responder) {
+	result, err := getRecord(ctx, mapClient, recordID, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(diffLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return nil, responses.ErrRetrieveRecordDiffVerificationFailed(err)
+		} else if status.Code(err) == codes.NotFound {
+			return nil, responses.ErrRetrieveRecordDiffResourceNotFound()
+		}
+		return nil, responses.ErrRetrieveRecordDiffInternalServerError(err)
+	} else if result == nil {
+		tracing.LogAndTraceErr(diffLogger, span, nil, responses.ResourceNotFound)
+		return nil, responses.ErrRetrieveRecordDiffResourceNotFound()
+	}
+	return result, nil
+}
+
+// recordPayload returns the record payload committed with a revision of a record
+func recordPayload(result *models.Record) interface{} {
+	if rec, ok := result.Payload.(map[string]interface{}); ok {
+		return rec["recordIDPayload"]
+	}
+	return nil
+}
+
+// revisionSummary describes the commit of a revision of a record
+func revisionSummary(result *models.Record) *models.RevisionSummaryDefinition {
+	revision := result.Revision
+	return &models.RevisionSummaryDefinition{Revision: &revision, EventType: result.EventType, Timestamp: result.Timestamp}
+}
+
+// patchOperation describes an operation of a patch
+func patchOperation(op *patch.Operation) *models.JSONPatchOperationDefinition {
+	name := op.Op
+	return &models.JSONPatchOperationDefinition{Op: &name, Path: &op.Path, From: op.From, Value: op.Value}
+}
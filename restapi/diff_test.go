@@ -0,0 +1,95 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serveDiff(t *testing.T, url string) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = getRecordDiffMock
+	}, "GET", url, nil, nil)
+}
+
+//TestRetrieveRecordDiff tests comparing the record payloads of two revisions of a record
+func TestRetrieveRecordDiff(t *testing.T) {
+	rr := serveDiff(t, "/channels/test-channel/records/diff-record/diff?from=1&to=2")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.RecordDiffDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "diff-record", *res.RecordID)
+	assert.Equal(t, int64(1), *res.From.Revision)
+	assert.Equal(t, CREATE, *res.From.EventType)
+	assert.Equal(t, int64(2), *res.To.Revision)
+	assert.Equal(t, UPDATE, *res.To.EventType)
+	assert.Equal(t, "2020-10-02T12:00:00.000Z", res.To.Timestamp.String())
+	assert.Equal(t, 3, len(res.Patch))
+	assert.Equal(t, "remove", *res.Patch[0].Op)
+	assert.Equal(t, "/color", *res.Patch[0].Path)
+	assert.Equal(t, "add", *res.Patch[1].Op)
+	assert.Equal(t, "/size", *res.Patch[1].Path)
+	assert.Equal(t, "L", res.Patch[1].Value)
+	assert.Equal(t, "replace", *res.Patch[2].Op)
+	assert.Equal(t, 12.5, res.Patch[2].Value)
+	assert.Equal(t, []string{`/color removed, was "blue"`, `/size added as "L"`, `/weight changed from 12 to 12.5`}, res.Summary)
+
+	rr = serveDiff(t, "/channels/test-channel/records/diff-record/diff?from=2")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(2), *res.From.Revision)
+	assert.Equal(t, int64(2), *res.To.Revision)
+	assert.Empty(t, res.Patch)
+	assert.Empty(t, res.Summary)
+}
+
+//TestRetrieveRecordDiffErrors tests comparing revisions of missing channels, records and revisions and errors reading them
+func TestRetrieveRecordDiffErrors(t *testing.T) {
+	rr := serveDiff(t, "/channels/test-channel/records/diff-record/diff?from=1&to=3")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveDiff(t, "/channels/test-channel/records/diff-record/diff")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	rr = serveDiff(t, "/channels/random-channel/records/diff-record/diff?from=1")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveDiff(t, "/channels/test-channel/records/random-record/diff?from=1")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveDiff(t, "/channels/test-channel/records/error-record/diff?from=1")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveDiff(t, "/channels/test-channel/records/unverified-record/diff?from=1")
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+// getRecordDiffMock reads diff-record, created at revision 1 and updated at revision 2
+func getRecordDiffMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.Record, error) {
+	if recordID != "diff-record" {
+		return GetRecordMock(ctx, client, recordID, revision, tracer)
+	} else if revision > 2 {
+		return nil, status.Errorf(codes.NotFound, "revision %v not found", revision)
+	}
+	channelID, eventType, payload := "test-channel", CREATE, map[string]interface{}{"weight": 12, "color": "blue"}
+	if revision != 1 {
+		revision, eventType, payload = 2, UPDATE, map[string]interface{}{"weight": 12.5, "size": "L"}
+	}
+	timestamp := strfmt.DateTime(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, int(revision-1)))
+	audit := models.AuditDefinition{ChannelID: &channelID, ResourceID: &recordID, EventType: &eventType, Payload: map[string]interface{}{"recordIDPayload": payload}, Timestamp: &timestamp}
+	return &models.Record{AuditDefinition: audit, Revision: revision, PreviousRevision: revision - 1}, nil
+}
@@ -40,21 +40,127 @@ func init() {
   "host": "localhost:3000",
   "basePath": "/",
   "paths": {
-    "/channels/{channelID}/records": {
+    "/channels": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "List the Channels",
+        "operationId": "ListChannels",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Number of channels to skip",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of channels to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Revision of the channel config map to list the channels at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Page of channels is in the body",
+            "schema": {
+              "$ref": "#/definitions/ChannelListResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
       "post": {
         "produces": [
           "application/json"
         ],
         "tags": [
-          "Record"
+          "Channel"
+        ],
+        "summary": "Create a Channel",
+        "operationId": "CreateChannel",
+        "parameters": [
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ChannelDefinition"
+            }
+          }
         ],
-        "summary": "Commit a Record",
-        "operationId": "CommitRecord",
         "responses": {
           "200": {
-            "description": "Record has been cretead successfully",
+            "description": "Channel has been created",
             "schema": {
-              "$ref": "#/definitions/CreateRecordResponseDefinition"
+              "$ref": "#/definitions/ChannelResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Channel already exists",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      }
+    },
+    "/channels/{channelID}": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Query a Channel",
+        "operationId": "GetChannel",
+        "responses": {
+          "200": {
+            "description": "Channel has been retrieved and is in the body",
+            "schema": {
+              "$ref": "#/definitions/ChannelResponseDefinition"
             }
           },
           "404": {
@@ -63,8 +169,39 @@ func init() {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
           },
-          "409": {
-            "description": "Record already exists",
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deleting a channel is permanent. The records and the schema versions of the channel cannot be read anymore, and a channel created again with the same ID starts without records nor schema",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Delete a Channel",
+        "operationId": "DeleteChannel",
+        "responses": {
+          "200": {
+            "description": "Channel has been deleted",
+            "schema": {
+              "$ref": "#/definitions/ChannelResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
             "schema": {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
@@ -84,49 +221,86 @@ func init() {
         }
       },
       "parameters": [
-        {
-          "type": "string",
-          "description": "Commit Type",
-          "name": "commit-type",
-          "in": "header",
-          "required": true
-        },
         {
           "type": "string",
           "description": "Channel ID",
           "name": "channelID",
           "in": "path",
           "required": true
-        },
-        {
-          "name": "Body",
-          "in": "body",
-          "required": true,
-          "schema": {
-            "$ref": "#/definitions/RecordDefinition"
-          }
         }
       ]
     },
-    "/channels/{channelID}/records/{recordID}": {
+    "/channels/{channelID}/lifecycle": {
       "get": {
         "produces": [
           "application/json"
         ],
         "tags": [
-          "Record"
+          "Channel"
         ],
-        "summary": "Query a Record",
-        "operationId": "RetrieveRecord",
+        "summary": "Query the record lifecycle of a Channel",
+        "operationId": "GetChannelLifecycle",
         "responses": {
           "200": {
-            "description": "Record has been retrieved and is in the body",
+            "description": "Lifecycle of the channel is in the body",
             "schema": {
-              "$ref": "#/definitions/ExampleRecordPayloadDefinition"
+              "$ref": "#/definitions/LifecycleDefinition"
             }
           },
           "404": {
-            "description": "Channel and/or record does not exist",
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "put": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Configure the record lifecycle of a Channel",
+        "operationId": "UpdateChannelLifecycle",
+        "parameters": [
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/LifecycleDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Lifecycle of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/LifecycleDefinition"
+            }
+          },
+          "400": {
+            "description": "Lifecycle is invalid",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
             "schema": {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
@@ -146,13 +320,6 @@ func init() {
         }
       },
       "parameters": [
-        {
-          "type": "string",
-          "description": "Record ID",
-          "name": "recordID",
-          "in": "path",
-          "required": true
-        },
         {
           "type": "string",
           "description": "Channel ID",
@@ -162,7 +329,7 @@ func init() {
         }
       ]
     },
-    "/channels/{channelID}/records/{recordID}/audit": {
+    "/channels/{channelID}/records": {
       "get": {
         "produces": [
           "application/json"
@@ -170,17 +337,150 @@ func init() {
         "tags": [
           "Record"
         ],
-        "summary": "Audit an record",
-        "operationId": "AuditRecord",
+        "summary": "List the Records of a Channel",
+        "operationId": "ListRecords",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cursor returned with the previous page of records, the first page is returned if it is not set",
+            "name": "cursor",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of records to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return records whose last commit has this commit type",
+            "name": "eventType",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return records last changed at or after this time",
+            "name": "changedSince",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return records last changed before this time",
+            "name": "changedBefore",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Record Audit Trail has been retrieved and is in the body",
+            "description": "Page of records is in the body",
             "schema": {
-              "$ref": "#/definitions/AuditResponseDefinition"
+              "$ref": "#/definitions/RecordListResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Cursor is invalid",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
             }
           },
           "404": {
-            "description": "Channel and/or record does not exist",
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Commit a Record",
+        "operationId": "CommitRecord",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Commit Type",
+            "name": "commit-type",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ETag of the latest revision of the record the commit is made on, as returned when retrieving the record. The commit fails if the record is at another revision",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Key identifying the commit across retries. A commit retried with the same key and body is not committed again and returns the result of the first one",
+            "name": "Idempotency-Key",
+            "in": "header"
+          },
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/RecordDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Record has been cretead successfully",
+            "schema": {
+              "$ref": "#/definitions/CreateRecordResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Attachment of the commit is invalid, or the record payload violates the schema of the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Record already exists, or the commit is not allowed in the lifecycle state of the record",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "412": {
+            "description": "Record is not at the revision the commit expects",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "422": {
+            "description": "Idempotency key was used by a commit with another commit type or body",
             "schema": {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
@@ -200,13 +500,6 @@ func init() {
         }
       },
       "parameters": [
-        {
-          "type": "string",
-          "description": "Record ID",
-          "name": "recordID",
-          "in": "path",
-          "required": true
-        },
         {
           "type": "string",
           "description": "Channel ID",
@@ -215,214 +508,134 @@ func init() {
           "required": true
         }
       ]
-    }
-  },
-  "definitions": {
-    "AuditDefinition": {
-      "type": "object",
-      "title": "AuditDefinition",
-      "required": [
-        "_id",
-        "timestamp",
-        "payload",
-        "resourceID",
-        "eventType",
-        "channelID"
-      ],
-      "properties": {
-        "_id": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "channelID": {
-          "type": "string"
-        },
-        "eventType": {
-          "type": "string"
-        },
-        "payload": {
-          "type": "object"
-        },
-        "resourceID": {
-          "type": "string"
-        },
-        "timestamp": {
-          "type": "string",
-          "format": "date-time"
-        }
-      },
-      "example": {
-        "_id": 3412,
-        "channelID": "exampleChannel",
-        "eventType": "CREATE",
-        "payload": {
-          "example": "example"
-        },
-        "resourceID": "exampleRecord",
-        "timestamp": "2020-10-01T21:04:14.840Z"
-      }
     },
-    "AuditResponseDefinition": {
-      "type": "object",
-      "title": "AuditResponseDefinition",
-      "required": [
-        "history"
-      ],
-      "properties": {
-        "history": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/AuditDefinition"
-          }
-        }
-      },
-      "example": {
-        "history": [
+    "/channels/{channelID}/records/{recordID}": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Query a Record",
+        "operationId": "RetrieveRecord",
+        "parameters": [
           {
-            "_id": 3412,
-            "channelID": "exampleChannel",
-            "eventType": "CREATE",
-            "payload": {
-              "example": "example"
-            },
-            "resourceID": "exampleRecord",
-            "timestamp": "2020-9-01T21:04:14.840Z"
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to retrieve the record at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
           },
           {
-            "_id": 3474,
-            "channelID": "exampleChannel",
-            "eventType": "UPDATE",
-            "payload": {
-              "example": "exampleUpdated"
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieve the last revision of the record committed at or before this time",
+            "name": "asOf",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Record has been retrieved and is in the body",
+            "schema": {
+              "$ref": "#/definitions/ExampleRecordPayloadDefinition"
             },
-            "resourceID": "exampleRecord",
-            "timestamp": "2020-10-02T11:54:35.345Z"
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "Revision of the retrieved record, to commit on it with If-Match"
+              }
+            }
+          },
+          "400": {
+            "description": "Both revision and asOf are set",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "410": {
+            "description": "Record has been retired, the retirement is in the body",
+            "schema": {
+              "$ref": "#/definitions/RetirementDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
           }
-        ]
-      }
-    },
-    "CreateRecordResponseDefinition": {
-      "type": "object",
-      "title": "CreateRecordResponseDefinition",
-      "required": [
-        "success"
-      ],
-      "properties": {
-        "success": {
-          "type": "boolean"
-        }
-      },
-      "example": {
-        "success": true
-      }
-    },
-    "ErrorResponseDefinition": {
-      "type": "object",
-      "title": "ErrorResponseDefinition",
-      "required": [
-        "success",
-        "status"
-      ],
-      "properties": {
-        "error": {
-          "type": "string"
-        },
-        "status": {
-          "type": "string"
-        },
-        "success": {
-          "type": "boolean"
-        }
-      },
-      "example": {
-        "error": "Channel query failed",
-        "status": "No resource found",
-        "success": false
-      }
-    },
-    "ExampleRecordPayloadDefinition": {
-      "title": "ExampleRecordPayloadDefinition",
-      "example": {
-        "example": "example"
-      }
-    },
-    "RecordDefinition": {
-      "type": "object",
-      "title": "RecordDefinition",
-      "required": [
-        "recordID",
-        "recordIDPayload"
-      ],
-      "properties": {
-        "recordID": {
-          "type": "string"
-        },
-        "recordIDPayload": {
-          "type": "object"
         }
       },
-      "example": {
-        "recordID": "exampleRecord",
-        "recordIDPayload": {
-          "example": "example"
-        }
-      }
-    }
-  },
-  "tags": [
-    {
-      "name": "Record"
-    }
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "The HTTP REST API for the Distributed Bill of Materials Agent",
-    "title": "DBoM Agent",
-    "contact": {
-      "name": "Ryan",
-      "email": "Ryan.Mathison2@unisys.com"
-    },
-    "version": "1.0"
-  },
-  "host": "localhost:3000",
-  "basePath": "/",
-  "paths": {
-    "/channels/{channelID}/records": {
-      "post": {
+      "patch": {
+        "description": "The body is an RFC 7386 JSON Merge Patch if its content type is application/merge-patch+json, or an RFC 6902 JSON Patch if it is application/json-patch+json. The patch is applied to the record payload of the latest revision of the record, and the full record payload it results in is committed",
+        "consumes": [
+          "application/merge-patch+json",
+          "application/json-patch+json"
+        ],
         "produces": [
           "application/json"
         ],
         "tags": [
           "Record"
         ],
-        "summary": "Commit a Record",
-        "operationId": "CommitRecord",
+        "summary": "Commit an UPDATE of a Record as a patch of its latest Record Payload",
+        "operationId": "PatchRecord",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ETag of the latest revision of the record the commit is made on, as returned when retrieving the record. The commit fails if the record is at another revision",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {}
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Record has been cretead successfully",
+            "description": "Record has been committed",
             "schema": {
               "$ref": "#/definitions/CreateRecordResponseDefinition"
             }
           },
+          "400": {
+            "description": "Patch is invalid, or the patched record payload violates the schema of the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
           "404": {
-            "description": "Channel does not exist",
+            "description": "Channel and/or record does not exist",
             "schema": {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
           },
           "409": {
-            "description": "Record already exists",
+            "description": "Patch does not apply to the latest record payload or the lifecycle of the channel does not allow updating the record",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "412": {
+            "description": "Record is not at the revision the patch expects",
             "schema": {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
@@ -444,9 +657,9 @@ func init() {
       "parameters": [
         {
           "type": "string",
-          "description": "Commit Type",
-          "name": "commit-type",
-          "in": "header",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
           "required": true
         },
         {
@@ -455,18 +668,10 @@ func init() {
           "name": "channelID",
           "in": "path",
           "required": true
-        },
-        {
-          "name": "Body",
-          "in": "body",
-          "required": true,
-          "schema": {
-            "$ref": "#/definitions/RecordDefinition"
-          }
         }
       ]
     },
-    "/channels/{channelID}/records/{recordID}": {
+    "/channels/{channelID}/records/{recordID}/audit": {
       "get": {
         "produces": [
           "application/json"
@@ -474,13 +679,68 @@ func init() {
         "tags": [
           "Record"
         ],
-        "summary": "Query a Record",
-        "operationId": "RetrieveRecord",
+        "summary": "Audit an record",
+        "operationId": "AuditRecord",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cursor returned with the previous page of the history, the first page is returned if it is not set",
+            "name": "cursor",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of revisions to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "newest-first",
+              "oldest-first"
+            ],
+            "type": "string",
+            "default": "newest-first",
+            "description": "Order of the revisions in the history",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return revisions committed with this commit type",
+            "name": "eventType",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return revisions committed at or after this time",
+            "name": "changedSince",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return revisions committed before this time",
+            "name": "changedBefore",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Record has been retrieved and is in the body",
+            "description": "Record Audit Trail has been retrieved and is in the body",
             "schema": {
-              "$ref": "#/definitions/ExampleRecordPayloadDefinition"
+              "$ref": "#/definitions/AuditResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Cursor is invalid",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
             }
           },
           "404": {
@@ -520,7 +780,7 @@ func init() {
         }
       ]
     },
-    "/channels/{channelID}/records/{recordID}/audit": {
+    "/channels/{channelID}/records/{recordID}/diff": {
       "get": {
         "produces": [
           "application/json"
@@ -528,13 +788,156 @@ func init() {
         "tags": [
           "Record"
         ],
-        "summary": "Audit an record",
-        "operationId": "AuditRecord",
+        "summary": "Compare the Record Payloads of two revisions of a Record",
+        "operationId": "RetrieveRecordDiff",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to read the record to compare from at",
+            "name": "from",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to read the record to compare to at, defaults to the latest revision",
+            "name": "to",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Record Audit Trail has been retrieved and is in the body",
+            "description": "Differences between the revisions are in the body",
             "schema": {
-              "$ref": "#/definitions/AuditResponseDefinition"
+              "$ref": "#/definitions/RecordDiffDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel, record and/or revision does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/proof": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Get the inclusion proof of a Record",
+        "operationId": "RetrieveRecordProof",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to prove the record at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Proof bundle of the record is in the body",
+            "schema": {
+              "$ref": "#/definitions/ProofBundleDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/transfer": {
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Transfer a Record to another Channel",
+        "operationId": "TransferRecord",
+        "responses": {
+          "200": {
+            "description": "Record has been transferred out of the channel and into the target channel",
+            "schema": {
+              "$ref": "#/definitions/TransferResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Target channel of the transfer is invalid, or the record payload violates the schema of the target channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
             }
           },
           "404": {
@@ -543,6 +946,164 @@ func init() {
               "$ref": "#/definitions/ErrorResponseDefinition"
             }
           },
+          "409": {
+            "description": "Record exists in the target channel, or cannot be transferred in its lifecycle state",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "name": "Body",
+          "in": "body",
+          "required": true,
+          "schema": {
+            "$ref": "#/definitions/TransferDefinition"
+          }
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/tree": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Resolve the bill of materials of a Record",
+        "operationId": "RetrieveRecordTree",
+        "parameters": [
+          {
+            "maximum": 100,
+            "type": "integer",
+            "format": "int64",
+            "default": 10,
+            "description": "Number of levels of attached records to resolve below the record",
+            "name": "depth",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to resolve the tree at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Include the inclusion proof of every record of the tree",
+            "name": "proofs",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Tree of attached records is in the body",
+            "schema": {
+              "$ref": "#/definitions/RecordTreeDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel, record and/or revision does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/where-used": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "List the Records a Record is or was attached to",
+        "operationId": "RetrieveWhereUsed",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to read the parents at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Parents of the record are in the body",
+            "schema": {
+              "$ref": "#/definitions/WhereUsedResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel, record and/or revision does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
           "500": {
             "description": "Error on agent",
             "schema": {
@@ -572,36 +1133,3273 @@ func init() {
           "in": "path",
           "required": true
         }
-      ]
-    }
-  },
-  "definitions": {
-    "AuditDefinition": {
+      ]
+    },
+    "/channels/{channelID}/schema": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Query the record payload schema of a Channel",
+        "operationId": "GetChannelSchema",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Version of the schema to return instead of the latest one",
+            "name": "version",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Schema of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/SchemaDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel or schema version does not exist, or no schema was set for the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Record payloads committed to the channel from then on must validate against the schema. Record payloads committed before are not validated again. An empty schema allows any record payload",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Set a new version of the record payload schema of a Channel",
+        "operationId": "UpdateChannelSchema",
+        "parameters": [
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SchemaDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Schema of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/SchemaDefinition"
+            }
+          },
+          "400": {
+            "description": "Schema is not a valid JSON Schema",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/transactions": {
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Commit several records atomically",
+        "operationId": "CommitTransaction",
+        "responses": {
+          "200": {
+            "description": "All records have been committed in one map revision",
+            "schema": {
+              "$ref": "#/definitions/TransactionResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Invalid transaction, or the record payload of an operation violates the schema of the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Record already exists, or an operation is not allowed in the lifecycle state of its record",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "412": {
+            "description": "A record of the transaction is not at the revision its operation expects",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "name": "Body",
+          "in": "body",
+          "required": true,
+          "schema": {
+            "$ref": "#/definitions/TransactionDefinition"
+          }
+        }
+      ]
+    }
+  },
+  "definitions": {
+    "AttachmentDefinition": {
+      "type": "object",
+      "title": "AttachmentDefinition",
+      "required": [
+        "parentRecordID",
+        "childRecordID"
+      ],
+      "properties": {
+        "childRecordID": {
+          "type": "string",
+          "minLength": 1
+        },
+        "parentRecordID": {
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "example": {
+        "childRecordID": "exampleComponent",
+        "parentRecordID": "exampleAssembly"
+      }
+    },
+    "AuditDefinition": {
+      "type": "object",
+      "title": "AuditDefinition",
+      "required": [
+        "_id",
+        "timestamp",
+        "payload",
+        "resourceID",
+        "eventType",
+        "channelID"
+      ],
+      "properties": {
+        "_id": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "channelID": {
+          "type": "string"
+        },
+        "eventType": {
+          "type": "string"
+        },
+        "payload": {
+          "type": "object"
+        },
+        "resourceID": {
+          "type": "string"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "transferSource": {
+          "$ref": "#/definitions/TransferSourceDefinition"
+        },
+        "transferTargetChannelID": {
+          "description": "Channel the record was transferred to by a TRANSFER-OUT commit",
+          "type": "string"
+        }
+      },
+      "example": {
+        "_id": 3412,
+        "channelID": "exampleChannel",
+        "eventType": "CREATE",
+        "payload": {
+          "example": "example"
+        },
+        "resourceID": "exampleRecord",
+        "timestamp": "2020-10-01T21:04:14.840Z"
+      }
+    },
+    "AuditResponseDefinition": {
+      "type": "object",
+      "title": "AuditResponseDefinition",
+      "required": [
+        "history"
+      ],
+      "properties": {
+        "history": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AuditDefinition"
+          }
+        },
+        "nextCursor": {
+          "description": "Cursor to request the next page of the history with, not set on the last page",
+          "type": "string"
+        }
+      },
+      "example": {
+        "history": [
+          {
+            "_id": 3412,
+            "channelID": "exampleChannel",
+            "eventType": "CREATE",
+            "payload": {
+              "example": "example"
+            },
+            "resourceID": "exampleRecord",
+            "timestamp": "2020-9-01T21:04:14.840Z"
+          },
+          {
+            "_id": 3474,
+            "channelID": "exampleChannel",
+            "eventType": "UPDATE",
+            "payload": {
+              "example": "exampleUpdated"
+            },
+            "resourceID": "exampleRecord",
+            "timestamp": "2020-10-02T11:54:35.345Z"
+          }
+        ]
+      }
+    },
+    "ChannelDefinition": {
+      "type": "object",
+      "title": "ChannelDefinition",
+      "required": [
+        "channelID"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel"
+      }
+    },
+    "ChannelListResponseDefinition": {
+      "type": "object",
+      "title": "ChannelListResponseDefinition",
+      "required": [
+        "channels",
+        "offset",
+        "total",
+        "revision"
+      ],
+      "properties": {
+        "channels": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "offset": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "revision": {
+          "description": "Revision of the channel config map the channels were read at, to request the next pages at",
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "description": "Number of channels in the registry",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "channels": [
+          "exampleChannel",
+          "otherChannel"
+        ],
+        "offset": 0,
+        "revision": 42,
+        "total": 2
+      }
+    },
+    "ChannelResponseDefinition": {
+      "type": "object",
+      "title": "ChannelResponseDefinition",
+      "required": [
+        "channelID",
+        "mapID",
+        "treeState",
+        "deleted"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "createTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "deleteTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "deleted": {
+          "type": "boolean"
+        },
+        "mapID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "revision": {
+          "description": "Current revision of the channel map",
+          "type": "integer",
+          "format": "int64"
+        },
+        "treeState": {
+          "description": "State of the channel map, such as ACTIVE or FROZEN",
+          "type": "string"
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel",
+        "createTime": "2020-06-01T12:00:00.000Z",
+        "deleted": false,
+        "mapID": 3513,
+        "revision": 12,
+        "treeState": "ACTIVE"
+      }
+    },
+    "CreateRecordResponseDefinition": {
+      "type": "object",
+      "title": "CreateRecordResponseDefinition",
+      "required": [
+        "success"
+      ],
+      "properties": {
+        "revision": {
+          "description": "Map revision the commit was written at",
+          "type": "integer",
+          "format": "int64"
+        },
+        "success": {
+          "type": "boolean"
+        }
+      },
+      "example": {
+        "success": true
+      }
+    },
+    "ErrorResponseDefinition": {
+      "type": "object",
+      "title": "ErrorResponseDefinition",
+      "required": [
+        "success",
+        "status"
+      ],
+      "properties": {
+        "error": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "success": {
+          "type": "boolean"
+        },
+        "violations": {
+          "description": "Violations of the schema of the channel by the record payload of a commit",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        }
+      },
+      "example": {
+        "error": "Channel query failed",
+        "status": "No resource found",
+        "success": false
+      }
+    },
+    "ExampleRecordPayloadDefinition": {
+      "title": "ExampleRecordPayloadDefinition",
+      "example": {
+        "example": "example"
+      }
+    },
+    "JSONPatchOperationDefinition": {
+      "description": "Operation of an RFC 6902 JSON Patch",
+      "type": "object",
+      "title": "JSONPatchOperationDefinition",
+      "required": [
+        "op",
+        "path"
+      ],
+      "properties": {
+        "from": {
+          "description": "JSON Pointer to the location a move or copy operation takes its value from",
+          "type": "string"
+        },
+        "op": {
+          "type": "string",
+          "enum": [
+            "add",
+            "remove",
+            "replace",
+            "move",
+            "copy",
+            "test"
+          ]
+        },
+        "path": {
+          "description": "JSON Pointer to the location the operation applies to",
+          "type": "string"
+        },
+        "value": {
+          "description": "Value of an add, replace or test operation"
+        }
+      }
+    },
+    "LifecycleDefinition": {
+      "type": "object",
+      "title": "LifecycleDefinition",
+      "required": [
+        "transitions"
+      ],
+      "properties": {
+        "transitions": {
+          "description": "Lifecycle states a record may be in for each commit type changing it, among active and attached. Records transferred out or retired cannot be changed anymore. Commit types that are not set keep their default states",
+          "type": "object",
+          "additionalProperties": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "example": {
+        "transitions": {
+          "ATTACH": [
+            "active"
+          ],
+          "DETACH": [
+            "attached"
+          ],
+          "RETIRE": [
+            "active"
+          ],
+          "TRANSFER-OUT": [
+            "active"
+          ],
+          "UPDATE": [
+            "active",
+            "attached"
+          ]
+        }
+      }
+    },
+    "ProofBundleDefinition": {
+      "type": "object",
+      "title": "ProofBundleDefinition",
+      "required": [
+        "channelID",
+        "recordID",
+        "mapID",
+        "revision",
+        "leafIndex",
+        "leafValue",
+        "inclusion",
+        "signedMapRoot",
+        "publicKey",
+        "hashStrategy",
+        "hashAlgorithm",
+        "signatureAlgorithm"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "hashAlgorithm": {
+          "description": "Hash algorithm used to sign the map root, such as SHA256",
+          "type": "string"
+        },
+        "hashStrategy": {
+          "description": "Hash strategy of the map, such as CONIKS_SHA256",
+          "type": "string"
+        },
+        "inclusion": {
+          "description": "Sibling hashes from the leaf to the map root, empty for default nodes",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "byte"
+          }
+        },
+        "leafIndex": {
+          "description": "SHA-256 of the record ID",
+          "type": "string",
+          "format": "byte"
+        },
+        "leafValue": {
+          "description": "Raw value of the leaf holding the record",
+          "type": "string",
+          "format": "byte"
+        },
+        "mapID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "publicKey": {
+          "description": "DER encoded public key of the map",
+          "type": "string",
+          "format": "byte"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "signatureAlgorithm": {
+          "description": "Signature algorithm used to sign the map root, such as ECDSA",
+          "type": "string"
+        },
+        "signedMapRoot": {
+          "$ref": "#/definitions/SignedMapRootDefinition"
+        }
+      }
+    },
+    "RecordDefinition": {
+      "type": "object",
+      "title": "RecordDefinition",
+      "required": [
+        "recordID",
+        "recordIDPayload"
+      ],
+      "properties": {
+        "attachment": {
+          "description": "Parent and child of an ATTACH or DETACH commit, one of which is the record of the commit",
+          "$ref": "#/definitions/AttachmentDefinition"
+        },
+        "expectedRevision": {
+          "description": "Revision the record must be at for the commit to be made on it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "recordIDPayload": {
+          "type": "object"
+        },
+        "schemaVersion": {
+          "description": "Version of the schema of the channel the record payload was validated against, set by the agent",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        }
+      },
+      "example": {
+        "recordID": "exampleRecord",
+        "recordIDPayload": {
+          "example": "example"
+        }
+      }
+    },
+    "RecordDiffDefinition": {
+      "type": "object",
+      "title": "RecordDiffDefinition",
+      "required": [
+        "recordID",
+        "channelID",
+        "from",
+        "to",
+        "patch",
+        "summary"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "from": {
+          "$ref": "#/definitions/RevisionSummaryDefinition"
+        },
+        "patch": {
+          "description": "RFC 6902 JSON Patch turning the record payload of the from revision into the one of the to revision",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/JSONPatchOperationDefinition"
+          }
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "summary": {
+          "description": "Description of each operation of the patch",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "to": {
+          "$ref": "#/definitions/RevisionSummaryDefinition"
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel",
+        "from": {
+          "eventType": "CREATE",
+          "revision": 12,
+          "timestamp": "2020-10-01T09:12:45.120Z"
+        },
+        "patch": [
+          {
+            "op": "replace",
+            "path": "/weight",
+            "value": 12.5
+          },
+          {
+            "op": "add",
+            "path": "/color",
+            "value": "red"
+          }
+        ],
+        "recordID": "exampleRecord",
+        "summary": [
+          "/weight changed from 12 to 12.5",
+          "/color added as \"red\""
+        ],
+        "to": {
+          "eventType": "UPDATE",
+          "revision": 20,
+          "timestamp": "2020-10-02T09:12:45.120Z"
+        }
+      }
+    },
+    "RecordListResponseDefinition": {
+      "type": "object",
+      "title": "RecordListResponseDefinition",
+      "required": [
+        "records",
+        "revision"
+      ],
+      "properties": {
+        "nextCursor": {
+          "description": "Cursor to request the next page of records with, not set on the last page",
+          "type": "string"
+        },
+        "records": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RecordSummaryDefinition"
+          }
+        },
+        "revision": {
+          "description": "Revision of the channel map the records were read at",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "nextCursor": "NDI6MTAw",
+        "records": [
+          {
+            "eventType": "CREATE",
+            "lastChanged": "2020-07-01T10:00:00.000Z",
+            "recordID": "exampleRecord",
+            "revision": 42
+          }
+        ],
+        "revision": 42
+      }
+    },
+    "RecordSummaryDefinition": {
+      "type": "object",
+      "title": "RecordSummaryDefinition",
+      "required": [
+        "recordID",
+        "eventType",
+        "lastChanged",
+        "revision"
+      ],
+      "properties": {
+        "eventType": {
+          "description": "Commit type of the last commit of the record",
+          "type": "string"
+        },
+        "lastChanged": {
+          "description": "Time of the last commit of the record",
+          "type": "string",
+          "format": "date-time"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the last commit of the record",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "RecordTreeDefinition": {
+      "type": "object",
+      "title": "RecordTreeDefinition",
+      "required": [
+        "revision",
+        "root"
+      ],
+      "properties": {
+        "cycles": {
+          "description": "Paths of record IDs from the root that lead back to one of their own records",
+          "type": "array",
+          "items": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          }
+        },
+        "revision": {
+          "description": "Revision of the channel map the tree was resolved at",
+          "type": "integer",
+          "format": "int64"
+        },
+        "root": {
+          "$ref": "#/definitions/RecordTreeNodeDefinition"
+        }
+      }
+    },
+    "RecordTreeNodeDefinition": {
+      "type": "object",
+      "title": "RecordTreeNodeDefinition",
+      "required": [
+        "recordID",
+        "revision",
+        "eventType"
+      ],
+      "properties": {
+        "children": {
+          "description": "Records attached to the record",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RecordTreeNodeDefinition"
+          }
+        },
+        "cycle": {
+          "description": "Set when the record is already one of its own ancestors in the tree, its attached records are not resolved again",
+          "type": "boolean"
+        },
+        "eventType": {
+          "description": "Commit type of the last commit of the record",
+          "type": "string"
+        },
+        "payload": {
+          "description": "Payload of the last commit of the record",
+          "type": "object"
+        },
+        "proof": {
+          "$ref": "#/definitions/ProofBundleDefinition"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the last commit of the record",
+          "type": "integer",
+          "format": "int64"
+        },
+        "truncated": {
+          "description": "Set when the record has attached records below the depth limit, which are not resolved",
+          "type": "boolean"
+        }
+      }
+    },
+    "RetirementDefinition": {
+      "type": "object",
+      "title": "RetirementDefinition",
+      "required": [
+        "status",
+        "recordID",
+        "channelID",
+        "revision",
+        "previousRevision",
+        "timestamp"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "metadata": {
+          "description": "Record payload of the RETIRE commit",
+          "type": "object"
+        },
+        "previousRevision": {
+          "description": "Revision of the record before it was retired",
+          "type": "integer",
+          "format": "int64"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the RETIRE commit",
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "date-time"
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel",
+        "metadata": {
+          "reason": "scrapped"
+        },
+        "previousRevision": 3412,
+        "recordID": "exampleRecord",
+        "revision": 3413,
+        "status": "Record Retired",
+        "timestamp": "2020-10-02T09:12:45.120Z"
+      }
+    },
+    "RevisionSummaryDefinition": {
+      "type": "object",
+      "title": "RevisionSummaryDefinition",
+      "required": [
+        "revision",
+        "eventType",
+        "timestamp"
+      ],
+      "properties": {
+        "eventType": {
+          "type": "string"
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "SchemaDefinition": {
+      "type": "object",
+      "title": "SchemaDefinition",
+      "required": [
+        "schema"
+      ],
+      "properties": {
+        "schema": {
+          "description": "JSON Schema (draft 4) the record payloads committed to the channel must validate against",
+          "type": "object"
+        },
+        "version": {
+          "description": "Version of the schema, counting from 1 for the first schema set for the channel",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        }
+      },
+      "example": {
+        "schema": {
+          "properties": {
+            "partNumber": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "partNumber"
+          ],
+          "type": "object"
+        },
+        "version": 1
+      }
+    },
+    "SignedMapRootDefinition": {
+      "type": "object",
+      "title": "SignedMapRootDefinition",
+      "required": [
+        "mapRoot",
+        "signature"
+      ],
+      "properties": {
+        "mapRoot": {
+          "description": "TLS encoded map root",
+          "type": "string",
+          "format": "byte"
+        },
+        "signature": {
+          "description": "Signature over the map root",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "TransactionDefinition": {
+      "type": "object",
+      "title": "TransactionDefinition",
+      "required": [
+        "operations"
+      ],
+      "properties": {
+        "operations": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/TransactionOperationDefinition"
+          }
+        }
+      },
+      "example": {
+        "operations": [
+          {
+            "commitType": "CREATE",
+            "record": {
+              "recordID": "exampleAssembly",
+              "recordIDPayload": {
+                "example": "example"
+              }
+            }
+          },
+          {
+            "commitType": "ATTACH",
+            "record": {
+              "recordID": "exampleComponent",
+              "recordIDPayload": {
+                "parent": "exampleAssembly"
+              }
+            }
+          }
+        ]
+      }
+    },
+    "TransactionOperationDefinition": {
+      "type": "object",
+      "title": "TransactionOperationDefinition",
+      "required": [
+        "commitType",
+        "record"
+      ],
+      "properties": {
+        "commitType": {
+          "type": "string"
+        },
+        "record": {
+          "$ref": "#/definitions/RecordDefinition"
+        }
+      },
+      "example": {
+        "commitType": "CREATE",
+        "record": {
+          "recordID": "exampleRecord",
+          "recordIDPayload": {
+            "example": "example"
+          }
+        }
+      }
+    },
+    "TransactionRecordDefinition": {
+      "type": "object",
+      "title": "TransactionRecordDefinition",
+      "required": [
+        "recordID",
+        "revision",
+        "previousRevision"
+      ],
+      "properties": {
+        "previousRevision": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "previousRevision": 9,
+        "recordID": "exampleComponent",
+        "revision": 12
+      }
+    },
+    "TransactionResponseDefinition": {
+      "type": "object",
+      "title": "TransactionResponseDefinition",
+      "required": [
+        "success",
+        "revision",
+        "records"
+      ],
+      "properties": {
+        "records": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/TransactionRecordDefinition"
+          }
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "success": {
+          "type": "boolean"
+        }
+      },
+      "example": {
+        "records": [
+          {
+            "previousRevision": 0,
+            "recordID": "exampleAssembly",
+            "revision": 12
+          },
+          {
+            "previousRevision": 9,
+            "recordID": "exampleComponent",
+            "revision": 12
+          }
+        ],
+        "revision": 12,
+        "success": true
+      }
+    },
+    "TransferDefinition": {
+      "type": "object",
+      "title": "TransferDefinition",
+      "required": [
+        "targetChannelID"
+      ],
+      "properties": {
+        "targetChannelID": {
+          "description": "Channel to transfer the record to, created if it does not exist",
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "example": {
+        "targetChannelID": "otherChannel"
+      }
+    },
+    "TransferResponseDefinition": {
+      "type": "object",
+      "title": "TransferResponseDefinition",
+      "required": [
+        "success",
+        "sourceRevision",
+        "targetRevision",
+        "mapRootHash"
+      ],
+      "properties": {
+        "mapRootHash": {
+          "description": "Root hash of the source channel map at the revision of the TRANSFER-OUT commit",
+          "type": "string",
+          "format": "byte"
+        },
+        "sourceRevision": {
+          "description": "Revision of the TRANSFER-OUT commit in the source channel",
+          "type": "integer",
+          "format": "int64"
+        },
+        "success": {
+          "type": "boolean"
+        },
+        "targetRevision": {
+          "description": "Revision of the TRANSFER-IN commit in the target channel",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "TransferSourceDefinition": {
+      "description": "TRANSFER-OUT commit a TRANSFER-IN commit follows",
+      "type": "object",
+      "title": "TransferSourceDefinition",
+      "required": [
+        "channelID",
+        "revision",
+        "mapRootHash"
+      ],
+      "properties": {
+        "channelID": {
+          "description": "Channel the record was transferred from",
+          "type": "string"
+        },
+        "mapRootHash": {
+          "description": "Root hash of the source channel map at the revision of the TRANSFER-OUT commit",
+          "type": "string",
+          "format": "byte"
+        },
+        "revision": {
+          "description": "Revision of the TRANSFER-OUT commit in the source channel",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "WhereUsedParentDefinition": {
+      "type": "object",
+      "title": "WhereUsedParentDefinition",
+      "required": [
+        "parentRecordID",
+        "attachRevision"
+      ],
+      "properties": {
+        "attachRevision": {
+          "description": "Revision of the ATTACH commit attaching the record to the parent",
+          "type": "integer",
+          "format": "int64"
+        },
+        "detachRevision": {
+          "description": "Revision of the DETACH commit detaching the record from the parent, not set while the record is attached",
+          "type": "integer",
+          "format": "int64"
+        },
+        "parentRecordID": {
+          "type": "string"
+        }
+      }
+    },
+    "WhereUsedResponseDefinition": {
+      "type": "object",
+      "title": "WhereUsedResponseDefinition",
+      "required": [
+        "recordID",
+        "revision",
+        "parents"
+      ],
+      "properties": {
+        "parents": {
+          "description": "Every attachment of the record to a parent, in the order they were made",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/WhereUsedParentDefinition"
+          }
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the channel map the parents were read at",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "parents": [
+          {
+            "attachRevision": 12,
+            "detachRevision": 20,
+            "parentRecordID": "exampleAssembly"
+          },
+          {
+            "attachRevision": 21,
+            "parentRecordID": "otherAssembly"
+          }
+        ],
+        "recordID": "examplePart",
+        "revision": 42
+      }
+    }
+  },
+  "tags": [
+    {
+      "name": "Channel"
+    },
+    {
+      "name": "Record"
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "The HTTP REST API for the Distributed Bill of Materials Agent",
+    "title": "DBoM Agent",
+    "contact": {
+      "name": "Ryan",
+      "email": "Ryan.Mathison2@unisys.com"
+    },
+    "version": "1.0"
+  },
+  "host": "localhost:3000",
+  "basePath": "/",
+  "paths": {
+    "/channels": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "List the Channels",
+        "operationId": "ListChannels",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Number of channels to skip",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of channels to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Revision of the channel config map to list the channels at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Page of channels is in the body",
+            "schema": {
+              "$ref": "#/definitions/ChannelListResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Create a Channel",
+        "operationId": "CreateChannel",
+        "parameters": [
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ChannelDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Channel has been created",
+            "schema": {
+              "$ref": "#/definitions/ChannelResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Channel already exists",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      }
+    },
+    "/channels/{channelID}": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Query a Channel",
+        "operationId": "GetChannel",
+        "responses": {
+          "200": {
+            "description": "Channel has been retrieved and is in the body",
+            "schema": {
+              "$ref": "#/definitions/ChannelResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deleting a channel is permanent. The records and the schema versions of the channel cannot be read anymore, and a channel created again with the same ID starts without records nor schema",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Delete a Channel",
+        "operationId": "DeleteChannel",
+        "responses": {
+          "200": {
+            "description": "Channel has been deleted",
+            "schema": {
+              "$ref": "#/definitions/ChannelResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/lifecycle": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Query the record lifecycle of a Channel",
+        "operationId": "GetChannelLifecycle",
+        "responses": {
+          "200": {
+            "description": "Lifecycle of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/LifecycleDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "put": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Configure the record lifecycle of a Channel",
+        "operationId": "UpdateChannelLifecycle",
+        "parameters": [
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/LifecycleDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Lifecycle of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/LifecycleDefinition"
+            }
+          },
+          "400": {
+            "description": "Lifecycle is invalid",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "List the Records of a Channel",
+        "operationId": "ListRecords",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cursor returned with the previous page of records, the first page is returned if it is not set",
+            "name": "cursor",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of records to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return records whose last commit has this commit type",
+            "name": "eventType",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return records last changed at or after this time",
+            "name": "changedSince",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return records last changed before this time",
+            "name": "changedBefore",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Page of records is in the body",
+            "schema": {
+              "$ref": "#/definitions/RecordListResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Cursor is invalid",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Commit a Record",
+        "operationId": "CommitRecord",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Commit Type",
+            "name": "commit-type",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "ETag of the latest revision of the record the commit is made on, as returned when retrieving the record. The commit fails if the record is at another revision",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Key identifying the commit across retries. A commit retried with the same key and body is not committed again and returns the result of the first one",
+            "name": "Idempotency-Key",
+            "in": "header"
+          },
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/RecordDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Record has been cretead successfully",
+            "schema": {
+              "$ref": "#/definitions/CreateRecordResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Attachment of the commit is invalid, or the record payload violates the schema of the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Record already exists, or the commit is not allowed in the lifecycle state of the record",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "412": {
+            "description": "Record is not at the revision the commit expects",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "422": {
+            "description": "Idempotency key was used by a commit with another commit type or body",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Query a Record",
+        "operationId": "RetrieveRecord",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to retrieve the record at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieve the last revision of the record committed at or before this time",
+            "name": "asOf",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Record has been retrieved and is in the body",
+            "schema": {
+              "$ref": "#/definitions/ExampleRecordPayloadDefinition"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "Revision of the retrieved record, to commit on it with If-Match"
+              }
+            }
+          },
+          "400": {
+            "description": "Both revision and asOf are set",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "410": {
+            "description": "Record has been retired, the retirement is in the body",
+            "schema": {
+              "$ref": "#/definitions/RetirementDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "patch": {
+        "description": "The body is an RFC 7386 JSON Merge Patch if its content type is application/merge-patch+json, or an RFC 6902 JSON Patch if it is application/json-patch+json. The patch is applied to the record payload of the latest revision of the record, and the full record payload it results in is committed",
+        "consumes": [
+          "application/json-patch+json",
+          "application/merge-patch+json"
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Commit an UPDATE of a Record as a patch of its latest Record Payload",
+        "operationId": "PatchRecord",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ETag of the latest revision of the record the commit is made on, as returned when retrieving the record. The commit fails if the record is at another revision",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {}
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Record has been committed",
+            "schema": {
+              "$ref": "#/definitions/CreateRecordResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Patch is invalid, or the patched record payload violates the schema of the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Patch does not apply to the latest record payload or the lifecycle of the channel does not allow updating the record",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "412": {
+            "description": "Record is not at the revision the patch expects",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/audit": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Audit an record",
+        "operationId": "AuditRecord",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cursor returned with the previous page of the history, the first page is returned if it is not set",
+            "name": "cursor",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "description": "Maximum number of revisions to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "newest-first",
+              "oldest-first"
+            ],
+            "type": "string",
+            "default": "newest-first",
+            "description": "Order of the revisions in the history",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return revisions committed with this commit type",
+            "name": "eventType",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return revisions committed at or after this time",
+            "name": "changedSince",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only return revisions committed before this time",
+            "name": "changedBefore",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Record Audit Trail has been retrieved and is in the body",
+            "schema": {
+              "$ref": "#/definitions/AuditResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Cursor is invalid",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/diff": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Compare the Record Payloads of two revisions of a Record",
+        "operationId": "RetrieveRecordDiff",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to read the record to compare from at",
+            "name": "from",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to read the record to compare to at, defaults to the latest revision",
+            "name": "to",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Differences between the revisions are in the body",
+            "schema": {
+              "$ref": "#/definitions/RecordDiffDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel, record and/or revision does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/proof": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Get the inclusion proof of a Record",
+        "operationId": "RetrieveRecordProof",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to prove the record at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Proof bundle of the record is in the body",
+            "schema": {
+              "$ref": "#/definitions/ProofBundleDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/transfer": {
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Transfer a Record to another Channel",
+        "operationId": "TransferRecord",
+        "responses": {
+          "200": {
+            "description": "Record has been transferred out of the channel and into the target channel",
+            "schema": {
+              "$ref": "#/definitions/TransferResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Target channel of the transfer is invalid, or the record payload violates the schema of the target channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Record exists in the target channel, or cannot be transferred in its lifecycle state",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "name": "Body",
+          "in": "body",
+          "required": true,
+          "schema": {
+            "$ref": "#/definitions/TransferDefinition"
+          }
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/tree": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Resolve the bill of materials of a Record",
+        "operationId": "RetrieveRecordTree",
+        "parameters": [
+          {
+            "maximum": 100,
+            "minimum": 0,
+            "type": "integer",
+            "format": "int64",
+            "default": 10,
+            "description": "Number of levels of attached records to resolve below the record",
+            "name": "depth",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to resolve the tree at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Include the inclusion proof of every record of the tree",
+            "name": "proofs",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Tree of attached records is in the body",
+            "schema": {
+              "$ref": "#/definitions/RecordTreeDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel, record and/or revision does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/records/{recordID}/where-used": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "List the Records a Record is or was attached to",
+        "operationId": "RetrieveWhereUsed",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Map revision to read the parents at, defaults to the latest revision",
+            "name": "revision",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Parents of the record are in the body",
+            "schema": {
+              "$ref": "#/definitions/WhereUsedResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel, record and/or revision does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Record ID",
+          "name": "recordID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/schema": {
+      "get": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Query the record payload schema of a Channel",
+        "operationId": "GetChannelSchema",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "description": "Version of the schema to return instead of the latest one",
+            "name": "version",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Schema of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/SchemaDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel or schema version does not exist, or no schema was set for the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Record payloads committed to the channel from then on must validate against the schema. Record payloads committed before are not validated again. An empty schema allows any record payload",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Channel"
+        ],
+        "summary": "Set a new version of the record payload schema of a Channel",
+        "operationId": "UpdateChannelSchema",
+        "parameters": [
+          {
+            "name": "Body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SchemaDefinition"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Schema of the channel is in the body",
+            "schema": {
+              "$ref": "#/definitions/SchemaDefinition"
+            }
+          },
+          "400": {
+            "description": "Schema is not a valid JSON Schema",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/channels/{channelID}/transactions": {
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "Record"
+        ],
+        "summary": "Commit several records atomically",
+        "operationId": "CommitTransaction",
+        "responses": {
+          "200": {
+            "description": "All records have been committed in one map revision",
+            "schema": {
+              "$ref": "#/definitions/TransactionResponseDefinition"
+            }
+          },
+          "400": {
+            "description": "Invalid transaction, or the record payload of an operation violates the schema of the channel",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "404": {
+            "description": "Channel and/or record does not exist",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "409": {
+            "description": "Record already exists, or an operation is not allowed in the lifecycle state of its record",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "412": {
+            "description": "A record of the transaction is not at the revision its operation expects",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "500": {
+            "description": "Error on agent",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          },
+          "502": {
+            "description": "Error in repository",
+            "schema": {
+              "$ref": "#/definitions/ErrorResponseDefinition"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Channel ID",
+          "name": "channelID",
+          "in": "path",
+          "required": true
+        },
+        {
+          "name": "Body",
+          "in": "body",
+          "required": true,
+          "schema": {
+            "$ref": "#/definitions/TransactionDefinition"
+          }
+        }
+      ]
+    }
+  },
+  "definitions": {
+    "AttachmentDefinition": {
+      "type": "object",
+      "title": "AttachmentDefinition",
+      "required": [
+        "parentRecordID",
+        "childRecordID"
+      ],
+      "properties": {
+        "childRecordID": {
+          "type": "string",
+          "minLength": 1
+        },
+        "parentRecordID": {
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "example": {
+        "childRecordID": "exampleComponent",
+        "parentRecordID": "exampleAssembly"
+      }
+    },
+    "AuditDefinition": {
+      "type": "object",
+      "title": "AuditDefinition",
+      "required": [
+        "_id",
+        "timestamp",
+        "payload",
+        "resourceID",
+        "eventType",
+        "channelID"
+      ],
+      "properties": {
+        "_id": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "channelID": {
+          "type": "string"
+        },
+        "eventType": {
+          "type": "string"
+        },
+        "payload": {
+          "type": "object"
+        },
+        "resourceID": {
+          "type": "string"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "transferSource": {
+          "$ref": "#/definitions/TransferSourceDefinition"
+        },
+        "transferTargetChannelID": {
+          "description": "Channel the record was transferred to by a TRANSFER-OUT commit",
+          "type": "string"
+        }
+      },
+      "example": {
+        "_id": 3412,
+        "channelID": "exampleChannel",
+        "eventType": "CREATE",
+        "payload": {
+          "example": "example"
+        },
+        "resourceID": "exampleRecord",
+        "timestamp": "2020-10-01T21:04:14.840Z"
+      }
+    },
+    "AuditResponseDefinition": {
+      "type": "object",
+      "title": "AuditResponseDefinition",
+      "required": [
+        "history"
+      ],
+      "properties": {
+        "history": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AuditDefinition"
+          }
+        },
+        "nextCursor": {
+          "description": "Cursor to request the next page of the history with, not set on the last page",
+          "type": "string"
+        }
+      },
+      "example": {
+        "history": [
+          {
+            "_id": 3412,
+            "channelID": "exampleChannel",
+            "eventType": "CREATE",
+            "payload": {
+              "example": "example"
+            },
+            "resourceID": "exampleRecord",
+            "timestamp": "2020-9-01T21:04:14.840Z"
+          },
+          {
+            "_id": 3474,
+            "channelID": "exampleChannel",
+            "eventType": "UPDATE",
+            "payload": {
+              "example": "exampleUpdated"
+            },
+            "resourceID": "exampleRecord",
+            "timestamp": "2020-10-02T11:54:35.345Z"
+          }
+        ]
+      }
+    },
+    "ChannelDefinition": {
+      "type": "object",
+      "title": "ChannelDefinition",
+      "required": [
+        "channelID"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel"
+      }
+    },
+    "ChannelListResponseDefinition": {
+      "type": "object",
+      "title": "ChannelListResponseDefinition",
+      "required": [
+        "channels",
+        "offset",
+        "total",
+        "revision"
+      ],
+      "properties": {
+        "channels": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "offset": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "revision": {
+          "description": "Revision of the channel config map the channels were read at, to request the next pages at",
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "description": "Number of channels in the registry",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "channels": [
+          "exampleChannel",
+          "otherChannel"
+        ],
+        "offset": 0,
+        "revision": 42,
+        "total": 2
+      }
+    },
+    "ChannelResponseDefinition": {
+      "type": "object",
+      "title": "ChannelResponseDefinition",
+      "required": [
+        "channelID",
+        "mapID",
+        "treeState",
+        "deleted"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "createTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "deleteTime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "deleted": {
+          "type": "boolean"
+        },
+        "mapID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "revision": {
+          "description": "Current revision of the channel map",
+          "type": "integer",
+          "format": "int64"
+        },
+        "treeState": {
+          "description": "State of the channel map, such as ACTIVE or FROZEN",
+          "type": "string"
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel",
+        "createTime": "2020-06-01T12:00:00.000Z",
+        "deleted": false,
+        "mapID": 3513,
+        "revision": 12,
+        "treeState": "ACTIVE"
+      }
+    },
+    "CreateRecordResponseDefinition": {
+      "type": "object",
+      "title": "CreateRecordResponseDefinition",
+      "required": [
+        "success"
+      ],
+      "properties": {
+        "revision": {
+          "description": "Map revision the commit was written at",
+          "type": "integer",
+          "format": "int64"
+        },
+        "success": {
+          "type": "boolean"
+        }
+      },
+      "example": {
+        "success": true
+      }
+    },
+    "ErrorResponseDefinition": {
+      "type": "object",
+      "title": "ErrorResponseDefinition",
+      "required": [
+        "success",
+        "status"
+      ],
+      "properties": {
+        "error": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "success": {
+          "type": "boolean"
+        },
+        "violations": {
+          "description": "Violations of the schema of the channel by the record payload of a commit",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-omitempty": true
+        }
+      },
+      "example": {
+        "error": "Channel query failed",
+        "status": "No resource found",
+        "success": false
+      }
+    },
+    "ExampleRecordPayloadDefinition": {
+      "title": "ExampleRecordPayloadDefinition",
+      "example": {
+        "example": "example"
+      }
+    },
+    "JSONPatchOperationDefinition": {
+      "description": "Operation of an RFC 6902 JSON Patch",
+      "type": "object",
+      "title": "JSONPatchOperationDefinition",
+      "required": [
+        "op",
+        "path"
+      ],
+      "properties": {
+        "from": {
+          "description": "JSON Pointer to the location a move or copy operation takes its value from",
+          "type": "string"
+        },
+        "op": {
+          "type": "string",
+          "enum": [
+            "add",
+            "remove",
+            "replace",
+            "move",
+            "copy",
+            "test"
+          ]
+        },
+        "path": {
+          "description": "JSON Pointer to the location the operation applies to",
+          "type": "string"
+        },
+        "value": {
+          "description": "Value of an add, replace or test operation"
+        }
+      }
+    },
+    "LifecycleDefinition": {
+      "type": "object",
+      "title": "LifecycleDefinition",
+      "required": [
+        "transitions"
+      ],
+      "properties": {
+        "transitions": {
+          "description": "Lifecycle states a record may be in for each commit type changing it, among active and attached. Records transferred out or retired cannot be changed anymore. Commit types that are not set keep their default states",
+          "type": "object",
+          "additionalProperties": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "example": {
+        "transitions": {
+          "ATTACH": [
+            "active"
+          ],
+          "DETACH": [
+            "attached"
+          ],
+          "RETIRE": [
+            "active"
+          ],
+          "TRANSFER-OUT": [
+            "active"
+          ],
+          "UPDATE": [
+            "active",
+            "attached"
+          ]
+        }
+      }
+    },
+    "ProofBundleDefinition": {
+      "type": "object",
+      "title": "ProofBundleDefinition",
+      "required": [
+        "channelID",
+        "recordID",
+        "mapID",
+        "revision",
+        "leafIndex",
+        "leafValue",
+        "inclusion",
+        "signedMapRoot",
+        "publicKey",
+        "hashStrategy",
+        "hashAlgorithm",
+        "signatureAlgorithm"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "hashAlgorithm": {
+          "description": "Hash algorithm used to sign the map root, such as SHA256",
+          "type": "string"
+        },
+        "hashStrategy": {
+          "description": "Hash strategy of the map, such as CONIKS_SHA256",
+          "type": "string"
+        },
+        "inclusion": {
+          "description": "Sibling hashes from the leaf to the map root, empty for default nodes",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "byte"
+          }
+        },
+        "leafIndex": {
+          "description": "SHA-256 of the record ID",
+          "type": "string",
+          "format": "byte"
+        },
+        "leafValue": {
+          "description": "Raw value of the leaf holding the record",
+          "type": "string",
+          "format": "byte"
+        },
+        "mapID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "publicKey": {
+          "description": "DER encoded public key of the map",
+          "type": "string",
+          "format": "byte"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "signatureAlgorithm": {
+          "description": "Signature algorithm used to sign the map root, such as ECDSA",
+          "type": "string"
+        },
+        "signedMapRoot": {
+          "$ref": "#/definitions/SignedMapRootDefinition"
+        }
+      }
+    },
+    "RecordDefinition": {
+      "type": "object",
+      "title": "RecordDefinition",
+      "required": [
+        "recordID",
+        "recordIDPayload"
+      ],
+      "properties": {
+        "attachment": {
+          "description": "Parent and child of an ATTACH or DETACH commit, one of which is the record of the commit",
+          "$ref": "#/definitions/AttachmentDefinition"
+        },
+        "expectedRevision": {
+          "description": "Revision the record must be at for the commit to be made on it",
+          "type": "integer",
+          "format": "int64",
+          "minimum": 1
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "recordIDPayload": {
+          "type": "object"
+        },
+        "schemaVersion": {
+          "description": "Version of the schema of the channel the record payload was validated against, set by the agent",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        }
+      },
+      "example": {
+        "recordID": "exampleRecord",
+        "recordIDPayload": {
+          "example": "example"
+        }
+      }
+    },
+    "RecordDiffDefinition": {
+      "type": "object",
+      "title": "RecordDiffDefinition",
+      "required": [
+        "recordID",
+        "channelID",
+        "from",
+        "to",
+        "patch",
+        "summary"
+      ],
+      "properties": {
+        "channelID": {
+          "type": "string"
+        },
+        "from": {
+          "$ref": "#/definitions/RevisionSummaryDefinition"
+        },
+        "patch": {
+          "description": "RFC 6902 JSON Patch turning the record payload of the from revision into the one of the to revision",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/JSONPatchOperationDefinition"
+          }
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "summary": {
+          "description": "Description of each operation of the patch",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "to": {
+          "$ref": "#/definitions/RevisionSummaryDefinition"
+        }
+      },
+      "example": {
+        "channelID": "exampleChannel",
+        "from": {
+          "eventType": "CREATE",
+          "revision": 12,
+          "timestamp": "2020-10-01T09:12:45.120Z"
+        },
+        "patch": [
+          {
+            "op": "replace",
+            "path": "/weight",
+            "value": 12.5
+          },
+          {
+            "op": "add",
+            "path": "/color",
+            "value": "red"
+          }
+        ],
+        "recordID": "exampleRecord",
+        "summary": [
+          "/weight changed from 12 to 12.5",
+          "/color added as \"red\""
+        ],
+        "to": {
+          "eventType": "UPDATE",
+          "revision": 20,
+          "timestamp": "2020-10-02T09:12:45.120Z"
+        }
+      }
+    },
+    "RecordListResponseDefinition": {
+      "type": "object",
+      "title": "RecordListResponseDefinition",
+      "required": [
+        "records",
+        "revision"
+      ],
+      "properties": {
+        "nextCursor": {
+          "description": "Cursor to request the next page of records with, not set on the last page",
+          "type": "string"
+        },
+        "records": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RecordSummaryDefinition"
+          }
+        },
+        "revision": {
+          "description": "Revision of the channel map the records were read at",
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "nextCursor": "NDI6MTAw",
+        "records": [
+          {
+            "eventType": "CREATE",
+            "lastChanged": "2020-07-01T10:00:00.000Z",
+            "recordID": "exampleRecord",
+            "revision": 42
+          }
+        ],
+        "revision": 42
+      }
+    },
+    "RecordSummaryDefinition": {
+      "type": "object",
+      "title": "RecordSummaryDefinition",
+      "required": [
+        "recordID",
+        "eventType",
+        "lastChanged",
+        "revision"
+      ],
+      "properties": {
+        "eventType": {
+          "description": "Commit type of the last commit of the record",
+          "type": "string"
+        },
+        "lastChanged": {
+          "description": "Time of the last commit of the record",
+          "type": "string",
+          "format": "date-time"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the last commit of the record",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "RecordTreeDefinition": {
+      "type": "object",
+      "title": "RecordTreeDefinition",
+      "required": [
+        "revision",
+        "root"
+      ],
+      "properties": {
+        "cycles": {
+          "description": "Paths of record IDs from the root that lead back to one of their own records",
+          "type": "array",
+          "items": {
+            "type": "array",
+            "items": {
+              "type": "string"
+            }
+          }
+        },
+        "revision": {
+          "description": "Revision of the channel map the tree was resolved at",
+          "type": "integer",
+          "format": "int64"
+        },
+        "root": {
+          "$ref": "#/definitions/RecordTreeNodeDefinition"
+        }
+      }
+    },
+    "RecordTreeNodeDefinition": {
       "type": "object",
-      "title": "AuditDefinition",
+      "title": "RecordTreeNodeDefinition",
       "required": [
-        "_id",
-        "timestamp",
-        "payload",
-        "resourceID",
-        "eventType",
-        "channelID"
+        "recordID",
+        "revision",
+        "eventType"
       ],
       "properties": {
-        "_id": {
+        "children": {
+          "description": "Records attached to the record",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RecordTreeNodeDefinition"
+          }
+        },
+        "cycle": {
+          "description": "Set when the record is already one of its own ancestors in the tree, its attached records are not resolved again",
+          "type": "boolean"
+        },
+        "eventType": {
+          "description": "Commit type of the last commit of the record",
+          "type": "string"
+        },
+        "payload": {
+          "description": "Payload of the last commit of the record",
+          "type": "object"
+        },
+        "proof": {
+          "$ref": "#/definitions/ProofBundleDefinition"
+        },
+        "recordID": {
+          "type": "string"
+        },
+        "revision": {
+          "description": "Revision of the last commit of the record",
           "type": "integer",
           "format": "int64"
         },
+        "truncated": {
+          "description": "Set when the record has attached records below the depth limit, which are not resolved",
+          "type": "boolean"
+        }
+      }
+    },
+    "RetirementDefinition": {
+      "type": "object",
+      "title": "RetirementDefinition",
+      "required": [
+        "status",
+        "recordID",
+        "channelID",
+        "revision",
+        "previousRevision",
+        "timestamp"
+      ],
+      "properties": {
         "channelID": {
           "type": "string"
         },
-        "eventType": {
+        "metadata": {
+          "description": "Record payload of the RETIRE commit",
+          "type": "object"
+        },
+        "previousRevision": {
+          "description": "Revision of the record before it was retired",
+          "type": "integer",
+          "format": "int64"
+        },
+        "recordID": {
           "type": "string"
         },
-        "payload": {
-          "type": "object"
+        "revision": {
+          "description": "Revision of the RETIRE commit",
+          "type": "integer",
+          "format": "int64"
         },
-        "resourceID": {
+        "status": {
           "type": "string"
         },
         "timestamp": {
@@ -610,124 +4408,364 @@ func init() {
         }
       },
       "example": {
-        "_id": 3412,
         "channelID": "exampleChannel",
-        "eventType": "CREATE",
-        "payload": {
-          "example": "example"
+        "metadata": {
+          "reason": "scrapped"
         },
-        "resourceID": "exampleRecord",
-        "timestamp": "2020-10-01T21:04:14.840Z"
+        "previousRevision": 3412,
+        "recordID": "exampleRecord",
+        "revision": 3413,
+        "status": "Record Retired",
+        "timestamp": "2020-10-02T09:12:45.120Z"
       }
     },
-    "AuditResponseDefinition": {
+    "RevisionSummaryDefinition": {
       "type": "object",
-      "title": "AuditResponseDefinition",
+      "title": "RevisionSummaryDefinition",
       "required": [
-        "history"
+        "revision",
+        "eventType",
+        "timestamp"
       ],
       "properties": {
-        "history": {
+        "eventType": {
+          "type": "string"
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "SchemaDefinition": {
+      "type": "object",
+      "title": "SchemaDefinition",
+      "required": [
+        "schema"
+      ],
+      "properties": {
+        "schema": {
+          "description": "JSON Schema (draft 4) the record payloads committed to the channel must validate against",
+          "type": "object"
+        },
+        "version": {
+          "description": "Version of the schema, counting from 1 for the first schema set for the channel",
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        }
+      },
+      "example": {
+        "schema": {
+          "properties": {
+            "partNumber": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "partNumber"
+          ],
+          "type": "object"
+        },
+        "version": 1
+      }
+    },
+    "SignedMapRootDefinition": {
+      "type": "object",
+      "title": "SignedMapRootDefinition",
+      "required": [
+        "mapRoot",
+        "signature"
+      ],
+      "properties": {
+        "mapRoot": {
+          "description": "TLS encoded map root",
+          "type": "string",
+          "format": "byte"
+        },
+        "signature": {
+          "description": "Signature over the map root",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "TransactionDefinition": {
+      "type": "object",
+      "title": "TransactionDefinition",
+      "required": [
+        "operations"
+      ],
+      "properties": {
+        "operations": {
           "type": "array",
+          "minItems": 1,
           "items": {
-            "$ref": "#/definitions/AuditDefinition"
+            "$ref": "#/definitions/TransactionOperationDefinition"
           }
         }
       },
       "example": {
-        "history": [
+        "operations": [
           {
-            "_id": 3412,
-            "channelID": "exampleChannel",
-            "eventType": "CREATE",
-            "payload": {
-              "example": "example"
-            },
-            "resourceID": "exampleRecord",
-            "timestamp": "2020-9-01T21:04:14.840Z"
+            "commitType": "CREATE",
+            "record": {
+              "recordID": "exampleAssembly",
+              "recordIDPayload": {
+                "example": "example"
+              }
+            }
           },
           {
-            "_id": 3474,
-            "channelID": "exampleChannel",
-            "eventType": "UPDATE",
-            "payload": {
-              "example": "exampleUpdated"
-            },
-            "resourceID": "exampleRecord",
-            "timestamp": "2020-10-02T11:54:35.345Z"
+            "commitType": "ATTACH",
+            "record": {
+              "recordID": "exampleComponent",
+              "recordIDPayload": {
+                "parent": "exampleAssembly"
+              }
+            }
           }
         ]
       }
     },
-    "CreateRecordResponseDefinition": {
+    "TransactionOperationDefinition": {
       "type": "object",
-      "title": "CreateRecordResponseDefinition",
+      "title": "TransactionOperationDefinition",
       "required": [
-        "success"
+        "commitType",
+        "record"
       ],
       "properties": {
-        "success": {
-          "type": "boolean"
+        "commitType": {
+          "type": "string"
+        },
+        "record": {
+          "$ref": "#/definitions/RecordDefinition"
         }
       },
       "example": {
-        "success": true
+        "commitType": "CREATE",
+        "record": {
+          "recordID": "exampleRecord",
+          "recordIDPayload": {
+            "example": "example"
+          }
+        }
       }
     },
-    "ErrorResponseDefinition": {
+    "TransactionRecordDefinition": {
       "type": "object",
-      "title": "ErrorResponseDefinition",
+      "title": "TransactionRecordDefinition",
       "required": [
-        "success",
-        "status"
+        "recordID",
+        "revision",
+        "previousRevision"
       ],
       "properties": {
-        "error": {
-          "type": "string"
+        "previousRevision": {
+          "type": "integer",
+          "format": "int64"
         },
-        "status": {
+        "recordID": {
           "type": "string"
         },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        }
+      },
+      "example": {
+        "previousRevision": 9,
+        "recordID": "exampleComponent",
+        "revision": 12
+      }
+    },
+    "TransactionResponseDefinition": {
+      "type": "object",
+      "title": "TransactionResponseDefinition",
+      "required": [
+        "success",
+        "revision",
+        "records"
+      ],
+      "properties": {
+        "records": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/TransactionRecordDefinition"
+          }
+        },
+        "revision": {
+          "type": "integer",
+          "format": "int64"
+        },
         "success": {
           "type": "boolean"
         }
       },
       "example": {
-        "error": "Channel query failed",
-        "status": "No resource found",
-        "success": false
+        "records": [
+          {
+            "previousRevision": 0,
+            "recordID": "exampleAssembly",
+            "revision": 12
+          },
+          {
+            "previousRevision": 9,
+            "recordID": "exampleComponent",
+            "revision": 12
+          }
+        ],
+        "revision": 12,
+        "success": true
       }
     },
-    "ExampleRecordPayloadDefinition": {
-      "title": "ExampleRecordPayloadDefinition",
+    "TransferDefinition": {
+      "type": "object",
+      "title": "TransferDefinition",
+      "required": [
+        "targetChannelID"
+      ],
+      "properties": {
+        "targetChannelID": {
+          "description": "Channel to transfer the record to, created if it does not exist",
+          "type": "string",
+          "minLength": 1
+        }
+      },
       "example": {
-        "example": "example"
+        "targetChannelID": "otherChannel"
       }
     },
-    "RecordDefinition": {
+    "TransferResponseDefinition": {
       "type": "object",
-      "title": "RecordDefinition",
+      "title": "TransferResponseDefinition",
+      "required": [
+        "success",
+        "sourceRevision",
+        "targetRevision",
+        "mapRootHash"
+      ],
+      "properties": {
+        "mapRootHash": {
+          "description": "Root hash of the source channel map at the revision of the TRANSFER-OUT commit",
+          "type": "string",
+          "format": "byte"
+        },
+        "sourceRevision": {
+          "description": "Revision of the TRANSFER-OUT commit in the source channel",
+          "type": "integer",
+          "format": "int64"
+        },
+        "success": {
+          "type": "boolean"
+        },
+        "targetRevision": {
+          "description": "Revision of the TRANSFER-IN commit in the target channel",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "TransferSourceDefinition": {
+      "description": "TRANSFER-OUT commit a TRANSFER-IN commit follows",
+      "type": "object",
+      "title": "TransferSourceDefinition",
+      "required": [
+        "channelID",
+        "revision",
+        "mapRootHash"
+      ],
+      "properties": {
+        "channelID": {
+          "description": "Channel the record was transferred from",
+          "type": "string"
+        },
+        "mapRootHash": {
+          "description": "Root hash of the source channel map at the revision of the TRANSFER-OUT commit",
+          "type": "string",
+          "format": "byte"
+        },
+        "revision": {
+          "description": "Revision of the TRANSFER-OUT commit in the source channel",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "WhereUsedParentDefinition": {
+      "type": "object",
+      "title": "WhereUsedParentDefinition",
+      "required": [
+        "parentRecordID",
+        "attachRevision"
+      ],
+      "properties": {
+        "attachRevision": {
+          "description": "Revision of the ATTACH commit attaching the record to the parent",
+          "type": "integer",
+          "format": "int64"
+        },
+        "detachRevision": {
+          "description": "Revision of the DETACH commit detaching the record from the parent, not set while the record is attached",
+          "type": "integer",
+          "format": "int64"
+        },
+        "parentRecordID": {
+          "type": "string"
+        }
+      }
+    },
+    "WhereUsedResponseDefinition": {
+      "type": "object",
+      "title": "WhereUsedResponseDefinition",
       "required": [
         "recordID",
-        "recordIDPayload"
+        "revision",
+        "parents"
       ],
       "properties": {
+        "parents": {
+          "description": "Every attachment of the record to a parent, in the order they were made",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/WhereUsedParentDefinition"
+          }
+        },
         "recordID": {
           "type": "string"
         },
-        "recordIDPayload": {
-          "type": "object"
+        "revision": {
+          "description": "Revision of the channel map the parents were read at",
+          "type": "integer",
+          "format": "int64"
         }
       },
       "example": {
-        "recordID": "exampleRecord",
-        "recordIDPayload": {
-          "example": "example"
-        }
+        "parents": [
+          {
+            "attachRevision": 12,
+            "detachRevision": 20,
+            "parentRecordID": "exampleAssembly"
+          },
+          {
+            "attachRevision": 21,
+            "parentRecordID": "otherAssembly"
+          }
+        ],
+        "recordID": "examplePart",
+        "revision": 42
       }
     }
   },
   "tags": [
+    {
+      "name": "Channel"
+    },
     {
       "name": "Record"
     }
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+
+	"golang.org/x/net/context"
+)
+
+// errIdempotencyKeyReused is returned when an idempotency key was used by a commit with another commit type or body
+var errIdempotencyKeyReused = errors.New(responses.IdempotencyKeyReused)
+
+// commitBodyHash returns the hex encoded SHA-256 hash of the commit type and body of a commit, which a commit retried with the same idempotency key must match
+func commitBodyHash(commitType string, recordDef *models.RecordDefinition) (string, error) {
+	body, err := recordDef.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	hasher := sha256.New()
+	hasher.Write([]byte(commitType + "\n"))
+	hasher.Write(body)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// replayCommit returns the revision the commit made with an idempotency key was written at, or 0 if no commit was made with it yet.
+// The commit made with the key must have the body hash of the one retrying it
+func replayCommit(ctx context.Context, batch *dbom.Batch, key string, bodyHash string) (int64, error) {
+	idempotencyKey, err := getIdempotencyKey(ctx, batch, key)
+	if err != nil {
+		return 0, err
+	} else if idempotencyKey == nil {
+		return 0, nil
+	} else if idempotencyKey.BodyHash != bodyHash {
+		return 0, fmt.Errorf("%w: %v was used by a commit to %v at revision %v with another commit type or body", errIdempotencyKeyReused, key, idempotencyKey.RecordID, idempotencyKey.Revision)
+	}
+	return idempotencyKey.Revision, nil
+}
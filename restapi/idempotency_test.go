@@ -0,0 +1,102 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func serveIdempotent(t *testing.T, commitType string, idempotencyKey string, recordDef *models.RecordDefinition) *httptest.ResponseRecorder {
+	committedRecords = nil
+	headers := map[string]string{"commit-type": commitType}
+	if idempotencyKey != "" {
+		headers["Idempotency-Key"] = idempotencyKey
+	}
+	body, _ := recordDef.MarshalBinary()
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = GetRecordMock
+		createRecord = createRecordPreconditionMock
+		getLeavesByRevision = getWrittenLeavesMock
+		addLeaves = addLeavesMock
+	}, "POST", "/channels/test-channel/records", headers, body)
+}
+
+//TestCommitIdempotencyKey tests that a commit retried with its idempotency key and body gets the result of the commit written, without being committed again
+func TestCommitIdempotencyKey(t *testing.T) {
+	writtenLeaves = nil
+	rr := serveIdempotent(t, UPDATE, "update-1", preconditionRecord("test-record", 0))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.CreateRecordResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1655), res.Revision)
+	assert.Equal(t, 1, len(committedRecords))
+	assert.Equal(t, 1, len(writtenLeaves))
+	assert.Equal(t, dbom.IdempotencyKeyIndex("update-1"), writtenLeaves[0][0].Index)
+
+	rr = serveIdempotent(t, UPDATE, "update-1", preconditionRecord("test-record", 0))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1655), res.Revision)
+	assert.Empty(t, committedRecords)
+
+	rr = serveIdempotent(t, UPDATE, "update-2", preconditionRecord("test-record", 0))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, len(committedRecords))
+
+	rr = serveIdempotent(t, UPDATE, "", preconditionRecord("test-record", 0))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, len(committedRecords))
+	assert.Equal(t, 2, len(writtenLeaves))
+}
+
+//TestCommitIdempotencyKeyReused tests that an idempotency key cannot be used by commits with different commit types or bodies
+func TestCommitIdempotencyKeyReused(t *testing.T) {
+	writtenLeaves = nil
+	rr := serveIdempotent(t, UPDATE, "update-1", preconditionRecord("test-record", 0))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	recordDef := preconditionRecord("test-record", 0)
+	recordDef.RecordIDPayload = map[string]interface{}{"test": "other"}
+	rr = serveIdempotent(t, UPDATE, "update-1", recordDef)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Idempotency Key Reused", *res.Status)
+	assert.Equal(t, "Idempotency Key Reused: update-1 was used by a commit to test-record at revision 1655 with another commit type or body", res.Error)
+	assert.Empty(t, committedRecords)
+
+	rr = serveIdempotent(t, RETIRE, "update-1", preconditionRecord("test-record", 0))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Empty(t, committedRecords)
+}
+
+// getWrittenLeavesMock reads the leaves last written by addLeavesMock, or else the leaves of getLeavesByRevisionMock
+func getWrittenLeavesMock(c *client.MapClient, ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
+	inclusions, mapRoot, err := getLeavesByRevisionMock(c, ctx, indexes, revision, tracer)
+	writtenLeavesMu.Lock()
+	defer writtenLeavesMu.Unlock()
+	for _, inclusion := range inclusions {
+		for _, leaves := range writtenLeaves {
+			for _, leaf := range leaves {
+				if bytes.Equal(leaf.Index, inclusion.Leaf.Index) {
+					inclusion.Leaf = leaf
+				}
+			}
+		}
+	}
+	return inclusions, mapRoot, err
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/channel"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+)
+
+var lifecycleLogger = logger.GetLogger("Restapi:Lifecycle")
+
+const (
+	// stateActive is the lifecycle state of a record that is not attached to a parent
+	stateActive = "active"
+	// stateAttached is the lifecycle state of a record attached to a parent
+	stateAttached = "attached"
+	// stateTransferredOut is the lifecycle state of a record transferred out of its channel
+	stateTransferredOut = "transferred-out"
+	// stateRetired is the lifecycle state of a record that has been retired
+	stateRetired = "retired"
+)
+
+// defaultLifecycle holds the lifecycle states a record may be in for each commit type changing it, unless its channel sets other states.
+// The states of ATTACH and DETACH commits are the states of the child of the attachment
+var defaultLifecycle = map[string][]string{
+	UPDATE:      {stateActive, stateAttached},
+	ATTACH:      {stateActive},
+	DETACH:      {stateAttached},
+	TRANSFEROUT: {stateActive},
+	RETIRE:      {stateActive},
+}
+
+// lifecycleStates holds the lifecycle states of records
+var lifecycleStates = map[string]bool{stateActive: true, stateAttached: true, stateTransferredOut: true, stateRetired: true}
+
+// errInvalidLifecycle is returned when the lifecycle of a channel sets states for a commit type that does not change records or sets unknown states
+var errInvalidLifecycle = errors.New(responses.InvalidLifecycle)
+
+// errInvalidTransition is returned when a commit changes a record in a lifecycle state the commit type is not allowed in
+var errInvalidTransition = errors.New(responses.InvalidTransition)
+
+// recordState returns the lifecycle state of the latest revision of a record
+func recordState(current *models.Record) string {
+	if current.EventType != nil && *current.EventType == TRANSFEROUT {
+		return stateTransferredOut
+	} else if current.EventType != nil && *current.EventType == RETIRE {
+		return stateRetired
+	} else if current.ParentRecordID != "" {
+		return stateAttached
+	}
+	return stateActive
+}
+
+// finalState reports whether a lifecycle state ends the history of a record in its channel
+func finalState(state string) bool {
+	return state == stateTransferredOut || state == stateRetired
+}
+
+// channelLifecycle returns the lifecycle states a record may be in for each commit type changing it in a channel
+func channelLifecycle(channel *models.Channel) map[string][]string {
+	result := make(map[string][]string, len(defaultLifecycle))
+	for commitType, states := range defaultLifecycle {
+		result[commitType] = states
+	}
+	if channel != nil {
+		for commitType, states := range channel.Lifecycle {
+			result[commitType] = states
+		}
+	}
+	return result
+}
+
+// checkTransition validates that the lifecycle of a channel allows a commit type on a record in its current state.
+// A parent cannot be retired or transferred out while it has children, as DETACH is not allowed with a parent in a final state
+func checkTransition(channel *models.Channel, commitType string, recordID string, current *models.Record) error {
+	if (commitType == RETIRE || commitType == TRANSFEROUT) && current != nil && len(current.ChildRecordIDs) > 0 {
+		return fmt.Errorf("%w: %v is not allowed on %v while it has children %v", errInvalidTransition, commitType, recordID, strings.Join(current.ChildRecordIDs, ", "))
+	}
+	state := recordState(current)
+	allowed := channelLifecycle(channel)[commitType]
+	for _, allowedState := range allowed {
+		if allowedState == state {
+			return nil
+		}
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("%w: %v is not allowed on %v in state %v, nor in any other state", errInvalidTransition, commitType, recordID, state)
+	}
+	return fmt.Errorf("%w: %v is not allowed on %v in state %v, only in states %v", errInvalidTransition, commitType, recordID, state, strings.Join(allowed, ", "))
+}
+
+// checkParentTransition validates that the parent of an ATTACH or DETACH commit is not in a final lifecycle state
+func checkParentTransition(commitType string, parentID string, parent *models.Record) error {
+	if state := recordState(parent); finalState(state) {
+		return fmt.Errorf("%w: %v is not allowed with parent %v in state %v", errInvalidTransition, commitType, parentID, state)
+	}
+	return nil
+}
+
+// validateLifecycle validates that a lifecycle only sets known lifecycle states for commit types changing records,
+// and none of the final states, which end the history of a record in its channel
+func validateLifecycle(transitions map[string][]string) error {
+	commitTypes := make([]string, 0, len(transitions))
+	for commitType := range transitions {
+		commitTypes = append(commitTypes, commitType)
+	}
+	sort.Strings(commitTypes)
+	for _, commitType := range commitTypes {
+		if _, ok := defaultLifecycle[commitType]; !ok {
+			return fmt.Errorf("%w: %v does not change records", errInvalidLifecycle, commitType)
+		}
+		for _, state := range transitions[commitType] {
+			if !lifecycleStates[state] {
+				return fmt.Errorf("%w: %v is not a lifecycle state", errInvalidLifecycle, state)
+			} else if finalState(state) {
+				return fmt.Errorf("%w: %v is not allowed in final state %v", errInvalidLifecycle, commitType, state)
+			}
+		}
+	}
+	return nil
+}
+
+// retirementDefinition describes the RETIRE commit of a retired record, with the record payload of the commit as retirement metadata
+func retirementDefinition(retired *models.Record) *models.RetirementDefinition {
+	status := responses.Retired
+	revision, previousRevision := retired.Revision, retired.PreviousRevision
+	result := models.RetirementDefinition{
+		Status:           &status,
+		RecordID:         retired.ResourceID,
+		ChannelID:        retired.ChannelID,
+		Revision:         &revision,
+		PreviousRevision: &previousRevision,
+		Timestamp:        retired.Timestamp,
+	}
+	if rec, ok := retired.Payload.(map[string]interface{}); ok {
+		result.Metadata = rec["recordIDPayload"]
+	}
+	return &result
+}
+
+// lifecycleDefinition describes the lifecycle of a channel
+func lifecycleDefinition(found *models.Channel) *models.LifecycleDefinition {
+	return &models.LifecycleDefinition{Transitions: channelLifecycle(found)}
+}
+
+// retrieveChannelLifecycle returns the lifecycle states a record may be in for each commit type changing it in a channel
+func retrieveChannelLifecycle(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.GetChannelLifecycleParams) middleware.Responder {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(lifecycleLogger, span, err, responses.InternalError)
+		return responses.ErrGetChannelLifecycleInternalServerError(err)
+	}
+	found, err := getChannel(ctx, channelMapClient, params.ChannelID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(lifecycleLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrGetChannelLifecycleVerificationFailed(err)
+		}
+		return responses.ErrGetChannelLifecycleInternalServerError(err)
+	} else if found == nil {
+		tracing.LogAndTraceErr(lifecycleLogger, span, nil, responses.ChannelNotFound)
+		return responses.ErrGetChannelLifecycleNotFound()
+	}
+
+	var res = channel.GetChannelLifecycleOK{Payload: lifecycleDefinition(found)}
+	lifecycleLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// updateChannelLifecycle replaces the lifecycle states set for a channel. Commit types the lifecycle does not set keep their default states
+func updateChannelLifecycle(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.UpdateChannelLifecycleParams) middleware.Responder {
+	transitions := params.Body.Transitions
+	if err := validateLifecycle(transitions); err != nil {
+		tracing.LogAndTraceErr(lifecycleLogger, span, err, responses.InvalidLifecycle)
+		return responses.ErrUpdateChannelLifecycleInvalidLifecycle(err)
+	}
+
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(lifecycleLogger, span, err, responses.InternalError)
+		return responses.ErrUpdateChannelLifecycleInternalServerError(err)
+	}
+	var updated *models.Channel
+	err = commitCoordinator.Commit(ctx, channelConfigCommitKey, func(ctx context.Context) error {
+		found, err := getChannel(ctx, channelMapClient, params.ChannelID, tracer)
+		if err != nil {
+			return err
+		} else if found == nil {
+			return errChannelNotFound
+		}
+		channelRevision, err := getCurrentRevision(channelMapClient, ctx, channelConfigMapID, tracer)
+		if err != nil {
+			return err
+		}
+		found.Lifecycle = transitions
+		if len(transitions) == 0 {
+			found.Lifecycle = nil
+		}
+		if err := updateChannel(ctx, trillianConnection.MapWriteClient, int64(channelRevision+1), channelConfigMapID, found, tracer); err != nil {
+			return err
+		}
+		updated = found
+		return nil
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(lifecycleLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrUpdateChannelLifecycleNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrUpdateChannelLifecycleVerificationFailed(err)
+		}
+		return responses.ErrUpdateChannelLifecycleInternalServerError(err)
+	}
+
+	var res = channel.UpdateChannelLifecycleOK{Payload: lifecycleDefinition(updated)}
+	lifecycleLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
@@ -0,0 +1,176 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var updatedChannels []*models.Channel
+var updatedChannelRevision int64
+
+func serveLifecycle(t *testing.T, method string, url string, commitType string, body []byte) *httptest.ResponseRecorder {
+	updatedChannels = nil
+	headers := map[string]string{}
+	if commitType != "" {
+		headers["commit-type"] = commitType
+	}
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = getChannelLifecycleMock
+		getRecord = GetRecordMock
+		updateChannel = updateChannelMock
+		createRecord = CreateRecordMock
+		attachRecord = attachRecordMock
+		detachRecord = detachRecordMock
+		getLeavesByRevision = getLeavesByRevisionMock
+		addLeaves = addLeavesMock
+	}, method, url, headers, body)
+}
+
+func lifecycleBody(transitions map[string][]string) []byte {
+	body, _ := (&models.LifecycleDefinition{Transitions: transitions}).MarshalBinary()
+	return body
+}
+
+//TestGetChannelLifecycle tests querying the default lifecycle of a channel and the lifecycle set for a channel
+func TestGetChannelLifecycle(t *testing.T) {
+	rr := serveLifecycle(t, "GET", "/channels/test-channel/lifecycle", "", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.LifecycleDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, defaultLifecycle, res.Transitions)
+
+	rr = serveLifecycle(t, "GET", "/channels/lifecycle-channel/lifecycle", "", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, []string{"active"}, res.Transitions[UPDATE])
+	assert.Equal(t, []string{"active", "attached"}, res.Transitions[DETACH])
+	assert.Equal(t, []string{"active"}, res.Transitions[ATTACH])
+}
+
+//TestGetChannelLifecycleErrors tests querying the lifecycle of a missing channel and errors reading the channel
+func TestGetChannelLifecycleErrors(t *testing.T) {
+	rr := serveLifecycle(t, "GET", "/channels/random-channel/lifecycle", "", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveLifecycle(t, "GET", "/channels/error-channel/lifecycle", "", nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestUpdateChannelLifecycle tests setting the lifecycle of a channel, keeping the default states of the commit types it does not set
+func TestUpdateChannelLifecycle(t *testing.T) {
+	transitions := map[string][]string{UPDATE: {"active"}}
+	rr := serveLifecycle(t, "PUT", "/channels/test-channel/lifecycle", "", lifecycleBody(transitions))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.LifecycleDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, transitions[UPDATE], res.Transitions[UPDATE])
+	assert.Equal(t, defaultLifecycle[TRANSFEROUT], res.Transitions[TRANSFEROUT])
+	assert.Equal(t, 1, len(updatedChannels))
+	assert.Equal(t, transitions, updatedChannels[0].Lifecycle)
+	assert.Equal(t, int64(1536), updatedChannels[0].MapID)
+	assert.Equal(t, int64(1655), updatedChannelRevision)
+
+	rr = serveLifecycle(t, "PUT", "/channels/lifecycle-channel/lifecycle", "", lifecycleBody(map[string][]string{}))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, defaultLifecycle, res.Transitions)
+	assert.Nil(t, updatedChannels[0].Lifecycle)
+}
+
+//TestUpdateChannelLifecycleInvalid tests setting states for commit types that do not change records, setting unknown states and setting final states
+func TestUpdateChannelLifecycleInvalid(t *testing.T) {
+	rr := serveLifecycle(t, "PUT", "/channels/test-channel/lifecycle", "", lifecycleBody(map[string][]string{CREATE: {"active"}}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle", *res.Status)
+	assert.Contains(t, res.Error, "CREATE does not change records")
+
+	rr = serveLifecycle(t, "PUT", "/channels/test-channel/lifecycle", "", lifecycleBody(map[string][]string{UPDATE: {"deleted"}}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, res.Error, "deleted is not a lifecycle state")
+
+	rr = serveLifecycle(t, "PUT", "/channels/test-channel/lifecycle", "", lifecycleBody(map[string][]string{UPDATE: {"active", "transferred-out"}}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, res.Error, "UPDATE is not allowed in final state transferred-out")
+
+	rr = serveLifecycle(t, "PUT", "/channels/test-channel/lifecycle", "", lifecycleBody(map[string][]string{TRANSFEROUT: {"retired"}}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, res.Error, "TRANSFER-OUT is not allowed in final state retired")
+
+	rr = serveLifecycle(t, "PUT", "/channels/test-channel/lifecycle", "", []byte("{}"))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Empty(t, updatedChannels)
+}
+
+//TestUpdateChannelLifecycleErrors tests setting the lifecycle of a missing channel and errors writing the channel
+func TestUpdateChannelLifecycleErrors(t *testing.T) {
+	body := lifecycleBody(map[string][]string{UPDATE: {"active"}})
+	rr := serveLifecycle(t, "PUT", "/channels/random-channel/lifecycle", "", body)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveLifecycle(t, "PUT", "/channels/error-channel/lifecycle", "", body)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveLifecycle(t, "PUT", "/channels/update-error-channel/lifecycle", "", body)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestCommitLifecycleTransition tests that commits are checked against the lifecycle set for their channel
+func TestCommitLifecycleTransition(t *testing.T) {
+	recordID := "test-record"
+	body, _ := (&models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{}}).MarshalBinary()
+	rr := serveLifecycle(t, "POST", "/channels/lifecycle-channel/records", UPDATE, body)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	recordID = "attached-record"
+	body, _ = (&models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{}}).MarshalBinary()
+	rr = serveLifecycle(t, "POST", "/channels/lifecycle-channel/records", UPDATE, body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Equal(t, "Invalid Lifecycle Transition: UPDATE is not allowed on attached-record in state attached, only in states active", res.Error)
+
+	rr = serveLifecycle(t, "POST", "/channels/test-channel/records", UPDATE, body)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	body, _ = attachmentRecord("other-record", "test-record", "other-record").MarshalBinary()
+	rr = serveLifecycle(t, "POST", "/channels/lifecycle-channel/records", DETACH, body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, *res.Status, "other-record is not attached to test-record")
+}
+
+func getChannelLifecycleMock(ctx context.Context, client *client.MapClient, channelID string, tracer opentracing.Tracer) (*models.Channel, error) {
+	if channelID == "lifecycle-channel" {
+		return &models.Channel{ChannelID: channelID, MapID: 1536, Lifecycle: map[string][]string{UPDATE: {"active"}, DETACH: {"active", "attached"}}}, nil
+	} else if channelID == "update-error-channel" {
+		return &models.Channel{ChannelID: channelID, MapID: 1538}, nil
+	}
+	return GetChannelMock(ctx, client, channelID, tracer)
+}
+
+func updateChannelMock(ctx context.Context, trillMapWriteClient trillian.TrillianMapWriteClient, revision int64, channelMapID int64, channel *models.Channel, tracer opentracing.Tracer) error {
+	if channel.ChannelID == "update-error-channel" {
+		return errors.New("test-error")
+	}
+	updatedChannels = append(updatedChannels, channel)
+	updatedChannelRevision = revision
+	return nil
+}
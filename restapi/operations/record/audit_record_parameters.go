@@ -9,16 +9,29 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewAuditRecordParams creates a new AuditRecordParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewAuditRecordParams() AuditRecordParams {
 
-	return AuditRecordParams{}
+	var (
+		// initialize parameters with default values
+
+		limitDefault = int64(100)
+		orderDefault = string("newest-first")
+	)
+
+	return AuditRecordParams{
+		Limit: &limitDefault,
+
+		Order: &orderDefault,
+	}
 }
 
 // AuditRecordParams contains all the bound params for the audit record operation
@@ -30,11 +43,39 @@ type AuditRecordParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Only return revisions committed before this time
+	  In: query
+	*/
+	ChangedBefore *strfmt.DateTime
+	/*Only return revisions committed at or after this time
+	  In: query
+	*/
+	ChangedSince *strfmt.DateTime
 	/*Channel ID
 	  Required: true
 	  In: path
 	*/
 	ChannelID string
+	/*Cursor returned with the previous page of the history, the first page is returned if it is not set
+	  In: query
+	*/
+	Cursor *string
+	/*Only return revisions committed with this commit type
+	  In: query
+	*/
+	EventType *string
+	/*Maximum number of revisions to return
+	  Maximum: 1000
+	  Minimum: 1
+	  In: query
+	  Default: 100
+	*/
+	Limit *int64
+	/*Order of the revisions in the history
+	  In: query
+	  Default: "newest-first"
+	*/
+	Order *string
 	/*Record ID
 	  Required: true
 	  In: path
@@ -51,11 +92,43 @@ func (o *AuditRecordParams) BindRequest(r *http.Request, route *middleware.Match
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qChangedBefore, qhkChangedBefore, _ := qs.GetOK("changedBefore")
+	if err := o.bindChangedBefore(qChangedBefore, qhkChangedBefore, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qChangedSince, qhkChangedSince, _ := qs.GetOK("changedSince")
+	if err := o.bindChangedSince(qChangedSince, qhkChangedSince, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rChannelID, rhkChannelID, _ := route.Params.GetOK("channelID")
 	if err := o.bindChannelID(rChannelID, rhkChannelID, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qCursor, qhkCursor, _ := qs.GetOK("cursor")
+	if err := o.bindCursor(qCursor, qhkCursor, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qEventType, qhkEventType, _ := qs.GetOK("eventType")
+	if err := o.bindEventType(qEventType, qhkEventType, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qLimit, qhkLimit, _ := qs.GetOK("limit")
+	if err := o.bindLimit(qLimit, qhkLimit, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qOrder, qhkOrder, _ := qs.GetOK("order")
+	if err := o.bindOrder(qOrder, qhkOrder, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rRecordID, rhkRecordID, _ := route.Params.GetOK("recordID")
 	if err := o.bindRecordID(rRecordID, rhkRecordID, route.Formats); err != nil {
 		res = append(res, err)
@@ -66,6 +139,80 @@ func (o *AuditRecordParams) BindRequest(r *http.Request, route *middleware.Match
 	return nil
 }
 
+// bindChangedBefore binds and validates parameter ChangedBefore from query.
+func (o *AuditRecordParams) bindChangedBefore(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("changedBefore", "query", "strfmt.DateTime", raw)
+	}
+	o.ChangedBefore = (value.(*strfmt.DateTime))
+
+	if err := o.validateChangedBefore(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateChangedBefore carries on validations for parameter ChangedBefore
+func (o *AuditRecordParams) validateChangedBefore(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("changedBefore", "query", "date-time", o.ChangedBefore.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
+// bindChangedSince binds and validates parameter ChangedSince from query.
+func (o *AuditRecordParams) bindChangedSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("changedSince", "query", "strfmt.DateTime", raw)
+	}
+	o.ChangedSince = (value.(*strfmt.DateTime))
+
+	if err := o.validateChangedSince(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateChangedSince carries on validations for parameter ChangedSince
+func (o *AuditRecordParams) validateChangedSince(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("changedSince", "query", "date-time", o.ChangedSince.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
 // bindChannelID binds and validates parameter ChannelID from path.
 func (o *AuditRecordParams) bindChannelID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -80,6 +227,117 @@ func (o *AuditRecordParams) bindChannelID(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindCursor binds and validates parameter Cursor from query.
+func (o *AuditRecordParams) bindCursor(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Cursor = &raw
+
+	return nil
+}
+
+// bindEventType binds and validates parameter EventType from query.
+func (o *AuditRecordParams) bindEventType(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.EventType = &raw
+
+	return nil
+}
+
+// bindLimit binds and validates parameter Limit from query.
+func (o *AuditRecordParams) bindLimit(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewAuditRecordParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("limit", "query", "int64", raw)
+	}
+	o.Limit = &value
+
+	if err := o.validateLimit(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateLimit carries on validations for parameter Limit
+func (o *AuditRecordParams) validateLimit(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("limit", "query", *o.Limit, 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("limit", "query", *o.Limit, 1000, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindOrder binds and validates parameter Order from query.
+func (o *AuditRecordParams) bindOrder(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewAuditRecordParams()
+		return nil
+	}
+	o.Order = &raw
+
+	if err := o.validateOrder(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateOrder carries on validations for parameter Order
+func (o *AuditRecordParams) validateOrder(formats strfmt.Registry) error {
+
+	if err := validate.EnumCase("order", "query", *o.Order, []interface{}{"newest-first", "oldest-first"}, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindRecordID binds and validates parameter RecordID from path.
 func (o *AuditRecordParams) bindRecordID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
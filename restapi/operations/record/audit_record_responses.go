@@ -57,6 +57,50 @@ func (o *AuditRecordOK) WriteResponse(rw http.ResponseWriter, producer runtime.P
 	}
 }
 
+// AuditRecordBadRequestCode is the HTTP code returned for type AuditRecordBadRequest
+const AuditRecordBadRequestCode int = 400
+
+/*AuditRecordBadRequest Cursor is invalid
+
+swagger:response auditRecordBadRequest
+*/
+type AuditRecordBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorResponseDefinition `json:"body,omitempty"`
+}
+
+// NewAuditRecordBadRequest creates AuditRecordBadRequest with default headers values
+func NewAuditRecordBadRequest() *AuditRecordBadRequest {
+
+	return &AuditRecordBadRequest{}
+}
+
+// WithPayload adds the payload to the audit record bad request response
+func (o *AuditRecordBadRequest) WithPayload(payload *models.ErrorResponseDefinition) *AuditRecordBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the audit record bad request response
+func (o *AuditRecordBadRequest) SetPayload(payload *models.ErrorResponseDefinition) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *AuditRecordBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // AuditRecordNotFoundCode is the HTTP code returned for type AuditRecordNotFound
 const AuditRecordNotFoundCode int = 404
 
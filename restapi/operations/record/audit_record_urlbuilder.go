@@ -10,6 +10,9 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // AuditRecordURL generates an URL for the audit record operation
@@ -17,6 +20,13 @@ type AuditRecordURL struct {
 	ChannelID string
 	RecordID  string
 
+	ChangedBefore *strfmt.DateTime
+	ChangedSince  *strfmt.DateTime
+	Cursor        *string
+	EventType     *string
+	Limit         *int64
+	Order         *string
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -63,6 +73,58 @@ func (o *AuditRecordURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var changedBeforeQ string
+	if o.ChangedBefore != nil {
+		changedBeforeQ = o.ChangedBefore.String()
+	}
+	if changedBeforeQ != "" {
+		qs.Set("changedBefore", changedBeforeQ)
+	}
+
+	var changedSinceQ string
+	if o.ChangedSince != nil {
+		changedSinceQ = o.ChangedSince.String()
+	}
+	if changedSinceQ != "" {
+		qs.Set("changedSince", changedSinceQ)
+	}
+
+	var cursorQ string
+	if o.Cursor != nil {
+		cursorQ = *o.Cursor
+	}
+	if cursorQ != "" {
+		qs.Set("cursor", cursorQ)
+	}
+
+	var eventTypeQ string
+	if o.EventType != nil {
+		eventTypeQ = *o.EventType
+	}
+	if eventTypeQ != "" {
+		qs.Set("eventType", eventTypeQ)
+	}
+
+	var limitQ string
+	if o.Limit != nil {
+		limitQ = swag.FormatInt64(*o.Limit)
+	}
+	if limitQ != "" {
+		qs.Set("limit", limitQ)
+	}
+
+	var orderQ string
+	if o.Order != nil {
+		orderQ = *o.Order
+	}
+	if orderQ != "" {
+		qs.Set("order", orderQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -41,6 +41,14 @@ type CommitRecordParams struct {
 	  In: body
 	*/
 	Body *models.RecordDefinition
+	/*Key identifying the commit across retries. A commit retried with the same key and body is not committed again and returns the result of the first one
+	  In: header
+	*/
+	IdempotencyKey *string
+	/*ETag of the latest revision of the record the commit is made on, as returned when retrieving the record. The commit fails if the record is at another revision
+	  In: header
+	*/
+	IfMatch *string
 	/*Channel ID
 	  Required: true
 	  In: path
@@ -90,6 +98,14 @@ func (o *CommitRecordParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, errors.Required("body", "body", ""))
 	}
 
+	if err := o.bindIdempotencyKey(r.Header[http.CanonicalHeaderKey("Idempotency-Key")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rChannelID, rhkChannelID, _ := route.Params.GetOK("channelID")
 	if err := o.bindChannelID(rChannelID, rhkChannelID, route.Formats); err != nil {
 		res = append(res, err)
@@ -104,6 +120,40 @@ func (o *CommitRecordParams) BindRequest(r *http.Request, route *middleware.Matc
 	return nil
 }
 
+// bindIdempotencyKey binds and validates parameter IdempotencyKey from header.
+func (o *CommitRecordParams) bindIdempotencyKey(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IdempotencyKey = &raw
+
+	return nil
+}
+
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *CommitRecordParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
+
 // bindChannelID binds and validates parameter ChannelID from path.
 func (o *CommitRecordParams) bindChannelID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -57,6 +57,50 @@ func (o *CommitRecordOK) WriteResponse(rw http.ResponseWriter, producer runtime.
 	}
 }
 
+// CommitRecordBadRequestCode is the HTTP code returned for type CommitRecordBadRequest
+const CommitRecordBadRequestCode int = 400
+
+/*CommitRecordBadRequest Attachment of the commit is invalid, or the record payload violates the schema of the channel
+
+swagger:response commitRecordBadRequest
+*/
+type CommitRecordBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorResponseDefinition `json:"body,omitempty"`
+}
+
+// NewCommitRecordBadRequest creates CommitRecordBadRequest with default headers values
+func NewCommitRecordBadRequest() *CommitRecordBadRequest {
+
+	return &CommitRecordBadRequest{}
+}
+
+// WithPayload adds the payload to the commit record bad request response
+func (o *CommitRecordBadRequest) WithPayload(payload *models.ErrorResponseDefinition) *CommitRecordBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the commit record bad request response
+func (o *CommitRecordBadRequest) SetPayload(payload *models.ErrorResponseDefinition) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CommitRecordBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // CommitRecordNotFoundCode is the HTTP code returned for type CommitRecordNotFound
 const CommitRecordNotFoundCode int = 404
 
@@ -104,7 +148,7 @@ func (o *CommitRecordNotFound) WriteResponse(rw http.ResponseWriter, producer ru
 // CommitRecordConflictCode is the HTTP code returned for type CommitRecordConflict
 const CommitRecordConflictCode int = 409
 
-/*CommitRecordConflict Record already exists
+/*CommitRecordConflict Record already exists, or the commit is not allowed in the lifecycle state of the record
 
 swagger:response commitRecordConflict
 */
@@ -145,6 +189,94 @@ func (o *CommitRecordConflict) WriteResponse(rw http.ResponseWriter, producer ru
 	}
 }
 
+// CommitRecordPreconditionFailedCode is the HTTP code returned for type CommitRecordPreconditionFailed
+const CommitRecordPreconditionFailedCode int = 412
+
+/*CommitRecordPreconditionFailed Record is not at the revision the commit expects
+
+swagger:response commitRecordPreconditionFailed
+*/
+type CommitRecordPreconditionFailed struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorResponseDefinition `json:"body,omitempty"`
+}
+
+// NewCommitRecordPreconditionFailed creates CommitRecordPreconditionFailed with default headers values
+func NewCommitRecordPreconditionFailed() *CommitRecordPreconditionFailed {
+
+	return &CommitRecordPreconditionFailed{}
+}
+
+// WithPayload adds the payload to the commit record precondition failed response
+func (o *CommitRecordPreconditionFailed) WithPayload(payload *models.ErrorResponseDefinition) *CommitRecordPreconditionFailed {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the commit record precondition failed response
+func (o *CommitRecordPreconditionFailed) SetPayload(payload *models.ErrorResponseDefinition) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CommitRecordPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(412)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// CommitRecordUnprocessableEntityCode is the HTTP code returned for type CommitRecordUnprocessableEntity
+const CommitRecordUnprocessableEntityCode int = 422
+
+/*CommitRecordUnprocessableEntity Idempotency key was used by a commit with another commit type or body
+
+swagger:response commitRecordUnprocessableEntity
+*/
+type CommitRecordUnprocessableEntity struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorResponseDefinition `json:"body,omitempty"`
+}
+
+// NewCommitRecordUnprocessableEntity creates CommitRecordUnprocessableEntity with default headers values
+func NewCommitRecordUnprocessableEntity() *CommitRecordUnprocessableEntity {
+
+	return &CommitRecordUnprocessableEntity{}
+}
+
+// WithPayload adds the payload to the commit record unprocessable entity response
+func (o *CommitRecordUnprocessableEntity) WithPayload(payload *models.ErrorResponseDefinition) *CommitRecordUnprocessableEntity {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the commit record unprocessable entity response
+func (o *CommitRecordUnprocessableEntity) SetPayload(payload *models.ErrorResponseDefinition) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *CommitRecordUnprocessableEntity) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(422)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // CommitRecordInternalServerErrorCode is the HTTP code returned for type CommitRecordInternalServerError
 const CommitRecordInternalServerErrorCode int = 500
 
@@ -9,8 +9,11 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewRetrieveRecordParams creates a new RetrieveRecordParams object
@@ -30,6 +33,10 @@ type RetrieveRecordParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Retrieve the last revision of the record committed at or before this time
+	  In: query
+	*/
+	AsOf *strfmt.DateTime
 	/*Channel ID
 	  Required: true
 	  In: path
@@ -40,6 +47,11 @@ type RetrieveRecordParams struct {
 	  In: path
 	*/
 	RecordID string
+	/*Map revision to retrieve the record at, defaults to the latest revision
+	  Minimum: 1
+	  In: query
+	*/
+	Revision *int64
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -51,6 +63,13 @@ func (o *RetrieveRecordParams) BindRequest(r *http.Request, route *middleware.Ma
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qAsOf, qhkAsOf, _ := qs.GetOK("asOf")
+	if err := o.bindAsOf(qAsOf, qhkAsOf, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rChannelID, rhkChannelID, _ := route.Params.GetOK("channelID")
 	if err := o.bindChannelID(rChannelID, rhkChannelID, route.Formats); err != nil {
 		res = append(res, err)
@@ -60,12 +79,54 @@ func (o *RetrieveRecordParams) BindRequest(r *http.Request, route *middleware.Ma
 	if err := o.bindRecordID(rRecordID, rhkRecordID, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qRevision, qhkRevision, _ := qs.GetOK("revision")
+	if err := o.bindRevision(qRevision, qhkRevision, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+// bindAsOf binds and validates parameter AsOf from query.
+func (o *RetrieveRecordParams) bindAsOf(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("asOf", "query", "strfmt.DateTime", raw)
+	}
+	o.AsOf = (value.(*strfmt.DateTime))
+
+	if err := o.validateAsOf(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateAsOf carries on validations for parameter AsOf
+func (o *RetrieveRecordParams) validateAsOf(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("asOf", "query", "date-time", o.AsOf.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
 // bindChannelID binds and validates parameter ChannelID from path.
 func (o *RetrieveRecordParams) bindChannelID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -93,3 +154,40 @@ func (o *RetrieveRecordParams) bindRecordID(rawData []string, hasKey bool, forma
 
 	return nil
 }
+
+// bindRevision binds and validates parameter Revision from query.
+func (o *RetrieveRecordParams) bindRevision(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("revision", "query", "int64", raw)
+	}
+	o.Revision = &value
+
+	if err := o.validateRevision(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateRevision carries on validations for parameter Revision
+func (o *RetrieveRecordParams) validateRevision(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("revision", "query", *o.Revision, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -21,6 +21,10 @@ const RetrieveRecordOKCode int = 200
 swagger:response retrieveRecordOK
 */
 type RetrieveRecordOK struct {
+	/*Revision of the retrieved record, to commit on it with If-Match
+
+	 */
+	ETag string `json:"ETag"`
 
 	/*
 	  In: Body
@@ -34,6 +38,17 @@ func NewRetrieveRecordOK() *RetrieveRecordOK {
 	return &RetrieveRecordOK{}
 }
 
+// WithETag adds the eTag to the retrieve record o k response
+func (o *RetrieveRecordOK) WithETag(eTag string) *RetrieveRecordOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the retrieve record o k response
+func (o *RetrieveRecordOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the retrieve record o k response
 func (o *RetrieveRecordOK) WithPayload(payload models.ExampleRecordPayloadDefinition) *RetrieveRecordOK {
 	o.Payload = payload
@@ -48,6 +63,13 @@ func (o *RetrieveRecordOK) SetPayload(payload models.ExampleRecordPayloadDefinit
 // WriteResponse to the client
 func (o *RetrieveRecordOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if err := producer.Produce(rw, payload); err != nil {
@@ -55,6 +77,50 @@ func (o *RetrieveRecordOK) WriteResponse(rw http.ResponseWriter, producer runtim
 	}
 }
 
+// RetrieveRecordBadRequestCode is the HTTP code returned for type RetrieveRecordBadRequest
+const RetrieveRecordBadRequestCode int = 400
+
+/*RetrieveRecordBadRequest Both revision and asOf are set
+
+swagger:response retrieveRecordBadRequest
+*/
+type RetrieveRecordBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ErrorResponseDefinition `json:"body,omitempty"`
+}
+
+// NewRetrieveRecordBadRequest creates RetrieveRecordBadRequest with default headers values
+func NewRetrieveRecordBadRequest() *RetrieveRecordBadRequest {
+
+	return &RetrieveRecordBadRequest{}
+}
+
+// WithPayload adds the payload to the retrieve record bad request response
+func (o *RetrieveRecordBadRequest) WithPayload(payload *models.ErrorResponseDefinition) *RetrieveRecordBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the retrieve record bad request response
+func (o *RetrieveRecordBadRequest) SetPayload(payload *models.ErrorResponseDefinition) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RetrieveRecordBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // RetrieveRecordNotFoundCode is the HTTP code returned for type RetrieveRecordNotFound
 const RetrieveRecordNotFoundCode int = 404
 
@@ -99,6 +165,50 @@ func (o *RetrieveRecordNotFound) WriteResponse(rw http.ResponseWriter, producer
 	}
 }
 
+// RetrieveRecordGoneCode is the HTTP code returned for type RetrieveRecordGone
+const RetrieveRecordGoneCode int = 410
+
+/*RetrieveRecordGone Record has been retired, the retirement is in the body
+
+swagger:response retrieveRecordGone
+*/
+type RetrieveRecordGone struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.RetirementDefinition `json:"body,omitempty"`
+}
+
+// NewRetrieveRecordGone creates RetrieveRecordGone with default headers values
+func NewRetrieveRecordGone() *RetrieveRecordGone {
+
+	return &RetrieveRecordGone{}
+}
+
+// WithPayload adds the payload to the retrieve record gone response
+func (o *RetrieveRecordGone) WithPayload(payload *models.RetirementDefinition) *RetrieveRecordGone {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the retrieve record gone response
+func (o *RetrieveRecordGone) SetPayload(payload *models.RetirementDefinition) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *RetrieveRecordGone) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(410)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 // RetrieveRecordInternalServerErrorCode is the HTTP code returned for type RetrieveRecordInternalServerError
 const RetrieveRecordInternalServerErrorCode int = 500
 
@@ -10,6 +10,9 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // RetrieveRecordURL generates an URL for the retrieve record operation
@@ -17,6 +20,9 @@ type RetrieveRecordURL struct {
 	ChannelID string
 	RecordID  string
 
+	AsOf     *strfmt.DateTime
+	Revision *int64
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -63,6 +69,26 @@ func (o *RetrieveRecordURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var asOfQ string
+	if o.AsOf != nil {
+		asOfQ = o.AsOf.String()
+	}
+	if asOfQ != "" {
+		qs.Set("asOf", asOfQ)
+	}
+
+	var revisionQ string
+	if o.Revision != nil {
+		revisionQ = swag.FormatInt64(*o.Revision)
+	}
+	if revisionQ != "" {
+		qs.Set("revision", revisionQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
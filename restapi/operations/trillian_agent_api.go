@@ -19,6 +19,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"trillian-agent/restapi/operations/channel"
 	"trillian-agent/restapi/operations/record"
 )
 
@@ -50,9 +51,57 @@ func NewTrillianAgentAPI(spec *loads.Document) *TrillianAgentAPI {
 		RecordCommitRecordHandler: record.CommitRecordHandlerFunc(func(params record.CommitRecordParams) middleware.Responder {
 			return middleware.NotImplemented("operation record.CommitRecord has not yet been implemented")
 		}),
+		RecordCommitTransactionHandler: record.CommitTransactionHandlerFunc(func(params record.CommitTransactionParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.CommitTransaction has not yet been implemented")
+		}),
+		ChannelCreateChannelHandler: channel.CreateChannelHandlerFunc(func(params channel.CreateChannelParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.CreateChannel has not yet been implemented")
+		}),
+		ChannelDeleteChannelHandler: channel.DeleteChannelHandlerFunc(func(params channel.DeleteChannelParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.DeleteChannel has not yet been implemented")
+		}),
+		ChannelGetChannelHandler: channel.GetChannelHandlerFunc(func(params channel.GetChannelParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.GetChannel has not yet been implemented")
+		}),
+		ChannelGetChannelLifecycleHandler: channel.GetChannelLifecycleHandlerFunc(func(params channel.GetChannelLifecycleParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.GetChannelLifecycle has not yet been implemented")
+		}),
+		ChannelGetChannelSchemaHandler: channel.GetChannelSchemaHandlerFunc(func(params channel.GetChannelSchemaParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.GetChannelSchema has not yet been implemented")
+		}),
+		ChannelListChannelsHandler: channel.ListChannelsHandlerFunc(func(params channel.ListChannelsParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.ListChannels has not yet been implemented")
+		}),
+		RecordListRecordsHandler: record.ListRecordsHandlerFunc(func(params record.ListRecordsParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.ListRecords has not yet been implemented")
+		}),
+		RecordPatchRecordHandler: record.PatchRecordHandlerFunc(func(params record.PatchRecordParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.PatchRecord has not yet been implemented")
+		}),
 		RecordRetrieveRecordHandler: record.RetrieveRecordHandlerFunc(func(params record.RetrieveRecordParams) middleware.Responder {
 			return middleware.NotImplemented("operation record.RetrieveRecord has not yet been implemented")
 		}),
+		RecordRetrieveRecordDiffHandler: record.RetrieveRecordDiffHandlerFunc(func(params record.RetrieveRecordDiffParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.RetrieveRecordDiff has not yet been implemented")
+		}),
+		RecordRetrieveRecordProofHandler: record.RetrieveRecordProofHandlerFunc(func(params record.RetrieveRecordProofParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.RetrieveRecordProof has not yet been implemented")
+		}),
+		RecordRetrieveRecordTreeHandler: record.RetrieveRecordTreeHandlerFunc(func(params record.RetrieveRecordTreeParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.RetrieveRecordTree has not yet been implemented")
+		}),
+		RecordRetrieveWhereUsedHandler: record.RetrieveWhereUsedHandlerFunc(func(params record.RetrieveWhereUsedParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.RetrieveWhereUsed has not yet been implemented")
+		}),
+		RecordTransferRecordHandler: record.TransferRecordHandlerFunc(func(params record.TransferRecordParams) middleware.Responder {
+			return middleware.NotImplemented("operation record.TransferRecord has not yet been implemented")
+		}),
+		ChannelUpdateChannelLifecycleHandler: channel.UpdateChannelLifecycleHandlerFunc(func(params channel.UpdateChannelLifecycleParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.UpdateChannelLifecycle has not yet been implemented")
+		}),
+		ChannelUpdateChannelSchemaHandler: channel.UpdateChannelSchemaHandlerFunc(func(params channel.UpdateChannelSchemaParams) middleware.Responder {
+			return middleware.NotImplemented("operation channel.UpdateChannelSchema has not yet been implemented")
+		}),
 	}
 }
 
@@ -83,6 +132,8 @@ type TrillianAgentAPI struct {
 
 	// JSONConsumer registers a consumer for the following mime types:
 	//   - application/json
+	//   - application/json-patch+json
+	//   - application/merge-patch+json
 	JSONConsumer runtime.Consumer
 
 	// JSONProducer registers a producer for the following mime types:
@@ -93,8 +144,40 @@ type TrillianAgentAPI struct {
 	RecordAuditRecordHandler record.AuditRecordHandler
 	// RecordCommitRecordHandler sets the operation handler for the commit record operation
 	RecordCommitRecordHandler record.CommitRecordHandler
+	// RecordCommitTransactionHandler sets the operation handler for the commit transaction operation
+	RecordCommitTransactionHandler record.CommitTransactionHandler
+	// ChannelCreateChannelHandler sets the operation handler for the create channel operation
+	ChannelCreateChannelHandler channel.CreateChannelHandler
+	// ChannelDeleteChannelHandler sets the operation handler for the delete channel operation
+	ChannelDeleteChannelHandler channel.DeleteChannelHandler
+	// ChannelGetChannelHandler sets the operation handler for the get channel operation
+	ChannelGetChannelHandler channel.GetChannelHandler
+	// ChannelGetChannelLifecycleHandler sets the operation handler for the get channel lifecycle operation
+	ChannelGetChannelLifecycleHandler channel.GetChannelLifecycleHandler
+	// ChannelGetChannelSchemaHandler sets the operation handler for the get channel schema operation
+	ChannelGetChannelSchemaHandler channel.GetChannelSchemaHandler
+	// ChannelListChannelsHandler sets the operation handler for the list channels operation
+	ChannelListChannelsHandler channel.ListChannelsHandler
+	// RecordListRecordsHandler sets the operation handler for the list records operation
+	RecordListRecordsHandler record.ListRecordsHandler
+	// RecordPatchRecordHandler sets the operation handler for the patch record operation
+	RecordPatchRecordHandler record.PatchRecordHandler
 	// RecordRetrieveRecordHandler sets the operation handler for the retrieve record operation
 	RecordRetrieveRecordHandler record.RetrieveRecordHandler
+	// RecordRetrieveRecordDiffHandler sets the operation handler for the retrieve record diff operation
+	RecordRetrieveRecordDiffHandler record.RetrieveRecordDiffHandler
+	// RecordRetrieveRecordProofHandler sets the operation handler for the retrieve record proof operation
+	RecordRetrieveRecordProofHandler record.RetrieveRecordProofHandler
+	// RecordRetrieveRecordTreeHandler sets the operation handler for the retrieve record tree operation
+	RecordRetrieveRecordTreeHandler record.RetrieveRecordTreeHandler
+	// RecordRetrieveWhereUsedHandler sets the operation handler for the retrieve where used operation
+	RecordRetrieveWhereUsedHandler record.RetrieveWhereUsedHandler
+	// RecordTransferRecordHandler sets the operation handler for the transfer record operation
+	RecordTransferRecordHandler record.TransferRecordHandler
+	// ChannelUpdateChannelLifecycleHandler sets the operation handler for the update channel lifecycle operation
+	ChannelUpdateChannelLifecycleHandler channel.UpdateChannelLifecycleHandler
+	// ChannelUpdateChannelSchemaHandler sets the operation handler for the update channel schema operation
+	ChannelUpdateChannelSchemaHandler channel.UpdateChannelSchemaHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -178,9 +261,57 @@ func (o *TrillianAgentAPI) Validate() error {
 	if o.RecordCommitRecordHandler == nil {
 		unregistered = append(unregistered, "record.CommitRecordHandler")
 	}
+	if o.RecordCommitTransactionHandler == nil {
+		unregistered = append(unregistered, "record.CommitTransactionHandler")
+	}
+	if o.ChannelCreateChannelHandler == nil {
+		unregistered = append(unregistered, "channel.CreateChannelHandler")
+	}
+	if o.ChannelDeleteChannelHandler == nil {
+		unregistered = append(unregistered, "channel.DeleteChannelHandler")
+	}
+	if o.ChannelGetChannelHandler == nil {
+		unregistered = append(unregistered, "channel.GetChannelHandler")
+	}
+	if o.ChannelGetChannelLifecycleHandler == nil {
+		unregistered = append(unregistered, "channel.GetChannelLifecycleHandler")
+	}
+	if o.ChannelGetChannelSchemaHandler == nil {
+		unregistered = append(unregistered, "channel.GetChannelSchemaHandler")
+	}
+	if o.ChannelListChannelsHandler == nil {
+		unregistered = append(unregistered, "channel.ListChannelsHandler")
+	}
+	if o.RecordListRecordsHandler == nil {
+		unregistered = append(unregistered, "record.ListRecordsHandler")
+	}
+	if o.RecordPatchRecordHandler == nil {
+		unregistered = append(unregistered, "record.PatchRecordHandler")
+	}
 	if o.RecordRetrieveRecordHandler == nil {
 		unregistered = append(unregistered, "record.RetrieveRecordHandler")
 	}
+	if o.RecordRetrieveRecordDiffHandler == nil {
+		unregistered = append(unregistered, "record.RetrieveRecordDiffHandler")
+	}
+	if o.RecordRetrieveRecordProofHandler == nil {
+		unregistered = append(unregistered, "record.RetrieveRecordProofHandler")
+	}
+	if o.RecordRetrieveRecordTreeHandler == nil {
+		unregistered = append(unregistered, "record.RetrieveRecordTreeHandler")
+	}
+	if o.RecordRetrieveWhereUsedHandler == nil {
+		unregistered = append(unregistered, "record.RetrieveWhereUsedHandler")
+	}
+	if o.RecordTransferRecordHandler == nil {
+		unregistered = append(unregistered, "record.TransferRecordHandler")
+	}
+	if o.ChannelUpdateChannelLifecycleHandler == nil {
+		unregistered = append(unregistered, "channel.UpdateChannelLifecycleHandler")
+	}
+	if o.ChannelUpdateChannelSchemaHandler == nil {
+		unregistered = append(unregistered, "channel.UpdateChannelSchemaHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -212,6 +343,10 @@ func (o *TrillianAgentAPI) ConsumersFor(mediaTypes []string) map[string]runtime.
 		switch mt {
 		case "application/json":
 			result["application/json"] = o.JSONConsumer
+		case "application/json-patch+json":
+			result["application/json-patch+json"] = o.JSONConsumer
+		case "application/merge-patch+json":
+			result["application/merge-patch+json"] = o.JSONConsumer
 		}
 
 		if c, ok := o.customConsumers[mt]; ok {
@@ -277,10 +412,74 @@ func (o *TrillianAgentAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/channels/{channelID}/records"] = record.NewCommitRecord(o.context, o.RecordCommitRecordHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/channels/{channelID}/transactions"] = record.NewCommitTransaction(o.context, o.RecordCommitTransactionHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/channels"] = channel.NewCreateChannel(o.context, o.ChannelCreateChannelHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/channels/{channelID}"] = channel.NewDeleteChannel(o.context, o.ChannelDeleteChannelHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}"] = channel.NewGetChannel(o.context, o.ChannelGetChannelHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/lifecycle"] = channel.NewGetChannelLifecycle(o.context, o.ChannelGetChannelLifecycleHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/schema"] = channel.NewGetChannelSchema(o.context, o.ChannelGetChannelSchemaHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels"] = channel.NewListChannels(o.context, o.ChannelListChannelsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/records"] = record.NewListRecords(o.context, o.RecordListRecordsHandler)
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
+	o.handlers["PATCH"]["/channels/{channelID}/records/{recordID}"] = record.NewPatchRecord(o.context, o.RecordPatchRecordHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/channels/{channelID}/records/{recordID}"] = record.NewRetrieveRecord(o.context, o.RecordRetrieveRecordHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/records/{recordID}/diff"] = record.NewRetrieveRecordDiff(o.context, o.RecordRetrieveRecordDiffHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/records/{recordID}/proof"] = record.NewRetrieveRecordProof(o.context, o.RecordRetrieveRecordProofHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/records/{recordID}/tree"] = record.NewRetrieveRecordTree(o.context, o.RecordRetrieveRecordTreeHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/channels/{channelID}/records/{recordID}/where-used"] = record.NewRetrieveWhereUsed(o.context, o.RecordRetrieveWhereUsedHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/channels/{channelID}/records/{recordID}/transfer"] = record.NewTransferRecord(o.context, o.RecordTransferRecordHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/channels/{channelID}/lifecycle"] = channel.NewUpdateChannelLifecycle(o.context, o.ChannelUpdateChannelLifecycleHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/channels/{channelID}/schema"] = channel.NewUpdateChannelSchema(o.context, o.ChannelUpdateChannelSchemaHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/patch"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+)
+
+var patchLogger = logger.GetLogger("Restapi:Patch")
+
+// jsonPatchMediaType is the media type of RFC 6902 JSON Patch bodies. Any other body is an RFC 7386 merge patch
+const jsonPatchMediaType = "application/json-patch+json"
+
+// commitPatch commits UPDATE on a record with the record payload of its latest revision patched by the body of the request.
+// The patch is applied when the commit is staged, so that it is applied to the revision the commit follows, and the patched record payload is validated against the schema of the channel
+func commitPatch(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.PatchRecordParams) middleware.Responder {
+	apply, err := recordPatch(params)
+	if err != nil {
+		tracing.LogAndTraceErr(patchLogger, span, err, responses.InvalidPatch)
+		return responses.ErrPatchRecordInvalidPatch(err)
+	}
+
+	channel, mapClient, err := openCommitChannel(ctx, params.ChannelID, false, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(patchLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrPatchRecordChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrPatchRecordVerificationFailed(err)
+		}
+		return responses.ErrPatchRecordInternalServerError(err)
+	}
+
+	var res middleware.Responder
+	var revision int64
+	err = commitCoordinator.Stage(ctx, channelCommitKey(params.ChannelID), channelMapWriter(mapClient, channel.MapID, tracer), [][]byte{dbom.RecordIndex(params.RecordID)}, func(ctx context.Context, batch *dbom.Batch) error {
+		res = nil
+		prevRevision, err := checkCommit(ctx, batch, mapClient, channel, UPDATE, params.RecordID, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(patchLogger, span, err, responses.InternalError)
+			res = patchCommitError(err)
+			return nil
+		} else if err := checkPrecondition(params.IfMatch, 0, params.RecordID, prevRevision); err != nil {
+			tracing.LogAndTraceErr(patchLogger, span, err, responses.PreconditionFailed)
+			res = responses.ErrPatchRecordPreconditionFailed(err)
+			return nil
+		}
+		revision = batch.Revision()
+		err = patchRecord(ctx, batch, params.ChannelID, UPDATE, params.RecordID, channel.SchemaVersion, func(payload interface{}) (interface{}, error) {
+			patched, err := apply(payload)
+			if err != nil {
+				return nil, err
+			}
+			_, err = checkPayload(channel, params.RecordID, patched)
+			return patched, err
+		}, tracer)
+		if errors.Is(err, patch.ErrPatchFailed) {
+			tracing.LogAndTraceErr(patchLogger, span, err, responses.PatchFailed)
+			res = responses.ErrPatchRecordPatchFailed(err)
+			return nil
+		} else if errors.Is(err, errSchemaViolation) {
+			tracing.LogAndTraceErr(patchLogger, span, err, responses.SchemaViolation)
+			res = responses.ErrPatchRecordSchemaViolation(err, schemaViolations(err))
+			return nil
+		}
+		return err
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(patchLogger, span, err, responses.InternalError)
+		return responses.ErrPatchRecordInternalServerError(err)
+	} else if res != nil {
+		return res
+	}
+
+	var success = true
+	var resDef = models.CreateRecordResponseDefinition{Success: &success, Revision: revision}
+	var ok = record.PatchRecordOK{Payload: &resDef}
+	patchLogger.Debug().Msgf("%v", ok.Payload)
+	return &ok
+}
+
+// recordPatch parses the body of a patch request by its content type and returns the function applying it to a record payload.
+// A patched record payload must still be an object
+func recordPatch(params record.PatchRecordParams) (func(payload interface{}) (interface{}, error), error) {
+	mediaType, _, _ := mime.ParseMediaType(params.HTTPRequest.Header.Get("Content-Type"))
+	var apply func(payload interface{}) (interface{}, error)
+	if mediaType == jsonPatchMediaType {
+		ops, err := patch.ParsePatch(params.Body)
+		if err != nil {
+			return nil, err
+		}
+		apply = func(payload interface{}) (interface{}, error) {
+			return patch.Apply(payload, ops)
+		}
+	} else {
+		if _, ok := params.Body.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%w: a merge patch of a record payload must be an object", patch.ErrInvalidPatch)
+		}
+		apply = func(payload interface{}) (interface{}, error) {
+			return patch.MergePatch(payload, params.Body)
+		}
+	}
+	return func(payload interface{}) (interface{}, error) {
+		patched, err := apply(payload)
+		if err != nil {
+			return nil, err
+		} else if _, ok := patched.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%w: the patched record payload is not an object", patch.ErrPatchFailed)
+		}
+		return patched, nil
+	}, nil
+}
+
+// patchCommitError maps an error validating UPDATE on the patched record to its response
+func patchCommitError(err error) middleware.Responder {
+	if errors.Is(err, errInvalidTransition) {
+		return responses.ErrPatchRecordInvalidTransition(err)
+	} else if errors.Is(err, errRecordNotFound) {
+		return responses.ErrPatchRecordResourceNotFound()
+	} else if client.IsVerificationError(err) {
+		return responses.ErrPatchRecordVerificationFailed(err)
+	}
+	return responses.ErrPatchRecordInternalServerError(err)
+}
@@ -0,0 +1,108 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var patchedPayload interface{}
+
+func servePatch(t *testing.T, url string, contentType string, body string) *httptest.ResponseRecorder {
+	patchedPayload = nil
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = getChannelLifecycleMock
+		getRecord = GetRecordMock
+		patchRecord = patchRecordMock
+		getLeavesByRevision = getLeavesByRevisionMock
+		addLeaves = addLeavesMock
+	}, "PATCH", url, map[string]string{"Content-Type": contentType}, []byte(body))
+}
+
+//TestPatchRecordMergePatch tests updating a record with an RFC 7386 merge patch of its record payload
+func TestPatchRecordMergePatch(t *testing.T) {
+	rr := servePatch(t, "/channels/test-channel/records/test-record", "application/merge-patch+json", `{"size":"L","color":null,"weight":12}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, map[string]interface{}{"size": "L", "tags": []interface{}{"a"}, "weight": float64(12)}, patchedPayload)
+
+	rr = servePatch(t, "/channels/test-channel/records/test-record", "application/merge-patch+json", `["size"]`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Patch", *res.Status)
+	assert.Nil(t, patchedPayload)
+}
+
+//TestPatchRecordJSONPatch tests updating a record with an RFC 6902 JSON Patch of its record payload
+func TestPatchRecordJSONPatch(t *testing.T) {
+	rr := servePatch(t, "/channels/test-channel/records/test-record", "application/json-patch+json", `[{"op":"test","path":"/size","value":"M"},{"op":"add","path":"/tags/-","value":"b"},{"op":"remove","path":"/color"}]`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, map[string]interface{}{"size": "M", "tags": []interface{}{"a", "b"}}, patchedPayload)
+
+	rr = servePatch(t, "/channels/test-channel/records/test-record", "application/json-patch+json", `[{"op":"add","value":"b"}]`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Patch", *res.Status)
+	assert.Equal(t, "invalid patch: operation 0 has no path", res.Error)
+}
+
+//TestPatchRecordFailed tests that patches which do not apply to the latest record payload or do not leave an object are rejected
+func TestPatchRecordFailed(t *testing.T) {
+	for _, body := range []string{`[{"op":"test","path":"/size","value":"L"}]`, `[{"op":"remove","path":"/weight"}]`, `[{"op":"replace","path":"","value":"size"}]`} {
+		rr := servePatch(t, "/channels/test-channel/records/test-record", "application/json-patch+json", body)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		var res models.ErrorResponseDefinition
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, "Patch Failed", *res.Status)
+		assert.Nil(t, patchedPayload)
+	}
+}
+
+//TestPatchRecordErrors tests patching records in missing channels, missing records, records in a state UPDATE is not allowed in and errors reading them
+func TestPatchRecordErrors(t *testing.T) {
+	rr := servePatch(t, "/channels/test-channel2/records/test-record", "application/merge-patch+json", `{"size":"L"}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = servePatch(t, "/channels/test-channel/records/new-record", "application/merge-patch+json", `{"size":"L"}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "No Such Resource", *res.Status)
+
+	rr = servePatch(t, "/channels/lifecycle-channel/records/attached-record", "application/merge-patch+json", `{"size":"L"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+
+	rr = servePatch(t, "/channels/test-channel/records/unverified-record", "application/merge-patch+json", `{"size":"L"}`)
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+
+	rr = servePatch(t, "/channels/test-channel/records/error-record", "application/merge-patch+json", `{"size":"L"}`)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = servePatch(t, "/channels/test-channel/records/update-record-error", "application/merge-patch+json", `{"size":"L"}`)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func patchRecordMock(ctx context.Context, batch *dbom.Batch, channelID string, commitType string, recordID string, schemaVersion int64, patch func(payload interface{}) (interface{}, error), tracer opentracing.Tracer) error {
+	if recordID == "update-record-error" {
+		return errors.New("patch-record-error")
+	}
+	payload, err := patch(map[string]interface{}{"size": "M", "color": "blue", "tags": []interface{}{"a"}})
+	if err != nil {
+		return err
+	}
+	patchedPayload = payload
+	return nil
+}
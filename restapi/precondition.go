@@ -0,0 +1,52 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"trillian-agent/responses"
+)
+
+// errPreconditionFailed is returned when a commit expects a record at another revision than its latest one
+var errPreconditionFailed = errors.New(responses.PreconditionFailed)
+
+// recordETag returns the entity tag of a revision of a record
+func recordETag(revision int64) string {
+	return strconv.Quote(strconv.FormatInt(revision, 10))
+}
+
+// checkPrecondition checks the latest revision of the record of a commit, 0 if it does not exist, against the revision the commit expects and its If-Match header.
+// If-Match lists entity tags of revisions of the record, or is * for any revision of an existing record
+func checkPrecondition(ifMatch *string, expectedRevision int64, recordID string, revision int64) error {
+	if expectedRevision != 0 && expectedRevision != revision {
+		return fmt.Errorf("%w: %v is at revision %v, not %v", errPreconditionFailed, recordID, revision, expectedRevision)
+	}
+	if ifMatch == nil {
+		return nil
+	}
+	for _, tag := range strings.Split(*ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if (tag == "*" && revision > 0) || tag == recordETag(revision) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v is at revision %v, which does not match %v", errPreconditionFailed, recordID, revision, *ifMatch)
+}
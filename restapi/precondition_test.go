@@ -0,0 +1,130 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+	"trillian-agent/restapi/operations"
+
+	"github.com/go-openapi/loads"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var committedRecords []*models.RecordDefinition
+
+func servePrecondition(t *testing.T, commitType string, ifMatch string, recordDef *models.RecordDefinition) *httptest.ResponseRecorder {
+	committedRecords = nil
+	headers := map[string]string{"commit-type": commitType}
+	if ifMatch != "" {
+		headers["If-Match"] = ifMatch
+	}
+	body, _ := recordDef.MarshalBinary()
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = GetRecordMock
+		createRecord = createRecordPreconditionMock
+		getLeavesByRevision = getLeavesByRevisionMock
+		addLeaves = addLeavesMock
+	}, "POST", "/channels/test-channel/records", headers, body)
+}
+
+func preconditionRecord(recordID string, expectedRevision int64) *models.RecordDefinition {
+	return &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{"test": "test"}, ExpectedRevision: expectedRevision}
+}
+
+//TestCommitExpectedRevision tests committing on a record only if it is at the revision the commit expects
+func TestCommitExpectedRevision(t *testing.T) {
+	rr := servePrecondition(t, UPDATE, "", preconditionRecord("test-record", 2))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, len(committedRecords))
+	assert.Equal(t, int64(0), committedRecords[0].ExpectedRevision)
+
+	rr = servePrecondition(t, UPDATE, "", preconditionRecord("test-record", 1))
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Precondition Failed", *res.Status)
+	assert.Equal(t, "Precondition Failed: test-record is at revision 2, not 1", res.Error)
+	assert.Empty(t, committedRecords)
+
+	rr = servePrecondition(t, CREATE, "", preconditionRecord("new-record", 1))
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+
+	rr = servePrecondition(t, UPDATE, "", preconditionRecord("new-record", 1))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestCommitIfMatch tests committing on a record only if it is at a revision named by the If-Match header
+func TestCommitIfMatch(t *testing.T) {
+	for _, ifMatch := range []string{`"2"`, `"1", "2"`, `*`} {
+		rr := servePrecondition(t, UPDATE, ifMatch, preconditionRecord("test-record", 0))
+		assert.Equal(t, http.StatusOK, rr.Code, ifMatch)
+	}
+	for _, ifMatch := range []string{`"1"`, `2`, `W/"2"`} {
+		rr := servePrecondition(t, UPDATE, ifMatch, preconditionRecord("test-record", 0))
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code, ifMatch)
+	}
+
+	rr := servePrecondition(t, UPDATE, `"2"`, preconditionRecord("test-record", 1))
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+
+	rr = servePrecondition(t, CREATE, `*`, preconditionRecord("new-record", 0))
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+}
+
+//TestPatchRecordIfMatch tests patching a record only if it is at a revision named by the If-Match header
+func TestPatchRecordIfMatch(t *testing.T) {
+	getChannelClient = getChannelClientMock
+	getCurrentRevision = getCurrentRevisionMock
+	getChannel = GetChannelMock
+	getRecord = GetRecordMock
+	patchRecord = patchRecordMock
+	getLeavesByRevision = getLeavesByRevisionMock
+	addLeaves = addLeavesMock
+	swaggerSpec, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	handler := configureAPI(operations.NewTrillianAgentAPI(swaggerSpec))
+
+	for ifMatch, code := range map[string]int{`"2"`: http.StatusOK, `"1"`: http.StatusPreconditionFailed} {
+		patchedPayload = nil
+		req, err := http.NewRequest("PATCH", "/channels/test-channel/records/test-record", bytes.NewBufferString(`{"size":"L"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", ifMatch)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, code, rr.Code, ifMatch)
+		assert.Equal(t, code == http.StatusOK, patchedPayload != nil)
+	}
+}
+
+//TestCommitTransactionExpectedRevision tests that a transaction is not committed if any of its records is not at the revision its operation expects
+func TestCommitTransactionExpectedRevision(t *testing.T) {
+	update := transactionOperation("UPDATE", "test-record")
+	update.Record.ExpectedRevision = 2
+	rr := serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), update)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	update.Record.ExpectedRevision = 1
+	rr = serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), update)
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	assert.Empty(t, writtenLeaves)
+}
+
+func createRecordPreconditionMock(ctx context.Context, batch *dbom.Batch, prevRevision int64, channelID string, commitType string, recordDef *models.RecordDefinition, tracer opentracing.Tracer) error {
+	committedRecords = append(committedRecords, recordDef)
+	return nil
+}
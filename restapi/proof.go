@@ -0,0 +1,92 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"trillian-agent/logger"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	tclient "github.com/google/trillian/client"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var proofLogger = logger.GetLogger("Restapi:Proof")
+
+// retrieveRecordProof builds the proof bundle of a record at the requested revision of its channel, or at the latest one
+func retrieveRecordProof(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.RetrieveRecordProofParams) middleware.Responder {
+	trillMapClient := trillianConnection.MapClient
+	trillAdminClient := trillianConnection.AdminClient
+	channelMapClientTree, err := getChannelClient(ctx, trillAdminClient, trillMapClient, channelConfigMapID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(proofLogger, span, err, responses.InternalError)
+		return responses.ErrRetrieveProofChannelNotFound()
+	}
+	channelMapClient := client.MapClient{MapClient: channelMapClientTree}
+	channel, err := getChannel(ctx, &channelMapClient, params.ChannelID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(proofLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrRetrieveProofVerificationFailed(err)
+		}
+		return responses.ErrRetrieveProofInternalServerError(err)
+	} else if channel == nil {
+		tracing.LogAndTraceErr(proofLogger, span, nil, responses.ChannelNotFound)
+		return responses.ErrRetrieveProofChannelNotFound()
+	}
+
+	tree, err := getChannelTree(ctx, trillAdminClient, channel.MapID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(proofLogger, span, err, responses.InternalError)
+		return responses.ErrRetrieveProofInternalServerError(err)
+	}
+	mapClientTree, err := tclient.NewMapClientFromTree(trillMapClient, tree)
+	if err != nil {
+		tracing.LogAndTraceErr(proofLogger, span, err, responses.InternalError)
+		return responses.ErrRetrieveProofInternalServerError(err)
+	}
+
+	revision := int64(-1)
+	if params.Revision != nil {
+		revision = *params.Revision
+	}
+	proof, err := getRecordProof(ctx, &client.MapClient{MapClient: mapClientTree}, tree, params.ChannelID, params.RecordID, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(proofLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrRetrieveProofVerificationFailed(err)
+		} else if status.Code(err) == codes.NotFound {
+			return responses.ErrRetrieveProofResourceNotFound()
+		}
+		return responses.ErrRetrieveProofInternalServerError(err)
+	} else if proof == nil {
+		tracing.LogAndTraceErr(proofLogger, span, nil, responses.ResourceNotFound)
+		return responses.ErrRetrieveProofResourceNotFound()
+	}
+
+	var res = record.RetrieveRecordProofOK{Payload: proof}
+	proofLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
@@ -0,0 +1,122 @@
+package restapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/trillian"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serveProof(t *testing.T, url string) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getChannel = GetChannelMock
+		getChannelTree = getChannelTreeMock
+		getRecordProof = getRecordProofMock
+	}, "GET", url, nil, nil)
+}
+
+//TestRetrieveRecordProof tests getting the proof bundle of a record at the latest and at a specific revision
+func TestRetrieveRecordProof(t *testing.T) {
+	rr := serveProof(t, "/channels/test-channel/records/test-record/proof")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.ProofBundleDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "test-record", *res.RecordID)
+	assert.Equal(t, int64(1654), *res.Revision)
+
+	rr = serveProof(t, "/channels/test-channel/records/test-record/proof?revision=2")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(2), *res.Revision)
+}
+
+//TestRetrieveRecordProofNotFound tests getting the proof bundle of a missing channel, record or revision
+func TestRetrieveRecordProofNotFound(t *testing.T) {
+	rr := serveProof(t, "/channels/random-channel/records/test-record/proof")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveProof(t, "/channels/test-channel/records/random-record/proof")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveProof(t, "/channels/test-channel/records/test-record/proof?revision=5000")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestRetrieveRecordProofInvalidRevision tests getting the proof bundle of a record at a revision that cannot exist
+func TestRetrieveRecordProofInvalidRevision(t *testing.T) {
+	rr := serveProof(t, "/channels/test-channel/records/test-record/proof?revision=0")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestRetrieveRecordProofErrors tests errors reading the channel and the proof of a record
+func TestRetrieveRecordProofErrors(t *testing.T) {
+	rr := serveProof(t, "/channels/error-channel/records/test-record/proof")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveProof(t, "/channels/test-channel-bad-map-id/records/test-record/proof")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveProof(t, "/channels/test-channel/records/error-record/proof")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveProof(t, "/channels/test-channel/records/unverified-record/proof")
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+func getChannelTreeMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, channelMapID int64, tracer opentracing.Tracer) (*trillian.Tree, error) {
+	if channelMapID == 321 {
+		return nil, errors.New("test-error")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.Tree{
+		TreeId:             channelMapID,
+		TreeType:           trillian.TreeType_MAP,
+		HashStrategy:       trillian.HashStrategy_CONIKS_SHA256,
+		HashAlgorithm:      sigpb.DigitallySigned_SHA256,
+		SignatureAlgorithm: sigpb.DigitallySigned_ECDSA,
+		PublicKey:          &keyspb.PublicKey{Der: der},
+	}, nil
+}
+
+func getRecordProofMock(ctx context.Context, client *client.MapClient, tree *trillian.Tree, channelID string, recordID string, revision int64, tracer opentracing.Tracer) (*models.ProofBundleDefinition, error) {
+	if recordID == "error-record" {
+		return nil, errors.New("test-error")
+	} else if recordID == "unverified-record" {
+		return nil, errVerificationMock
+	} else if recordID != "test-record" {
+		return nil, nil
+	} else if revision > 1654 {
+		return nil, status.Errorf(codes.NotFound, "revision %v not found", revision)
+	}
+	if revision < 0 {
+		revision = 1654
+	}
+	mapID := tree.TreeId
+	leaf := strfmt.Base64("test-value")
+	return &models.ProofBundleDefinition{ChannelID: &channelID, RecordID: &recordID, MapID: &mapID, Revision: &revision, LeafValue: &leaf}, nil
+}
@@ -0,0 +1,128 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func serveRetire(t *testing.T, method string, url string, commitType string, body []byte) *httptest.ResponseRecorder {
+	headers := map[string]string{}
+	if commitType != "" {
+		headers["commit-type"] = commitType
+	}
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = getRecordRetireMock
+		getRecordHistory = GetRecordHistoryMock
+		createRecord = CreateRecordMock
+		attachRecord = attachRecordMock
+		getLeavesByRevision = getLeavesByRevisionMock
+		addLeaves = addLeavesMock
+	}, method, url, headers, body)
+}
+
+func retireBody(recordID string) []byte {
+	body, _ := (&models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{"reason": "scrapped"}}).MarshalBinary()
+	return body
+}
+
+//TestRetireRecord tests retiring a record
+func TestRetireRecord(t *testing.T) {
+	rr := serveRetire(t, "POST", "/channels/test-channel/records", RETIRE, retireBody("test-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+//TestRetireRecordConflict tests that a retired record cannot be changed anymore and that only active records are retired
+func TestRetireRecordConflict(t *testing.T) {
+	for _, commitType := range []string{RETIRE, UPDATE} {
+		rr := serveRetire(t, "POST", "/channels/test-channel/records", commitType, retireBody("retired-record"))
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		var res models.ErrorResponseDefinition
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Contains(t, res.Error, commitType+" is not allowed on retired-record in state retired")
+	}
+
+	rr := serveRetire(t, "POST", "/channels/test-channel/records", RETIRE, retireBody("attached-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	body, _ := attachmentRecord("other-record", "retired-record", "other-record").MarshalBinary()
+	rr = serveRetire(t, "POST", "/channels/test-channel/records", ATTACH, body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, res.Error, "ATTACH is not allowed with parent retired-record in state retired")
+}
+
+//TestRetireParentRecord tests that a parent is not retired while it has children, which could not be detached from it anymore
+func TestRetireParentRecord(t *testing.T) {
+	rr := serveRetire(t, "POST", "/channels/test-channel/records", RETIRE, retireBody("parent-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Contains(t, res.Error, "RETIRE is not allowed on parent-record while it has children child-record")
+
+	rr = serveRetire(t, "POST", "/channels/test-channel/records", UPDATE, retireBody("parent-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+//TestRetrieveRetiredRecord tests that retrieving a retired record returns its retirement
+func TestRetrieveRetiredRecord(t *testing.T) {
+	rr := serveRetire(t, "GET", "/channels/test-channel/records/retired-record", "", nil)
+	assert.Equal(t, http.StatusGone, rr.Code)
+	var res models.RetirementDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Record Retired", *res.Status)
+	assert.Equal(t, "retired-record", *res.RecordID)
+	assert.Equal(t, "test-channel", *res.ChannelID)
+	assert.Equal(t, int64(3), *res.Revision)
+	assert.Equal(t, int64(2), *res.PreviousRevision)
+	assert.Equal(t, map[string]interface{}{"reason": "scrapped"}, res.Metadata)
+
+	rr = serveRetire(t, "GET", "/channels/test-channel/records/test-record", "", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+//TestAuditRetiredRecord tests that the audit of a retired record returns its full history
+func TestAuditRetiredRecord(t *testing.T) {
+	rr := serveRetire(t, "GET", "/channels/test-channel/records/retired-record/audit", "", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.AuditResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, 3, len(res.History))
+	assert.Equal(t, RETIRE, *res.History[0].EventType)
+	assert.Equal(t, UPDATE, *res.History[1].EventType)
+	assert.Equal(t, CREATE, *res.History[2].EventType)
+}
+
+// getRecordRetireMock reads retired-record, retired at revision 3 after being created at revision 1 and updated at revision 2
+func getRecordRetireMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.Record, error) {
+	if recordID == "parent-record" {
+		channelID, eventType := "test-channel", UPDATE
+		audit := models.AuditDefinition{ChannelID: &channelID, ResourceID: &recordID, EventType: &eventType, Payload: map[string]interface{}{}}
+		return &models.Record{AuditDefinition: audit, Revision: 2, PreviousRevision: 1, ChildRecordIDs: []string{"child-record"}}, nil
+	} else if recordID != "retired-record" {
+		return GetRecordMock(ctx, client, recordID, revision, tracer)
+	}
+	if revision < 0 {
+		revision = 3
+	}
+	channelID, eventType, timestamp := "test-channel", []string{CREATE, UPDATE, RETIRE}[revision-1], strfmt.DateTime{}
+	payload := map[string]interface{}{"recordID": recordID, "recordIDPayload": map[string]interface{}{"name": "part"}}
+	if eventType == RETIRE {
+		payload["recordIDPayload"] = map[string]interface{}{"reason": "scrapped"}
+	}
+	audit := models.AuditDefinition{ChannelID: &channelID, ResourceID: &recordID, EventType: &eventType, Payload: payload, Timestamp: &timestamp}
+	return &models.Record{AuditDefinition: audit, Revision: revision, PreviousRevision: revision - 1}, nil
+}
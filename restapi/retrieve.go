@@ -0,0 +1,96 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var retrieveLogger = logger.GetLogger("Restapi:Retrieve")
+
+// recordAsOfChunk is the number of revisions of a record read at a time while looking for its revision as of a point in time
+const recordAsOfChunk = 100
+
+// errInvalidQuery is returned when a record is queried at both a revision and a point in time
+var errInvalidQuery = errors.New(responses.InvalidQuery)
+
+// retrieveRecordAt reads the revision of a record a query asks for: its revision at a map revision of its channel, its last revision committed at or before a point in time, or else its latest revision
+func retrieveRecordAt(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.RetrieveRecordParams, mapClient *client.MapClient) (*models.Record, middleware.Responder) {
+	var result *models.Record
+	var err error
+	if params.Revision != nil && params.AsOf != nil {
+		err = fmt.Errorf("%w: revision and asOf cannot both be set", errInvalidQuery)
+		tracing.LogAndTraceErr(retrieveLogger, span, err, responses.InvalidQuery)
+		return nil, responses.ErrRetrieveRecordInvalidQuery(err)
+	} else if params.Revision != nil {
+		result, err = getRecord(ctx, mapClient, params.RecordID, *params.Revision, tracer)
+	} else if params.AsOf != nil {
+		result, err = recordAsOf(ctx, mapClient, params.RecordID, time.Time(*params.AsOf), tracer)
+	} else {
+		result, err = getRecord(ctx, mapClient, params.RecordID, -1, tracer)
+	}
+	if err != nil {
+		tracing.LogAndTraceErr(retrieveLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return nil, responses.ErrRetrieveVerificationFailed(err)
+		} else if status.Code(err) == codes.NotFound {
+			return nil, responses.ErrRetrieveResourceNotFound()
+		}
+		return nil, responses.ErrRetrieveRecordInternalServerError(err)
+	} else if result == nil {
+		tracing.LogAndTraceErr(retrieveLogger, span, nil, responses.ResourceNotFound)
+		return nil, responses.ErrRetrieveResourceNotFound()
+	}
+	return result, nil
+}
+
+// recordAsOf reads back the history of a record in its channel to its last revision committed at or before a point in time.
+// It returns no record if the record was created in the channel after that time
+func recordAsOf(ctx context.Context, mapClient *client.MapClient, recordID string, asOf time.Time, tracer opentracing.Tracer) (*models.Record, error) {
+	rev := int64(-1)
+	for {
+		history, err := getRecordHistory(ctx, mapClient, recordID, rev, recordAsOfChunk, tracer)
+		if err != nil || len(history) == 0 {
+			return nil, err
+		}
+		for _, result := range history {
+			if result.Timestamp != nil && !time.Time(*result.Timestamp).After(asOf) {
+				return result, nil
+			}
+		}
+		rev = history[len(history)-1].PreviousRevision
+		if rev <= 0 {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serveRetrieve(t *testing.T, url string) *httptest.ResponseRecorder {
+	historyChunks = nil
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = func(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.Record, error) {
+			if revision > historyLatest {
+				return nil, status.Errorf(codes.NotFound, "revision %v not found", revision)
+			}
+			return getHistoryRecordMock(ctx, client, recordID, revision, tracer)
+		}
+		getRecordHistory = func(ctx context.Context, client *client.MapClient, recordID string, revision int64, limit int64, tracer opentracing.Tracer) ([]*models.Record, error) {
+			historyChunks = append(historyChunks, limit)
+			return GetRecordHistoryMock(ctx, client, recordID, revision, limit, tracer)
+		}
+	}, "GET", url, nil, nil)
+}
+
+func retrievedRevision(t *testing.T, rr *httptest.ResponseRecorder) int64 {
+	var res map[string]int64
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	return res["revision"]
+}
+
+//TestRetrieveRecordAtRevision tests retrieving a record at a map revision of its channel
+func TestRetrieveRecordAtRevision(t *testing.T) {
+	historyLatest = 7
+	rr := serveRetrieve(t, "/channels/test-channel/records/history-record")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(7), retrievedRevision(t, rr))
+	assert.Equal(t, `"7"`, rr.Header().Get("ETag"))
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?revision=4")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(4), retrievedRevision(t, rr))
+	assert.Equal(t, `"4"`, rr.Header().Get("ETag"))
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?revision=99")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?revision=0")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestRetrieveRecordAsOf tests retrieving the last revision of a record committed at or before a point in time
+func TestRetrieveRecordAsOf(t *testing.T) {
+	historyLatest = 7
+	rr := serveRetrieve(t, "/channels/test-channel/records/history-record?asOf=2020-10-04T12:00:00Z")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(3), retrievedRevision(t, rr))
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?asOf=2020-10-04T11:59:59Z")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(2), retrievedRevision(t, rr))
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?asOf=2021-01-01T00:00:00%2B01:00")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(7), retrievedRevision(t, rr))
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?asOf=2020-10-01T00:00:00Z")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/random-record?asOf=2020-10-04T12:00:00Z")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveRetrieve(t, "/channels/test-channel/records/history-record?asOf=yesterday")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestRetrieveRecordAsOfChunks tests that the history of a record is read back chunk at a time until the revision as of a point in time
+func TestRetrieveRecordAsOfChunks(t *testing.T) {
+	historyLatest = 250
+	defer func() { historyLatest = 7 }()
+	rr := serveRetrieve(t, "/channels/test-channel/records/history-record?asOf=2020-10-11T12:00:00Z")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(10), retrievedRevision(t, rr))
+	assert.Equal(t, []int64{recordAsOfChunk, recordAsOfChunk, recordAsOfChunk}, historyChunks)
+}
+
+//TestRetrieveRecordInvalidQuery tests retrieving a record at both a revision and a point in time
+func TestRetrieveRecordInvalidQuery(t *testing.T) {
+	historyLatest = 7
+	rr := serveRetrieve(t, "/channels/test-channel/records/history-record?revision=4&asOf=2020-10-04T12:00:00Z")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Query", *res.Status)
+	assert.Contains(t, res.Error, "revision and asOf cannot both be set")
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/channel"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	"github.com/opentracing/opentracing-go"
+)
+
+var schemaLogger = logger.GetLogger("Restapi:Schema")
+
+// draft4Schema is the JSON Schema draft 4 meta schema the schemas of channels must validate against
+var draft4Schema = spec.MustLoadJSONSchemaDraft04()
+
+// errInvalidSchema is returned when the schema set for a channel is not a valid JSON Schema
+var errInvalidSchema = errors.New(responses.InvalidSchema)
+
+// errSchemaViolation is returned when a record payload violates the schema of its channel
+var errSchemaViolation = errors.New(responses.SchemaViolation)
+
+// schemaViolationError is returned when a record payload violates the schema of its channel, with the violations found
+type schemaViolationError struct {
+	RecordID   string
+	Version    int64
+	Violations []string
+}
+
+// Error returns the schema violation message
+func (e *schemaViolationError) Error() string {
+	return fmt.Sprintf("%v: record payload of %v violates version %v of the schema of its channel", responses.SchemaViolation, e.RecordID, e.Version)
+}
+
+// Unwrap returns errSchemaViolation
+func (e *schemaViolationError) Unwrap() error {
+	return errSchemaViolation
+}
+
+// schemaViolations returns the violations of the schema of a channel err was returned for, if any
+func schemaViolations(err error) []string {
+	var violationErr *schemaViolationError
+	if errors.As(err, &violationErr) {
+		return violationErr.Violations
+	}
+	return nil
+}
+
+// validatesPayload reports whether the record payload of a commit type is validated against the schema of the channel,
+// which is the case for CREATE, UPDATE, ATTACH and DETACH. RETIRE is not validated, as its record payload is the metadata of the retirement rather than record data,
+// and TRANSFER-IN is validated against the target channel by transferredRecord
+func validatesPayload(commitType string) bool {
+	return commitType == CREATE || commitType == UPDATE || commitType == ATTACH || commitType == DETACH
+}
+
+// validateSchema validates that a schema is a JSON Schema draft 4 that only refers to definitions within itself
+func validateSchema(schema interface{}) error {
+	if _, ok := schema.(map[string]interface{}); !ok {
+		return fmt.Errorf("%w: a schema is an object", errInvalidSchema)
+	}
+	res := validate.NewSchemaValidator(draft4Schema, nil, "schema", strfmt.Default).Validate(schema)
+	if !res.IsValid() {
+		return fmt.Errorf("%w: %v", errInvalidSchema, strings.Join(errorMessages(res.Errors), ", "))
+	}
+	if ref := externalRef(schema); ref != "" {
+		return fmt.Errorf("%w: %v does not refer to a definition within the schema", errInvalidSchema, ref)
+	}
+	return nil
+}
+
+// externalRef returns the first $ref of a schema that does not refer to a definition within the schema, so that the agent never loads schemas from elsewhere
+func externalRef(value interface{}) string {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && !strings.HasPrefix(ref, "#") {
+			return ref
+		}
+		for _, member := range value {
+			if ref := externalRef(member); ref != "" {
+				return ref
+			}
+		}
+	case []interface{}:
+		for _, element := range value {
+			if ref := externalRef(element); ref != "" {
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
+// errorMessages returns the sorted messages of errors
+func errorMessages(errs []error) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+// checkPayload validates the record payload of a commit against the latest schema of its channel and returns the version of the schema, 0 if no schema was set for the channel
+func checkPayload(channel *models.Channel, recordID string, payload interface{}) (int64, error) {
+	if channel.SchemaVersion == 0 {
+		return 0, nil
+	}
+	raw, err := json.Marshal(channel.Schema)
+	if err != nil {
+		return 0, err
+	}
+	var schema spec.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return 0, err
+	}
+	res := validate.NewSchemaValidator(&schema, channel.Schema, "recordIDPayload", strfmt.Default).Validate(payload)
+	if !res.IsValid() {
+		return 0, &schemaViolationError{RecordID: recordID, Version: channel.SchemaVersion, Violations: errorMessages(res.Errors)}
+	}
+	return channel.SchemaVersion, nil
+}
+
+// schemaDefinition describes a version of the schema of a channel
+func schemaDefinition(schema interface{}, version int64) *models.SchemaDefinition {
+	return &models.SchemaDefinition{Schema: schema, Version: version}
+}
+
+// retrieveChannelSchema returns the latest version of the schema of a channel, or the version asked for
+func retrieveChannelSchema(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.GetChannelSchemaParams) middleware.Responder {
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(schemaLogger, span, err, responses.InternalError)
+		return responses.ErrGetChannelSchemaInternalServerError(err)
+	}
+	found, err := getChannel(ctx, channelMapClient, params.ChannelID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(schemaLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrGetChannelSchemaVerificationFailed(err)
+		}
+		return responses.ErrGetChannelSchemaInternalServerError(err)
+	} else if found == nil {
+		tracing.LogAndTraceErr(schemaLogger, span, nil, responses.ChannelNotFound)
+		return responses.ErrGetChannelSchemaNotFound()
+	}
+
+	version := found.SchemaVersion
+	if params.Version != nil {
+		version = *params.Version
+	}
+	if version == 0 || version > found.SchemaVersion {
+		tracing.LogAndTraceErr(schemaLogger, span, nil, responses.ResourceNotFound)
+		return responses.ErrGetChannelSchemaResourceNotFound()
+	}
+	result := schemaDefinition(found.Schema, found.SchemaVersion)
+	if version < found.SchemaVersion {
+		schema, err := getChannelSchema(ctx, channelMapClient, params.ChannelID, version, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(schemaLogger, span, err, responses.InternalError)
+			if client.IsVerificationError(err) {
+				return responses.ErrGetChannelSchemaVerificationFailed(err)
+			}
+			return responses.ErrGetChannelSchemaInternalServerError(err)
+		} else if schema == nil {
+			tracing.LogAndTraceErr(schemaLogger, span, nil, responses.ResourceNotFound)
+			return responses.ErrGetChannelSchemaResourceNotFound()
+		}
+		result = schemaDefinition(schema.Schema, schema.Version)
+	}
+
+	var res = channel.GetChannelSchemaOK{Payload: result}
+	schemaLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
+
+// setChannelSchema sets a new version of the schema of a channel, which the record payloads committed to the channel from then on must validate against
+func setChannelSchema(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params channel.UpdateChannelSchemaParams) middleware.Responder {
+	schema := params.Body.Schema
+	if err := validateSchema(schema); err != nil {
+		tracing.LogAndTraceErr(schemaLogger, span, err, responses.InvalidSchema)
+		return responses.ErrUpdateChannelSchemaInvalidSchema(err)
+	}
+
+	channelMapClient, err := openChannelConfig(ctx, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(schemaLogger, span, err, responses.InternalError)
+		return responses.ErrUpdateChannelSchemaInternalServerError(err)
+	}
+	var updated *models.Channel
+	err = commitCoordinator.Commit(ctx, channelConfigCommitKey, func(ctx context.Context) error {
+		found, err := getChannel(ctx, channelMapClient, params.ChannelID, tracer)
+		if err != nil {
+			return err
+		} else if found == nil {
+			return errChannelNotFound
+		}
+		channelRevision, err := getCurrentRevision(channelMapClient, ctx, channelConfigMapID, tracer)
+		if err != nil {
+			return err
+		}
+		found.Schema = schema
+		found.SchemaVersion++
+		if err := updateChannelSchema(ctx, trillianConnection.MapWriteClient, int64(channelRevision+1), channelConfigMapID, found, tracer); err != nil {
+			return err
+		}
+		updated = found
+		return nil
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(schemaLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrUpdateChannelSchemaNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrUpdateChannelSchemaVerificationFailed(err)
+		}
+		return responses.ErrUpdateChannelSchemaInternalServerError(err)
+	}
+
+	var res = channel.UpdateChannelSchemaOK{Payload: schemaDefinition(updated.Schema, updated.SchemaVersion)}
+	schemaLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
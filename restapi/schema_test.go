@@ -0,0 +1,230 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var updatedSchemaChannels []*models.Channel
+
+// partSchema is the schema set for schema-channel at version 2
+var partSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"partNumber"},
+	"properties": map[string]interface{}{
+		"partNumber": map[string]interface{}{"type": "string", "pattern": "^P-"},
+		"quantity":   map[string]interface{}{"$ref": "#/definitions/quantity"},
+	},
+	"definitions": map[string]interface{}{
+		"quantity": map[string]interface{}{"type": "integer", "minimum": float64(1)},
+	},
+}
+
+func serveSchema(t *testing.T, method string, url string, headers map[string]string, body []byte) *httptest.ResponseRecorder {
+	updatedSchemaChannels = nil
+	committedRecords = nil
+	patchedPayload = nil
+	stagedAttachments = nil
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = getSchemaChannelMock
+		getChannelSchema = getChannelSchemaMock
+		updateChannelSchema = updateChannelSchemaMock
+		getRecord = GetRecordMock
+		createRecord = createRecordPreconditionMock
+		patchRecord = patchRecordMock
+		attachRecord = attachRecordMock
+		detachRecord = detachRecordMock
+		getLeavesByRevision = getLeavesByRevisionMock
+		addLeaves = addLeavesMock
+	}, method, url, headers, body)
+}
+
+func schemaBody(schema interface{}) []byte {
+	body, _ := json.Marshal(map[string]interface{}{"schema": schema})
+	return body
+}
+
+func payloadRecord(recordID string, payload map[string]interface{}) []byte {
+	body, _ := (&models.RecordDefinition{RecordID: &recordID, RecordIDPayload: payload}).MarshalBinary()
+	return body
+}
+
+//TestGetChannelSchema tests querying the latest and earlier versions of the schema of a channel
+func TestGetChannelSchema(t *testing.T) {
+	rr := serveSchema(t, "GET", "/channels/schema-channel/schema", nil, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.SchemaDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(2), res.Version)
+	assert.Equal(t, partSchema, res.Schema)
+
+	rr = serveSchema(t, "GET", "/channels/schema-channel/schema?version=1", nil, nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1), res.Version)
+	assert.Equal(t, map[string]interface{}{}, res.Schema)
+
+	rr = serveSchema(t, "GET", "/channels/schema-channel/schema?version=3", nil, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveSchema(t, "GET", "/channels/test-channel/schema", nil, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	var errRes models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &errRes))
+	assert.Equal(t, "No Such Resource", *errRes.Status)
+	rr = serveSchema(t, "GET", "/channels/test-channel2/schema", nil, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &errRes))
+	assert.Equal(t, "No Such Channel", *errRes.Status)
+	rr = serveSchema(t, "GET", "/channels/error-channel/schema", nil, nil)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestUpdateChannelSchema tests setting new versions of the schema of a channel
+func TestUpdateChannelSchema(t *testing.T) {
+	rr := serveSchema(t, "PUT", "/channels/test-channel/schema", nil, schemaBody(partSchema))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.SchemaDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1), res.Version)
+	assert.Equal(t, partSchema, res.Schema)
+	assert.Equal(t, 1, len(updatedSchemaChannels))
+	assert.Equal(t, int64(1), updatedSchemaChannels[0].SchemaVersion)
+	assert.Equal(t, int64(1536), updatedSchemaChannels[0].MapID)
+
+	rr = serveSchema(t, "PUT", "/channels/schema-channel/schema", nil, schemaBody(map[string]interface{}{}))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(3), res.Version)
+
+	rr = serveSchema(t, "PUT", "/channels/test-channel2/schema", nil, schemaBody(partSchema))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serveSchema(t, "PUT", "/channels/error-channel/schema", nil, schemaBody(partSchema))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+//TestUpdateChannelSchemaInvalid tests that schemas which are not valid JSON Schemas or refer outside of themselves are rejected
+func TestUpdateChannelSchemaInvalid(t *testing.T) {
+	for _, schema := range []interface{}{
+		"object",
+		map[string]interface{}{"type": "objet"},
+		map[string]interface{}{"properties": map[string]interface{}{"quantity": map[string]interface{}{"minimum": "one"}}},
+		map[string]interface{}{"properties": map[string]interface{}{"part": map[string]interface{}{"$ref": "http://example.com/part.json"}}},
+	} {
+		rr := serveSchema(t, "PUT", "/channels/test-channel/schema", nil, schemaBody(schema))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var res models.ErrorResponseDefinition
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, "Invalid Schema", *res.Status)
+		assert.Empty(t, updatedSchemaChannels)
+	}
+}
+
+//TestCommitSchema tests that the record payloads of CREATE and UPDATE commits are validated against the schema of the channel
+func TestCommitSchema(t *testing.T) {
+	rr := serveSchema(t, "POST", "/channels/schema-channel/records", map[string]string{"commit-type": UPDATE}, payloadRecord("test-record", map[string]interface{}{"partNumber": "P-1", "quantity": 2}))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1, len(committedRecords))
+	assert.Equal(t, int64(2), committedRecords[0].SchemaVersion)
+
+	rr = serveSchema(t, "POST", "/channels/schema-channel/records", map[string]string{"commit-type": CREATE}, payloadRecord("new-record", map[string]interface{}{"partNumber": "X-1", "quantity": 0}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Schema Violation", *res.Status)
+	assert.Equal(t, "Schema Violation: record payload of new-record violates version 2 of the schema of its channel", res.Error)
+	assert.Equal(t, []string{"recordIDPayload.partNumber in body should match '^P-'", "recordIDPayload.quantity in body should be greater than or equal to 1"}, res.Violations)
+	assert.Empty(t, committedRecords)
+
+	rr = serveSchema(t, "POST", "/channels/schema-channel/records", map[string]string{"commit-type": RETIRE}, payloadRecord("test-record", map[string]interface{}{"reason": "scrapped"}))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(0), committedRecords[0].SchemaVersion)
+
+	rr = serveSchema(t, "POST", "/channels/test-channel/records", map[string]string{"commit-type": UPDATE}, payloadRecord("test-record", map[string]interface{}{"reason": "scrapped"}))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, int64(0), committedRecords[0].SchemaVersion)
+}
+
+//TestAttachRecordSchema tests that the record payloads of ATTACH and DETACH commits, stored as the one of the record they name, are validated against the schema of the channel
+func TestAttachRecordSchema(t *testing.T) {
+	attach := attachmentRecord("other-record", "test-record", "other-record")
+	attach.RecordIDPayload = map[string]interface{}{"partNumber": "P-1"}
+	body, _ := attach.MarshalBinary()
+	rr := serveSchema(t, "POST", "/channels/schema-channel/records", map[string]string{"commit-type": ATTACH}, body)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"ATTACH test-record other-record"}, stagedAttachments)
+
+	body, _ = attachmentRecord("other-record", "test-record", "other-record").MarshalBinary()
+	rr = serveSchema(t, "POST", "/channels/schema-channel/records", map[string]string{"commit-type": ATTACH}, body)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Schema Violation", *res.Status)
+	assert.Equal(t, []string{"recordIDPayload.partNumber in body is required"}, res.Violations)
+	assert.Empty(t, stagedAttachments)
+
+	body, _ = attachmentRecord("attached-record", "test-record", "attached-record").MarshalBinary()
+	rr = serveSchema(t, "POST", "/channels/schema-channel/records", map[string]string{"commit-type": DETACH}, body)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Empty(t, stagedAttachments)
+}
+
+//TestCommitTransactionSchema tests that a transaction is not committed if the record payload of any of its operations violates the schema of the channel
+func TestCommitTransactionSchema(t *testing.T) {
+	update := transactionOperation(UPDATE, "test-record")
+	update.Record.RecordIDPayload = map[string]interface{}{"quantity": 1}
+	transaction := models.TransactionDefinition{Operations: []*models.TransactionOperationDefinition{update}}
+	body, _ := transaction.MarshalBinary()
+	rr := serveSchema(t, "POST", "/channels/schema-channel/transactions", nil, body)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Schema Violation", *res.Status)
+	assert.Equal(t, []string{"recordIDPayload.partNumber in body is required"}, res.Violations)
+	assert.Empty(t, committedRecords)
+}
+
+//TestPatchRecordSchema tests that a patched record payload is validated against the schema of the channel
+func TestPatchRecordSchema(t *testing.T) {
+	headers := map[string]string{"Content-Type": "application/merge-patch+json"}
+	rr := serveSchema(t, "PATCH", "/channels/schema-channel/records/test-record", headers, []byte(`{"size":null,"color":null,"tags":null,"partNumber":"P-1"}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, map[string]interface{}{"partNumber": "P-1"}, patchedPayload)
+
+	rr = serveSchema(t, "PATCH", "/channels/schema-channel/records/test-record", headers, []byte(`{"quantity":1}`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Schema Violation", *res.Status)
+	assert.Equal(t, []string{"recordIDPayload.partNumber in body is required"}, res.Violations)
+	assert.Nil(t, patchedPayload)
+}
+
+func getSchemaChannelMock(ctx context.Context, client *client.MapClient, channelID string, tracer opentracing.Tracer) (*models.Channel, error) {
+	if channelID == "schema-channel" {
+		return &models.Channel{ChannelID: channelID, MapID: 1536, Schema: partSchema, SchemaVersion: 2}, nil
+	}
+	return GetChannelMock(ctx, client, channelID, tracer)
+}
+
+func getChannelSchemaMock(ctx context.Context, client *client.MapClient, channelID string, version int64, tracer opentracing.Tracer) (*models.ChannelSchema, error) {
+	if channelID == "schema-channel" && version == 1 {
+		return &models.ChannelSchema{ChannelID: channelID, Version: 1, Schema: map[string]interface{}{}}, nil
+	}
+	return nil, nil
+}
+
+func updateChannelSchemaMock(ctx context.Context, trillMapWriteClient trillian.TrillianMapWriteClient, revision int64, channelMapID int64, channel *models.Channel, tracer opentracing.Tracer) error {
+	updatedSchemaChannels = append(updatedSchemaChannels, channel)
+	return nil
+}
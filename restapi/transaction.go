@@ -0,0 +1,174 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+)
+
+// commitTransaction validates the operations of a transaction and stages them together, so that either all of them are written in one map revision or none are
+func commitTransaction(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.CommitTransactionParams) middleware.Responder {
+	operations := params.Body.Operations
+	createsChannel := true
+	committed := make(map[string]bool)
+	indexes := make([][]byte, 0, len(operations))
+	for _, operation := range operations {
+		recordID := *operation.Record.RecordID
+		if !validCommitType(*operation.CommitType) {
+			tracing.LogAndTraceErr(commitLogger, span, nil, responses.InvalidCommitType)
+			return responses.ErrTransactionInvalid(fmt.Sprintf("%v: %v %v", recordID, responses.InvalidCommitType, *operation.CommitType))
+		}
+		recordIDs, err := commitRecordIDs(*operation.CommitType, operation.Record)
+		if err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InvalidAttachment)
+			return responses.ErrTransactionInvalid(err.Error())
+		}
+		if committed[recordID] {
+			tracing.LogAndTraceErr(commitLogger, span, nil, responses.InvalidTransaction)
+			return responses.ErrTransactionInvalid(fmt.Sprintf("%v is committed more than once", recordID))
+		}
+		committed[recordID] = true
+		for _, recordID := range recordIDs {
+			indexes = append(indexes, dbom.RecordIndex(recordID))
+		}
+		createsChannel = createsChannel && createsRecord(*operation.CommitType)
+	}
+
+	channel, mapClient, err := openCommitChannel(ctx, params.ChannelID, createsChannel, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrTransactionChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrTransactionVerificationFailed(err)
+		}
+		return responses.ErrTransactionInternalServerError(err)
+	}
+
+	var res middleware.Responder
+	err = commitCoordinator.Stage(ctx, channelCommitKey(params.ChannelID), channelMapWriter(mapClient, channel.MapID, tracer), indexes, func(ctx context.Context, batch *dbom.Batch) error {
+		var stageErr error
+		res, stageErr = stageTransaction(ctx, span, tracer, params, channel, mapClient, batch)
+		return stageErr
+	}, tracer)
+	if errors.Is(err, errTransactionRejected) {
+		return res
+	} else if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		return responses.ErrTransactionInternalServerError(err)
+	}
+	return res
+}
+
+// errTransactionRejected is returned by stageTransaction when an operation cannot be committed, so that the operations already staged are dropped from the batch
+var errTransactionRejected = errors.New(responses.InvalidTransaction)
+
+// stageTransaction checks and stages the operations of a transaction in order, and names the first record that cannot be committed.
+// Each operation is checked against the records staged by the operations before it, so that several operations may attach to one parent or attach a record created by the transaction.
+// If an operation cannot be committed, errTransactionRejected drops every operation of the transaction from the batch
+func stageTransaction(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.CommitTransactionParams, channel *models.Channel, mapClient *client.MapClient, batch *dbom.Batch) (middleware.Responder, error) {
+	operations := params.Body.Operations
+	records := make([]*models.TransactionRecordDefinition, len(operations))
+	for i, operation := range operations {
+		prevRevision, schemaVersion, res := checkOperation(ctx, span, tracer, channel, mapClient, batch, operation)
+		if res != nil {
+			return res, errTransactionRejected
+		}
+		err := stageCommit(ctx, batch, prevRevision, params.ChannelID, *operation.CommitType, storedRecord(operation.Record, schemaVersion), tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+			return responses.ErrTransactionInternalServerError(err), err
+		}
+		revision := batch.Revision()
+		records[i] = &models.TransactionRecordDefinition{
+			RecordID:         operation.Record.RecordID,
+			Revision:         &revision,
+			PreviousRevision: &prevRevision,
+		}
+	}
+
+	var success = true
+	var revision = batch.Revision()
+	var resDef = models.TransactionResponseDefinition{Success: &success, Revision: &revision, Records: records}
+	var res = record.CommitTransactionOK{Payload: &resDef}
+	commitLogger.Debug().Msgf("%v", res.Payload)
+	return &res, nil
+}
+
+// checkOperation validates an operation of a transaction against the records staged in the batch, or else their latest revision.
+// It returns the revision of the record the operation follows and the version of the schema its record payload was validated against, or the response rejecting it
+func checkOperation(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, channel *models.Channel, mapClient *client.MapClient, batch *dbom.Batch, operation *models.TransactionOperationDefinition) (int64, int64, middleware.Responder) {
+	recordID := *operation.Record.RecordID
+	prevRevision, err := checkCommit(ctx, batch, mapClient, channel, *operation.CommitType, recordID, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+		if errors.Is(err, errRecordExists) {
+			return 0, 0, responses.ErrTransactionRecordConflict(recordID)
+		} else if errors.Is(err, errInvalidTransition) {
+			return 0, 0, responses.ErrTransactionInvalidTransition(err)
+		} else if errors.Is(err, errRecordNotFound) {
+			return 0, 0, responses.ErrTransactionResourceNotFound(recordID)
+		} else if client.IsVerificationError(err) {
+			return 0, 0, responses.ErrTransactionVerificationFailed(err)
+		}
+		return 0, 0, responses.ErrTransactionInternalServerError(err)
+	}
+	if err := checkPrecondition(nil, operation.Record.ExpectedRevision, recordID, prevRevision); err != nil {
+		tracing.LogAndTraceErr(commitLogger, span, err, responses.PreconditionFailed)
+		return 0, 0, responses.ErrTransactionPreconditionFailed(err)
+	}
+	var schemaVersion int64
+	if validatesPayload(*operation.CommitType) {
+		schemaVersion, err = checkPayload(channel, recordID, operation.Record.RecordIDPayload)
+		if err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.SchemaViolation)
+			if errors.Is(err, errSchemaViolation) {
+				return 0, 0, responses.ErrTransactionSchemaViolation(err, schemaViolations(err))
+			}
+			return 0, 0, responses.ErrTransactionInternalServerError(err)
+		}
+	}
+	if attachesRecords(*operation.CommitType) {
+		if recordID, err := checkAttachment(ctx, batch, mapClient, channel, *operation.CommitType, operation.Record, tracer); err != nil {
+			tracing.LogAndTraceErr(commitLogger, span, err, responses.InternalError)
+			if errors.Is(err, errRecordNotFound) {
+				return 0, 0, responses.ErrTransactionResourceNotFound(recordID)
+			} else if errors.Is(err, errInvalidTransition) {
+				return 0, 0, responses.ErrTransactionInvalidTransition(err)
+			} else if isAttachmentConflict(err) {
+				return 0, 0, responses.ErrTransactionAttachmentConflict(err)
+			} else if client.IsVerificationError(err) {
+				return 0, 0, responses.ErrTransactionVerificationFailed(err)
+			}
+			return 0, 0, responses.ErrTransactionInternalServerError(err)
+		}
+	}
+	return prevRevision, schemaVersion, nil
+}
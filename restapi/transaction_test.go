@@ -0,0 +1,173 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/models"
+
+	"github.com/google/trillian"
+	"github.com/stretchr/testify/assert"
+)
+
+func transactionOperation(commitType string, recordID string) *models.TransactionOperationDefinition {
+	return &models.TransactionOperationDefinition{
+		CommitType: &commitType,
+		Record:     &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{"test": "test"}},
+	}
+}
+
+func attachOperation(recordID string, parentID string, childID string) *models.TransactionOperationDefinition {
+	operation := transactionOperation("ATTACH", recordID)
+	operation.Record.Attachment = &models.AttachmentDefinition{ParentRecordID: &parentID, ChildRecordID: &childID}
+	return operation
+}
+
+func writtenRecord(t *testing.T, leaves []*trillian.MapLeaf, recordID string) *models.Record {
+	var result *models.Record
+	for _, leaf := range leaves {
+		if bytes.Equal(leaf.Index, dbom.RecordIndex(recordID)) {
+			assert.Nil(t, result, "%v is written more than once", recordID)
+			result = &models.Record{}
+			assert.Nil(t, result.UnmarshalBinary(leaf.LeafValue))
+		}
+	}
+	return result
+}
+
+func serveTransaction(t *testing.T, channelID string, ops ...*models.TransactionOperationDefinition) *httptest.ResponseRecorder {
+	writtenLeaves = nil
+	transaction := models.TransactionDefinition{Operations: ops}
+	reqBody, _ := transaction.MarshalBinary()
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = GetChannelMock
+		getRecord = GetRecordMock
+		createChannel = CreateChannelMock
+		createChannelMap = CreateChannelMapMock
+		deleteChannelMap = DeleteChannelMapMock
+		createRecord = dbom.CreateRecord
+		attachRecord = dbom.AttachRecord
+		detachRecord = dbom.DetachRecord
+		addLeaves = addLeavesMock
+		getLeavesByRevision = getLeavesByRevisionMock
+	}, "POST", "/channels/"+channelID+"/transactions", nil, reqBody)
+}
+
+//TestCommitTransaction tests committing several records in one map revision
+func TestCommitTransaction(t *testing.T) {
+	rr := serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), transactionOperation("UPDATE", "test-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var res models.TransactionResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1655), *res.Revision)
+	assert.Equal(t, 2, len(res.Records))
+	assert.Equal(t, "new-record", *res.Records[0].RecordID)
+	assert.Equal(t, int64(0), *res.Records[0].PreviousRevision)
+	assert.Equal(t, "test-record", *res.Records[1].RecordID)
+	assert.Equal(t, int64(1655), *res.Records[1].Revision)
+	assert.Equal(t, int64(2), *res.Records[1].PreviousRevision)
+
+	assert.Equal(t, 1, len(writtenLeaves))
+	assert.Equal(t, 6, len(writtenLeaves[0]))
+}
+
+//TestCommitTransactionAttach tests a transaction creating a record and attaching it and another record to one parent
+func TestCommitTransactionAttach(t *testing.T) {
+	rr := serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), attachOperation("test-record", "test-record", "new-record"), attachOperation("other-record", "test-record", "other-record"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var res models.TransactionResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, 3, len(res.Records))
+	assert.Equal(t, int64(2), *res.Records[1].PreviousRevision)
+	assert.Equal(t, int64(2), *res.Records[2].PreviousRevision)
+
+	assert.Equal(t, 1, len(writtenLeaves))
+	parent := writtenRecord(t, writtenLeaves[0], "test-record")
+	assert.Equal(t, []string{"new-record", "other-record"}, parent.ChildRecordIDs)
+	assert.Equal(t, int64(1655), parent.Revision)
+	assert.Equal(t, int64(2), parent.PreviousRevision)
+	created := writtenRecord(t, writtenLeaves[0], "new-record")
+	assert.Equal(t, "test-record", created.ParentRecordID)
+	assert.Equal(t, int64(0), created.PreviousRevision)
+	assert.Equal(t, int64(1), *created.HistoryLength)
+	assert.Equal(t, "test-record", writtenRecord(t, writtenLeaves[0], "other-record").ParentRecordID)
+}
+
+//TestCommitTransactionAttachConflict tests that no record is written when an operation conflicts with one staged before it
+func TestCommitTransactionAttachConflict(t *testing.T) {
+	rr := serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), attachOperation("test-record", "test-record", "other-record"), attachOperation("attached-record", "attached-record", "other-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Equal(t, 0, len(writtenLeaves))
+}
+
+//TestCommitTransactionCreateChannel tests a transaction creating the channel it commits to
+func TestCommitTransactionCreateChannel(t *testing.T) {
+	rr := serveTransaction(t, "new-channel", transactionOperation("CREATE", "new-record"), transactionOperation("CREATE", "new-record-2"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+//TestCommitTransactionConflict tests that no record is written when one of them already exists
+func TestCommitTransactionConflict(t *testing.T) {
+	rr := serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), transactionOperation("CREATE", "test-record"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "test-record", res.Error)
+	assert.Equal(t, 0, len(writtenLeaves))
+}
+
+//TestCommitTransactionResourceNotFound tests that no record is written when one of them is missing
+func TestCommitTransactionResourceNotFound(t *testing.T) {
+	rr := serveTransaction(t, "test-channel", transactionOperation("UPDATE", "test-record"), transactionOperation("UPDATE", "random-record"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "random-record", res.Error)
+	assert.Equal(t, 0, len(writtenLeaves))
+}
+
+//TestCommitTransactionInvalid tests transactions that cannot be committed as a whole
+func TestCommitTransactionInvalid(t *testing.T) {
+	rr := serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), transactionOperation("BAD", "test-record"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveTransaction(t, "test-channel", transactionOperation("CREATE", "new-record"), transactionOperation("UPDATE", "new-record"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveTransaction(t, "test-channel")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestCommitTransactionChannelNotFound tests a transaction changing records of a missing channel
+func TestCommitTransactionChannelNotFound(t *testing.T) {
+	rr := serveTransaction(t, "random-channel", transactionOperation("CREATE", "new-record"), transactionOperation("UPDATE", "test-record"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestCommitTransactionErrors tests errors reading the channel and the records of a transaction
+func TestCommitTransactionErrors(t *testing.T) {
+	rr := serveTransaction(t, "error-channel", transactionOperation("UPDATE", "test-record"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTransaction(t, "test-channel", transactionOperation("UPDATE", "error-record"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTransaction(t, "test-channel", transactionOperation("UPDATE", "unverified-record"))
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+
+	rr = serveTransaction(t, "test-channel-bad-map-id", transactionOperation("UPDATE", "test-record"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
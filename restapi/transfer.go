@@ -0,0 +1,222 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/logger"
+	"trillian-agent/models"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+)
+
+var transferLogger = logger.GetLogger("Restapi:Transfer")
+
+// transferRecord moves a record to another channel, creating the target channel if needed. It commits TRANSFER-OUT in the source channel, then TRANSFER-IN in the target channel
+// carrying the source channel, the revision of the TRANSFER-OUT commit and the root hash of the source map at that revision.
+// A transfer that stopped after its TRANSFER-OUT commit is completed by requesting it again
+func transferRecord(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.TransferRecordParams) middleware.Responder {
+	targetID := *params.Body.TargetChannelID
+	if targetID == params.ChannelID {
+		tracing.LogAndTraceErr(transferLogger, span, nil, responses.InvalidTransfer)
+		return responses.ErrTransferInvalidTarget()
+	}
+	source, sourceClient, err := openCommitChannel(ctx, params.ChannelID, false, tracer)
+	if err != nil {
+		return transferChannelError(span, err)
+	}
+	current, res := checkTransferSource(ctx, span, tracer, params, source, sourceClient)
+	if res != nil {
+		return res
+	}
+	target, targetClient, err := openCommitChannel(ctx, targetID, true, tracer)
+	if err != nil {
+		return transferChannelError(span, err)
+	} else if _, res := transferredRecord(span, target, current); res != nil {
+		return res
+	} else if res := checkTransferTarget(ctx, span, tracer, params.RecordID, targetClient); res != nil {
+		return res
+	}
+
+	sourceRevision, res := commitTransferOut(ctx, span, tracer, params, source, sourceClient)
+	if res != nil {
+		return res
+	}
+	link, transferred, err := getTransferSource(ctx, sourceClient, params.ChannelID, params.RecordID, sourceRevision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrTransferVerificationFailed(err)
+		}
+		return responses.ErrTransferInternalServerError(err)
+	} else if transferred == nil {
+		tracing.LogAndTraceErr(transferLogger, span, nil, responses.ResourceNotFound)
+		return responses.ErrTransferResourceNotFound()
+	}
+	transferred, res = transferredRecord(span, target, transferred)
+	if res != nil {
+		return res
+	}
+
+	var targetRevision int64
+	err = commitCoordinator.Stage(ctx, channelCommitKey(targetID), channelMapWriter(targetClient, target.MapID, tracer), [][]byte{dbom.RecordIndex(params.RecordID)}, func(ctx context.Context, batch *dbom.Batch) error {
+		if _, err := checkCommit(ctx, batch, targetClient, target, TRANSFERIN, params.RecordID, tracer); err != nil {
+			tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+			res = transferCommitError(err)
+			return nil
+		}
+		targetRevision = batch.Revision()
+		return transferInRecord(ctx, batch, targetID, TRANSFERIN, link, transferred, tracer)
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return responses.ErrTransferInternalServerError(err)
+	} else if res != nil {
+		return res
+	}
+
+	var success = true
+	var resDef = models.TransferResponseDefinition{Success: &success, SourceRevision: &sourceRevision, TargetRevision: &targetRevision, MapRootHash: link.MapRootHash}
+	var ok = record.TransferRecordOK{Payload: &resDef}
+	transferLogger.Debug().Msgf("%v", ok.Payload)
+	return &ok
+}
+
+// checkTransferSource checks that the record of a transfer can be transferred out of its channel, or was already transferred out to the target channel,
+// before the target channel is created. It returns the latest revision of the record
+func checkTransferSource(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.TransferRecordParams, source *models.Channel, sourceClient *client.MapClient) (*models.Record, middleware.Responder) {
+	current, err := getRecord(ctx, sourceClient, params.RecordID, -1, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return nil, transferCommitError(err)
+	} else if current == nil {
+		tracing.LogAndTraceErr(transferLogger, span, nil, responses.ResourceNotFound)
+		return nil, responses.ErrTransferResourceNotFound()
+	} else if recordState(current) == stateTransferredOut && current.TransferTargetChannelID == *params.Body.TargetChannelID {
+		return current, nil
+	} else if err := checkTransition(source, TRANSFEROUT, params.RecordID, current); err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InvalidTransition)
+		return nil, transferCommitError(err)
+	}
+	return current, nil
+}
+
+// checkTransferTarget checks that the record of a transfer does not exist in the target channel, unless it was transferred out of it, before it is transferred out of its channel,
+// so that a record is not left transferred out without being transferred in
+func checkTransferTarget(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, recordID string, targetClient *client.MapClient) middleware.Responder {
+	existing, err := getRecord(ctx, targetClient, recordID, -1, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return transferCommitError(err)
+	} else if existing != nil && !transfersBackIn(TRANSFERIN, existing) {
+		tracing.LogAndTraceErr(transferLogger, span, nil, responses.ResourceExists)
+		return responses.ErrTransferRecordConflict()
+	}
+	return nil
+}
+
+// transferredRecord validates the record payload of a record transferred to a target channel against the schema of the channel.
+// It returns the record with a payload noting the version of the schema it was validated against, or the response rejecting it.
+// The record payload is validated before the record is transferred out of its channel, and again once the revision transferred out is read
+func transferredRecord(span opentracing.Span, target *models.Channel, transferred *models.Record) (*models.Record, middleware.Responder) {
+	raw, err := json.Marshal(transferred.Payload)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return nil, responses.ErrTransferInternalServerError(err)
+	}
+	var recordDef models.RecordDefinition
+	if err := recordDef.UnmarshalBinary(raw); err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return nil, responses.ErrTransferInternalServerError(err)
+	}
+	schemaVersion, err := checkPayload(target, *transferred.ResourceID, recordDef.RecordIDPayload)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.SchemaViolation)
+		if errors.Is(err, errSchemaViolation) {
+			return nil, responses.ErrTransferSchemaViolation(err, schemaViolations(err))
+		}
+		return nil, responses.ErrTransferInternalServerError(err)
+	}
+	record := *transferred
+	record.Payload = storedRecord(&recordDef, schemaVersion)
+	return &record, nil
+}
+
+// commitTransferOut commits TRANSFER-OUT in the source channel of a transfer and returns its revision.
+// A record already transferred out to the target channel is not committed again, so that the transfer can be completed
+func commitTransferOut(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.TransferRecordParams, source *models.Channel, sourceClient *client.MapClient) (int64, middleware.Responder) {
+	targetID := *params.Body.TargetChannelID
+	current, err := getRecord(ctx, sourceClient, params.RecordID, -1, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return 0, transferCommitError(err)
+	} else if current != nil && recordState(current) == stateTransferredOut && current.TransferTargetChannelID == targetID {
+		transferLogger.Debug().Msgf("Completing transfer of %v to %v from revision %v", params.RecordID, targetID, current.Revision)
+		return current.Revision, nil
+	}
+
+	var revision int64
+	var res middleware.Responder
+	err = commitCoordinator.Stage(ctx, channelCommitKey(params.ChannelID), channelMapWriter(sourceClient, source.MapID, tracer), [][]byte{dbom.RecordIndex(params.RecordID)}, func(ctx context.Context, batch *dbom.Batch) error {
+		if _, err := checkCommit(ctx, batch, sourceClient, source, TRANSFEROUT, params.RecordID, tracer); err != nil {
+			tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+			res = transferCommitError(err)
+			return nil
+		}
+		revision = batch.Revision()
+		return transferOutRecord(ctx, batch, params.ChannelID, TRANSFEROUT, params.RecordID, targetID, tracer)
+	}, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+		return 0, responses.ErrTransferInternalServerError(err)
+	}
+	return revision, res
+}
+
+// transferChannelError maps an error opening the source or target channel of a transfer to its response
+func transferChannelError(span opentracing.Span, err error) middleware.Responder {
+	tracing.LogAndTraceErr(transferLogger, span, err, responses.InternalError)
+	if errors.Is(err, errChannelNotFound) {
+		return responses.ErrTransferChannelNotFound()
+	} else if client.IsVerificationError(err) {
+		return responses.ErrTransferVerificationFailed(err)
+	}
+	return responses.ErrTransferInternalServerError(err)
+}
+
+// transferCommitError maps an error checking the TRANSFER-OUT or TRANSFER-IN commit of a transfer to its response
+func transferCommitError(err error) middleware.Responder {
+	if errors.Is(err, errRecordExists) {
+		return responses.ErrTransferRecordConflict()
+	} else if errors.Is(err, errInvalidTransition) {
+		return responses.ErrTransferInvalidTransition(err)
+	} else if errors.Is(err, errRecordNotFound) {
+		return responses.ErrTransferResourceNotFound()
+	} else if client.IsVerificationError(err) {
+		return responses.ErrTransferVerificationFailed(err)
+	}
+	return responses.ErrTransferInternalServerError(err)
+}
@@ -0,0 +1,347 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	dbom "trillian-agent/dbom"
+	"trillian-agent/mock"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var stagedTransfers []string
+
+// partRecordPayload is the payload of part-record, which validates against the schema of schema-channel
+var partRecordPayload = map[string]interface{}{"recordID": "part-record", "recordIDPayload": map[string]interface{}{"partNumber": "P-1"}, "schemaVersion": 1}
+
+// transferredIn is the record staged by the last TRANSFER-IN commit
+var transferredIn *models.Record
+
+func serveTransfer(t *testing.T, method string, url string, body []byte) *httptest.ResponseRecorder {
+	stagedTransfers = nil
+	transferredIn = nil
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientTransferMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = getChannelTransferMock
+		getRecord = getRecordTransferMock
+		getRecordHistory = GetRecordHistoryMock
+		getRecordHistoryRange = GetRecordHistoryRangeMock
+		createChannel = CreateChannelMock
+		createChannelMap = CreateChannelMapMock
+		deleteChannelMap = DeleteChannelMapMock
+		addLeaves = addLeavesMock
+		transferOutRecord = transferOutRecordMock
+		getTransferSource = getTransferSourceMock
+		transferInRecord = transferInRecordMock
+	}, method, url, nil, body)
+}
+
+func transferBody(targetChannelID string) []byte {
+	body, _ := (&models.TransferDefinition{TargetChannelID: &targetChannelID}).MarshalBinary()
+	return body
+}
+
+//TestTransferRecord tests transferring a record out of its channel and into the target channel
+func TestTransferRecord(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/test-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.TransferResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.True(t, *res.Success)
+	assert.Equal(t, int64(1655), *res.SourceRevision)
+	assert.Equal(t, int64(1655), *res.TargetRevision)
+	assert.Equal(t, []byte("test-root"), []byte(*res.MapRootHash))
+	assert.Equal(t, []string{"TRANSFER-OUT test-channel test-record target-channel", "TRANSFER-IN target-channel test-record test-channel 1655"}, stagedTransfers)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/test-record/transfer", transferBody("new-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+//TestTransferRecordResume tests completing a transfer of a record that is already transferred out to the target channel
+func TestTransferRecordResume(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/transferred-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.TransferResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(5), *res.SourceRevision)
+	assert.Equal(t, []string{"TRANSFER-IN target-channel transferred-record test-channel 5"}, stagedTransfers)
+}
+
+//TestTransferRecordBack tests transferring a record back into a channel it was transferred out of
+func TestTransferRecordBack(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/returned-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"TRANSFER-OUT test-channel returned-record target-channel", "TRANSFER-IN target-channel returned-record test-channel 1655"}, stagedTransfers)
+}
+
+//TestTransferRecordConflict tests transferring a record transferred to another channel, or existing in the target channel, which is not transferred out of its channel
+func TestTransferRecordConflict(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/transferred-record/transfer", transferBody("other-channel"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Contains(t, res.Error, "TRANSFER-OUT is not allowed on transferred-record in state transferred-out")
+	assert.Empty(t, stagedTransfers)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/existing-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Resource Already Exists", *res.Status)
+	assert.Empty(t, stagedTransfers)
+}
+
+//TestTransferParentRecord tests that a parent is not transferred out while it has children, which could not be detached from it anymore
+func TestTransferParentRecord(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/parent-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Contains(t, res.Error, "TRANSFER-OUT is not allowed on parent-record while it has children child-record")
+	assert.Empty(t, stagedTransfers)
+}
+
+//TestTransferRecordSchema tests that the record payload of a transferred record is validated against the schema of the target channel before it is transferred out
+func TestTransferRecordSchema(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/test-record/transfer", transferBody("schema-channel"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Schema Violation", *res.Status)
+	assert.Equal(t, "Schema Violation: record payload of test-record violates version 2 of the schema of its channel", res.Error)
+	assert.Empty(t, stagedTransfers)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/part-record/transfer", transferBody("schema-channel"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []string{"TRANSFER-OUT test-channel part-record schema-channel", "TRANSFER-IN schema-channel part-record test-channel 1655"}, stagedTransfers)
+	assert.Equal(t, int64(2), transferredIn.Payload.(*models.RecordDefinition).SchemaVersion)
+	assert.Equal(t, map[string]interface{}{"partNumber": "P-1"}, transferredIn.Payload.(*models.RecordDefinition).RecordIDPayload)
+}
+
+//TestTransferRecordSourceFirst tests that the target channel is not created for a transfer whose record cannot be transferred out
+func TestTransferRecordSourceFirst(t *testing.T) {
+	for _, recordID := range []string{"random-record", "transferred-record", "parent-record"} {
+		createdChannelMaps = 0
+		rr := serveTransfer(t, "POST", "/channels/test-channel/records/"+recordID+"/transfer", transferBody("new-channel"))
+		assert.NotEqual(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 0, createdChannelMaps)
+		assert.Empty(t, stagedTransfers)
+	}
+}
+
+//TestCommitTransferTypes tests that TRANSFER-OUT and TRANSFER-IN are only committed by transfers
+func TestCommitTransferTypes(t *testing.T) {
+	recordID := "test-record"
+	body, _ := (&models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{}}).MarshalBinary()
+	for _, commitType := range []string{TRANSFEROUT, TRANSFERIN} {
+		rr := serveTransferCommit(t, commitType, body)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		var res models.ErrorResponseDefinition
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		assert.Equal(t, "Invalid Commit Type", *res.Status)
+
+		rr = serveTransaction(t, "test-channel", transactionOperation(commitType, "new-record"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, writtenLeaves)
+	}
+}
+
+//TestTransferRecordInvalid tests transferring a record to its own channel or without a target channel
+func TestTransferRecordInvalid(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/test-channel/records/test-record/transfer", transferBody("test-channel"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/test-record/transfer", transferBody(""))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestTransferRecordErrors tests transferring a missing record and errors reading the channels and the records
+func TestTransferRecordErrors(t *testing.T) {
+	rr := serveTransfer(t, "POST", "/channels/random-channel/records/test-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/random-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/error-record/transfer", transferBody("target-channel"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/test-record/transfer", transferBody("error-channel"))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTransfer(t, "POST", "/channels/test-channel/records/unverified-source/transfer", transferBody("new-channel"))
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+//TestUpdateTransferredRecord tests that a record transferred out of its channel cannot be changed anymore
+func TestUpdateTransferredRecord(t *testing.T) {
+	recordID := "transferred-record"
+	body, _ := (&models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{}}).MarshalBinary()
+	createRecord = CreateRecordMock
+	getLeavesByRevision = getLeavesByRevisionMock
+	rr := serveTransferCommit(t, UPDATE, body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	var res models.ErrorResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, "Invalid Lifecycle Transition", *res.Status)
+	assert.Contains(t, res.Error, "in state transferred-out")
+
+	attachRecord = attachRecordMock
+	body, _ = attachmentRecord("test-record", "test-record", "transferred-record").MarshalBinary()
+	rr = serveTransferCommit(t, ATTACH, body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	update := &models.TransactionOperationDefinition{CommitType: &UPDATEType, Record: &models.RecordDefinition{RecordID: &recordID, RecordIDPayload: map[string]interface{}{}}}
+	body, _ = (&models.TransactionDefinition{Operations: []*models.TransactionOperationDefinition{update}}).MarshalBinary()
+	rr = serveTransfer(t, "POST", "/channels/test-channel/transactions", body)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Contains(t, res.Error, "UPDATE is not allowed on transferred-record")
+}
+
+var UPDATEType = UPDATE
+
+func serveTransferCommit(t *testing.T, commitType string, body []byte) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientTransferMock
+		getCurrentRevision = getCurrentRevisionMock
+		getChannel = getChannelTransferMock
+		getRecord = getRecordTransferMock
+	}, "POST", "/channels/test-channel/records", map[string]string{"commit-type": commitType}, body)
+}
+
+//TestAuditTransferredRecord tests that the audit of a transferred record follows its history back into the source channel
+func TestAuditTransferredRecord(t *testing.T) {
+	rr := serveTransfer(t, "GET", "/channels/target-channel/records/transferred-in/audit", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.AuditResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, 3, len(res.History))
+	assert.Equal(t, "target-channel", *res.History[0].ChannelID)
+	assert.Equal(t, "test-channel", *res.History[0].TransferSource.ChannelID)
+	assert.Equal(t, "test-channel", *res.History[1].ChannelID)
+	assert.Equal(t, "target-channel", res.History[1].TransferTargetChannelID)
+	assert.Equal(t, int64(5), *res.History[1].ID)
+	assert.Equal(t, int64(2), *res.History[2].ID)
+}
+
+//TestAuditTransferredRecordMismatch tests that the audit of a transferred record fails when the source channel does not match the transfer
+func TestAuditTransferredRecordMismatch(t *testing.T) {
+	rr := serveTransfer(t, "GET", "/channels/target-channel/records/tampered-in/audit", nil)
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+
+	rr = serveTransfer(t, "GET", "/channels/target-channel/records/unverified-source/audit", nil)
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+//TestAuditTransferredRecordDeletedSource tests that the audit of a record transferred from a deleted channel ends with its TRANSFER-IN commit
+func TestAuditTransferredRecordDeletedSource(t *testing.T) {
+	rr := serveTransfer(t, "GET", "/channels/target-channel/records/orphan-in/audit", nil)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.AuditResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, 1, len(res.History))
+	assert.Equal(t, "random-channel", *res.History[0].TransferSource.ChannelID)
+}
+
+// getChannelClientTransferMock returns map clients that know the map of their channel, so that records can be read from the source or the target channel of a transfer
+func getChannelClientTransferMock(ctx context.Context, trillAdminClient trillian.TrillianAdminClient, trillMapClient trillian.TrillianMapClient, channelMapID int64, tracer opentracing.Tracer) (*tclient.MapClient, error) {
+	return &tclient.MapClient{MapID: channelMapID, Conn: mock.NewTrillianMapMockClient(nil, false, false, false)}, nil
+}
+
+func getChannelTransferMock(ctx context.Context, client *client.MapClient, channelID string, tracer opentracing.Tracer) (*models.Channel, error) {
+	if channelID == "target-channel" {
+		return &models.Channel{ChannelID: channelID, MapID: 1537}, nil
+	} else if channelID == "schema-channel" {
+		return &models.Channel{ChannelID: channelID, MapID: 1538, Schema: partSchema, SchemaVersion: 2}, nil
+	}
+	return GetChannelMock(ctx, client, channelID, tracer)
+}
+
+// getRecordTransferMock reads records from the map 1536 of the source channel of a transfer and from the map 1537 of target-channel. Other channels hold no records
+func getRecordTransferMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.Record, error) {
+	channelID, eventType := "test-channel", UPDATE
+	record := &models.Record{Revision: 2, AuditDefinition: models.AuditDefinition{ChannelID: &channelID, ResourceID: &recordID, EventType: &eventType, Payload: map[string]interface{}{}}}
+	if recordID == "error-record" {
+		return nil, errors.New("test-error")
+	} else if client.MapID != 1536 {
+		if client.MapID != 1537 {
+			return nil, nil
+		}
+		targetID, transferIn := "target-channel", TRANSFERIN
+		source := &models.TransferSourceDefinition{ChannelID: &channelID, Revision: &[]int64{5}[0], MapRootHash: &[]strfmt.Base64{strfmt.Base64("test-root")}[0]}
+		record.ChannelID, record.EventType, record.Revision, record.TransferSource = &targetID, &transferIn, 3, source
+		switch recordID {
+		case "existing-record":
+			record.TransferSource = nil
+			return record, nil
+		case "transferred-in", "unverified-source":
+			return record, nil
+		case "returned-record":
+			transferOut := TRANSFEROUT
+			record.EventType, record.TransferSource, record.TransferTargetChannelID = &transferOut, nil, "test-channel"
+			return record, nil
+		case "tampered-in":
+			source.MapRootHash = &[]strfmt.Base64{strfmt.Base64("other-root")}[0]
+			return record, nil
+		case "orphan-in":
+			source.ChannelID = &[]string{"random-channel"}[0]
+			return record, nil
+		}
+		return nil, nil
+	}
+	switch recordID {
+	case "transferred-record", "transferred-in":
+		if revision == 2 {
+			return record, nil
+		}
+		transferOut := TRANSFEROUT
+		record.EventType, record.Revision, record.PreviousRevision, record.TransferTargetChannelID = &transferOut, 5, 2, "target-channel"
+		return record, nil
+	case "test-record", "existing-record", "unverified-source", "returned-record":
+		return record, nil
+	case "parent-record":
+		record.ChildRecordIDs = []string{"child-record"}
+		return record, nil
+	case "part-record":
+		record.Payload = partRecordPayload
+		return record, nil
+	}
+	return nil, nil
+}
+
+func transferOutRecordMock(ctx context.Context, batch *dbom.Batch, channelID string, commitType string, recordID string, targetChannelID string, tracer opentracing.Tracer) error {
+	stagedTransfers = append(stagedTransfers, commitType+" "+channelID+" "+recordID+" "+targetChannelID)
+	return nil
+}
+
+func getTransferSourceMock(ctx context.Context, client *client.MapClient, channelID string, recordID string, revision int64, tracer opentracing.Tracer) (*models.TransferSourceDefinition, *models.Record, error) {
+	if recordID == "unverified-source" {
+		return nil, nil, errVerificationMock
+	}
+	mapRootHash := strfmt.Base64("test-root")
+	transferred := &models.Record{AuditDefinition: models.AuditDefinition{ResourceID: &recordID}}
+	if recordID == "part-record" {
+		transferred.Payload = partRecordPayload
+	}
+	return &models.TransferSourceDefinition{ChannelID: &channelID, Revision: &revision, MapRootHash: &mapRootHash}, transferred, nil
+}
+
+func transferInRecordMock(ctx context.Context, batch *dbom.Batch, channelID string, commitType string, source *models.TransferSourceDefinition, transferred *models.Record, tracer opentracing.Tracer) error {
+	stagedTransfers = append(stagedTransfers, commitType+" "+channelID+" "+*transferred.ResourceID+" "+*source.ChannelID+" "+strconv.FormatInt(*source.Revision, 10))
+	transferredIn = transferred
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package restapi
+
+import (
+	"errors"
+	"trillian-agent/logger"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var treeLogger = logger.GetLogger("Restapi:Tree")
+
+// retrieveRecordTree resolves the bill of materials of a record at the requested revision of its channel, or at the latest one
+func retrieveRecordTree(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.RetrieveRecordTreeParams) middleware.Responder {
+	channel, mapClient, err := openCommitChannel(ctx, params.ChannelID, false, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(treeLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrRetrieveTreeChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrRetrieveTreeVerificationFailed(err)
+		}
+		return responses.ErrRetrieveTreeInternalServerError(err)
+	}
+
+	var tree *trillian.Tree
+	if *params.Proofs {
+		tree, err = getChannelTree(ctx, trillianConnection.AdminClient, channel.MapID, tracer)
+		if err != nil {
+			tracing.LogAndTraceErr(treeLogger, span, err, responses.InternalError)
+			return responses.ErrRetrieveTreeInternalServerError(err)
+		}
+	}
+
+	revision := int64(-1)
+	if params.Revision != nil {
+		revision = *params.Revision
+	}
+	result, err := resolveRecordTree(ctx, mapClient, tree, params.ChannelID, params.RecordID, *params.Depth, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(treeLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrRetrieveTreeVerificationFailed(err)
+		} else if status.Code(err) == codes.NotFound {
+			return responses.ErrRetrieveTreeResourceNotFound()
+		}
+		return responses.ErrRetrieveTreeInternalServerError(err)
+	} else if result == nil {
+		tracing.LogAndTraceErr(treeLogger, span, nil, responses.ResourceNotFound)
+		return responses.ErrRetrieveTreeResourceNotFound()
+	}
+
+	var res = record.RetrieveRecordTreeOK{Payload: result}
+	treeLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
@@ -0,0 +1,108 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/google/trillian"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serveTree(t *testing.T, url string) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getChannel = GetChannelMock
+		getChannelTree = getChannelTreeMock
+		resolveRecordTree = resolveRecordTreeMock
+	}, "GET", url, nil, nil)
+}
+
+//TestRetrieveRecordTree tests resolving the tree of a record with the default and explicit parameters
+func TestRetrieveRecordTree(t *testing.T) {
+	rr := serveTree(t, "/channels/test-channel/records/test-record/tree")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.RecordTreeDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1654), *res.Revision)
+	assert.Equal(t, "test-record", *res.Root.RecordID)
+	assert.Equal(t, int64(10), *res.Root.Revision)
+	assert.Nil(t, res.Root.Proof)
+
+	rr = serveTree(t, "/channels/test-channel/records/test-record/tree?depth=2&revision=5&proofs=true")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	res = models.RecordTreeDefinition{}
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(5), *res.Revision)
+	assert.Equal(t, int64(2), *res.Root.Revision)
+	assert.Equal(t, int64(1536), *res.Root.Proof.MapID)
+}
+
+//TestRetrieveRecordTreeInvalid tests resolving the tree of a record with parameters out of range
+func TestRetrieveRecordTreeInvalid(t *testing.T) {
+	rr := serveTree(t, "/channels/test-channel/records/test-record/tree?depth=-1")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel/records/test-record/tree?depth=101")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel/records/test-record/tree?revision=0")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestRetrieveRecordTreeNotFound tests resolving the tree of a missing channel, record or revision
+func TestRetrieveRecordTreeNotFound(t *testing.T) {
+	rr := serveTree(t, "/channels/random-channel/records/test-record/tree")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel/records/random-record/tree")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel/records/test-record/tree?revision=5000")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestRetrieveRecordTreeErrors tests errors reading the channel and resolving the tree of a record
+func TestRetrieveRecordTreeErrors(t *testing.T) {
+	rr := serveTree(t, "/channels/error-channel/records/test-record/tree")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel-bad-map-id/records/test-record/tree?proofs=true")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel/records/error-record/tree")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveTree(t, "/channels/test-channel/records/unverified-record/tree")
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+func resolveRecordTreeMock(ctx context.Context, client *client.MapClient, tree *trillian.Tree, channelID string, recordID string, depth int64, revision int64, tracer opentracing.Tracer) (*models.RecordTreeDefinition, error) {
+	if recordID == "error-record" {
+		return nil, errors.New("test-error")
+	} else if recordID == "unverified-record" {
+		return nil, errVerificationMock
+	} else if recordID != "test-record" {
+		return nil, nil
+	} else if revision > 1654 {
+		return nil, status.Errorf(codes.NotFound, "revision %v not found", revision)
+	}
+	if revision < 0 {
+		revision = 1654
+	}
+	eventType := "CREATE"
+	root := &models.RecordTreeNodeDefinition{RecordID: &recordID, Revision: &depth, EventType: &eventType}
+	if tree != nil {
+		mapID := tree.TreeId
+		root.Proof = &models.ProofBundleDefinition{ChannelID: &channelID, RecordID: &recordID, MapID: &mapID, Revision: &revision}
+	}
+	return &models.RecordTreeDefinition{Revision: &revision, Root: root}, nil
+}
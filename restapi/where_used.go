@@ -0,0 +1,71 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package restapi
+
+import (
+	"errors"
+	"trillian-agent/logger"
+	"trillian-agent/responses"
+	"trillian-agent/restapi/operations/record"
+	"trillian-agent/tracing"
+	client "trillian-agent/trillian"
+
+	"golang.org/x/net/context"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var whereUsedLogger = logger.GetLogger("Restapi:WhereUsed")
+
+// retrieveWhereUsed lists the parents a record is or was attached to at the requested revision of its channel, or at the latest one
+func retrieveWhereUsed(ctx context.Context, span opentracing.Span, tracer opentracing.Tracer, params record.RetrieveWhereUsedParams) middleware.Responder {
+	_, mapClient, err := openCommitChannel(ctx, params.ChannelID, false, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(whereUsedLogger, span, err, responses.InternalError)
+		if errors.Is(err, errChannelNotFound) {
+			return responses.ErrRetrieveWhereUsedChannelNotFound()
+		} else if client.IsVerificationError(err) {
+			return responses.ErrRetrieveWhereUsedVerificationFailed(err)
+		}
+		return responses.ErrRetrieveWhereUsedInternalServerError(err)
+	}
+
+	revision := int64(-1)
+	if params.Revision != nil {
+		revision = *params.Revision
+	}
+	result, err := getWhereUsed(ctx, mapClient, params.RecordID, revision, tracer)
+	if err != nil {
+		tracing.LogAndTraceErr(whereUsedLogger, span, err, responses.InternalError)
+		if client.IsVerificationError(err) {
+			return responses.ErrRetrieveWhereUsedVerificationFailed(err)
+		} else if status.Code(err) == codes.NotFound {
+			return responses.ErrRetrieveWhereUsedResourceNotFound()
+		}
+		return responses.ErrRetrieveWhereUsedInternalServerError(err)
+	} else if result == nil {
+		tracing.LogAndTraceErr(whereUsedLogger, span, nil, responses.ResourceNotFound)
+		return responses.ErrRetrieveWhereUsedResourceNotFound()
+	}
+
+	var res = record.RetrieveWhereUsedOK{Payload: result}
+	whereUsedLogger.Debug().Msgf("%v", res.Payload)
+	return &res
+}
@@ -0,0 +1,91 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"trillian-agent/models"
+	client "trillian-agent/trillian"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func serveWhereUsed(t *testing.T, url string) *httptest.ResponseRecorder {
+	return serveAPI(t, func() {
+		getChannelClient = getChannelClientMock
+		getChannel = GetChannelMock
+		getWhereUsed = getWhereUsedMock
+	}, "GET", url, nil, nil)
+}
+
+//TestRetrieveWhereUsed tests listing the parents of a record at the latest and at a specific revision
+func TestRetrieveWhereUsed(t *testing.T) {
+	rr := serveWhereUsed(t, "/channels/test-channel/records/test-record/where-used")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var res models.WhereUsedResponseDefinition
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(1654), *res.Revision)
+	assert.Equal(t, 2, len(res.Parents))
+	assert.Equal(t, int64(20), res.Parents[0].DetachRevision)
+
+	rr = serveWhereUsed(t, "/channels/test-channel/records/test-record/where-used?revision=15")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	res = models.WhereUsedResponseDefinition{}
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	assert.Equal(t, int64(15), *res.Revision)
+
+	rr = serveWhereUsed(t, "/channels/test-channel/records/test-record/where-used?revision=0")
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+//TestRetrieveWhereUsedNotFound tests listing the parents of a missing channel, record or revision
+func TestRetrieveWhereUsedNotFound(t *testing.T) {
+	rr := serveWhereUsed(t, "/channels/random-channel/records/test-record/where-used")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveWhereUsed(t, "/channels/test-channel/records/random-record/where-used")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = serveWhereUsed(t, "/channels/test-channel/records/test-record/where-used?revision=5000")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+//TestRetrieveWhereUsedErrors tests errors reading the channel and the parents of a record
+func TestRetrieveWhereUsedErrors(t *testing.T) {
+	rr := serveWhereUsed(t, "/channels/error-channel/records/test-record/where-used")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveWhereUsed(t, "/channels/test-channel/records/error-record/where-used")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = serveWhereUsed(t, "/channels/test-channel/records/unverified-record/where-used")
+	assert.Equal(t, http.StatusBadGateway, rr.Code)
+}
+
+func getWhereUsedMock(ctx context.Context, client *client.MapClient, recordID string, revision int64, tracer opentracing.Tracer) (*models.WhereUsedResponseDefinition, error) {
+	if recordID == "error-record" {
+		return nil, errors.New("test-error")
+	} else if recordID == "unverified-record" {
+		return nil, errVerificationMock
+	} else if recordID != "test-record" {
+		return nil, nil
+	} else if revision > 1654 {
+		return nil, status.Errorf(codes.NotFound, "revision %v not found", revision)
+	}
+	if revision < 0 {
+		revision = 1654
+	}
+	parentID, otherID := "test-parent", "other-parent"
+	attached, reattached := int64(12), int64(21)
+	parents := []*models.WhereUsedParentDefinition{
+		{ParentRecordID: &parentID, AttachRevision: &attached, DetachRevision: 20},
+		{ParentRecordID: &otherID, AttachRevision: &reattached},
+	}
+	return &models.WhereUsedResponseDefinition{RecordID: &recordID, Revision: &revision, Parents: parents}, nil
+}
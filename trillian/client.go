@@ -33,6 +33,7 @@ import (
 
 var clientLogger = logger.GetLogger("Trillian:Client")
 var verifySignedMapRoot = tclient.MapVerifier.VerifySignedMapRoot
+var verifyMapLeafInclusion = (*tclient.MapVerifier).VerifyMapLeafInclusionHash
 
 // Client is a type that represents a Trillian Map Write Client
 type Client struct {
@@ -78,75 +79,93 @@ func (c *Client) Add(ctx context.Context, leaves []*trillian.MapLeaf, revision i
 func (c *MapClient) GetByRevision(ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
 	clientLogger.Info().Msg("[Client:GetByRevision] Entered")
 	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "Client:GetByRevision")
-	clientLogger.Debug().Msg("Get Map Leaves")
-	rqst := &trillian.GetMapLeavesByRevisionRequest{
-		MapId:    c.MapID,
-		Index:    indexes,
-		Revision: revision,
-	}
-	resp, err := c.Conn.GetLeavesByRevision(ctx, rqst)
+	inclusions, _, verify, err := c.getVerified(ctx, span, indexes, revision, false)
 	if err != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err, responses.InternalError)
 		return nil, nil, err
 	}
-	clientLogger.Debug().Msg("Get Map Root")
-	rqst2 := &trillian.GetSignedMapRootRequest{
-		MapId: c.MapID,
-	}
-	resp2, err2 := c.Conn.GetSignedMapRoot(ctx, rqst2)
-	if err2 != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err2, responses.InternalError)
-		return nil, nil, err2
-	}
-	clientLogger.Debug().Msg("Verify Map Root")
-	verify, err3 := verifySignedMapRoot(*c.MapVerifier, resp2.GetMapRoot())
-	clientLogger.Debug().Msgf("%v", verify)
-	if err3 != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err3, responses.InternalError)
-		return nil, nil, err3
-	}
 
-	clientLogger.Debug().Msgf("[Client:GetByRevision] %+v", resp)
+	clientLogger.Debug().Msgf("[Client:GetByRevision] %+v", inclusions)
 	clientLogger.Info().Msg("[Client:GetByRevision] Finished")
 	span.Finish()
-	return resp.GetMapLeafInclusion(), verify, nil
+	return inclusions, verify, nil
 }
 
 // Get is a function that gets leaves for the latest revision from a Map
 func (c *MapClient) Get(ctx context.Context, indexes [][]byte, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *types.MapRootV1, error) {
 	clientLogger.Info().Msg("[Client:Get] Entered")
 	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "Client:Get")
-	clientLogger.Debug().Msg("Get Map Leaves")
-	rqst := &trillian.GetMapLeavesRequest{
-		MapId: c.MapID,
-		Index: indexes,
-	}
-	resp, err := c.Conn.GetLeaves(ctx, rqst)
+	inclusions, _, verify, err := c.getVerified(ctx, span, indexes, 0, true)
 	if err != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err, responses.InternalError)
 		return nil, nil, err
 	}
-	clientLogger.Debug().Msg("Get Map Root")
-	rqst2 := &trillian.GetSignedMapRootRequest{
-		MapId: c.MapID,
+
+	clientLogger.Debug().Msgf("[Client:Get] %+v", inclusions)
+	clientLogger.Info().Msg("[Client:Get] Finished")
+	span.Finish()
+	return inclusions, verify, nil
+}
+
+// GetProof is a function that gets leaves from a Map along with the signed map root proving them, at a specific revision or at the latest revision if revision is not positive
+func (c *MapClient) GetProof(ctx context.Context, indexes [][]byte, revision int64, tracer opentracing.Tracer) ([]*trillian.MapLeafInclusion, *trillian.SignedMapRoot, *types.MapRootV1, error) {
+	clientLogger.Info().Msg("[Client:GetProof] Entered")
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, tracer, "Client:GetProof")
+	inclusions, mapRoot, verify, err := c.getVerified(ctx, span, indexes, revision, revision <= 0)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	resp2, err2 := c.Conn.GetSignedMapRoot(ctx, rqst2)
-	if err2 != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err2, responses.InternalError)
-		return nil, nil, err2
+
+	clientLogger.Debug().Msgf("[Client:GetProof] %+v", inclusions)
+	clientLogger.Info().Msg("[Client:GetProof] Finished")
+	span.Finish()
+	return inclusions, mapRoot, verify, nil
+}
+
+// getVerified gets leaves from a Map at a revision, or at the latest revision if latest is set, and verifies the signed map root and the inclusion proof of every leaf.
+// It returns the leaves, the signed map root and the verified map root
+func (c *MapClient) getVerified(ctx context.Context, span opentracing.Span, indexes [][]byte, revision int64, latest bool) ([]*trillian.MapLeafInclusion, *trillian.SignedMapRoot, *types.MapRootV1, error) {
+	clientLogger.Debug().Msg("Get Map Leaves")
+	var resp *trillian.GetMapLeavesResponse
+	var err error
+	if latest {
+		resp, err = c.Conn.GetLeaves(ctx, &trillian.GetMapLeavesRequest{MapId: c.MapID, Index: indexes})
+	} else {
+		resp, err = c.Conn.GetLeavesByRevision(ctx, &trillian.GetMapLeavesByRevisionRequest{MapId: c.MapID, Index: indexes, Revision: revision})
+	}
+	if err != nil {
+		tracing.LogAndTraceErr(clientLogger, span, err, responses.InternalError)
+		return nil, nil, nil, err
+	}
+	mapRoot := resp.GetMapRoot()
+	if mapRoot == nil {
+		clientLogger.Debug().Msg("Get Map Root")
+		var resp2 *trillian.GetSignedMapRootResponse
+		var err2 error
+		if latest {
+			resp2, err2 = c.Conn.GetSignedMapRoot(ctx, &trillian.GetSignedMapRootRequest{MapId: c.MapID})
+		} else {
+			resp2, err2 = c.Conn.GetSignedMapRootByRevision(ctx, &trillian.GetSignedMapRootByRevisionRequest{MapId: c.MapID, Revision: revision})
+		}
+		if err2 != nil {
+			tracing.LogAndTraceErr(clientLogger, span, err2, responses.InternalError)
+			return nil, nil, nil, err2
+		}
+		mapRoot = resp2.GetMapRoot()
 	}
 	clientLogger.Debug().Msg("Verify Map Root")
-	verify, err3 := verifySignedMapRoot(*c.MapVerifier, resp2.GetMapRoot())
+	verify, err3 := verifySignedMapRoot(*c.MapVerifier, mapRoot)
 	clientLogger.Debug().Msgf("%v", verify)
 	if err3 != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err3, responses.InternalError)
-		return nil, nil, err3
+		err3 = rootVerificationError(err3)
+		tracing.LogAndTraceErr(clientLogger, span, err3, responses.VerificationFailed)
+		return nil, nil, nil, err3
 	}
-
-	clientLogger.Debug().Msgf("[Client:Get] %+v", resp)
-	clientLogger.Info().Msg("[Client:Get] Finished")
-	span.Finish()
-	return resp.GetMapLeafInclusion(), verify, nil
+	clientLogger.Debug().Msg("Verify Map Leaves")
+	err4 := verifyInclusions(c.MapVerifier, verify, indexes, resp.GetMapLeafInclusion())
+	if err4 != nil {
+		tracing.LogAndTraceErr(clientLogger, span, err4, responses.VerificationFailed)
+		return nil, nil, nil, err4
+	}
+	return resp.GetMapLeafInclusion(), mapRoot, verify, nil
 }
 
 // GetCurrentRevision gets for the map
@@ -166,7 +185,8 @@ func (c *MapClient) GetCurrentRevision(ctx context.Context, mapID int64, tracer
 	verify, err3 := verifySignedMapRoot(*c.MapVerifier, resp2.GetMapRoot())
 	clientLogger.Debug().Msgf("%v", verify)
 	if err3 != nil {
-		tracing.LogAndTraceErr(clientLogger, span, err3, responses.InternalError)
+		err3 = rootVerificationError(err3)
+		tracing.LogAndTraceErr(clientLogger, span, err3, responses.VerificationFailed)
 		return 0, err3
 	}
 
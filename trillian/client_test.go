@@ -19,6 +19,10 @@ package trillian
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
 	"errors"
 	"testing"
@@ -27,6 +31,9 @@ import (
 
 	"github.com/google/trillian"
 	tclient "github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/maps"
+	"github.com/google/trillian/merkle/hashers"
 	"github.com/google/trillian/types"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -36,6 +43,7 @@ var writeLeavesError = false
 var getLeavesError = false
 var getRootError = false
 var verifyRootError = false
+var verifyInclusionError = false
 
 //TestAdd tests successfully adding to the trillian map
 func TestAdd(t *testing.T) {
@@ -75,6 +83,7 @@ func TestGet(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -99,6 +108,7 @@ func TestGetError(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -124,6 +134,7 @@ func TestGetErrorRoot(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -149,6 +160,7 @@ func TestGetErrorVerify(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -174,6 +186,7 @@ func TestGetByRevision(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -198,6 +211,7 @@ func TestGetByRevisionError(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -223,6 +237,7 @@ func TestGetByRevisionErrorRoot(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -248,6 +263,7 @@ func TestGetByRevisionErrorVerify(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -263,6 +279,191 @@ func TestGetByRevisionErrorVerify(t *testing.T) {
 	assert.Error(t, err)
 }
 
+//TestGetErrorInclusion tests an inclusion proof error while getting from the trillian map
+func TestGetErrorInclusion(t *testing.T) {
+	verifyRootError = false
+	verifyInclusionError = true
+	defer func() { verifyInclusionError = false }()
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	trillMapClient := mock.NewTrillianMapMockClient(conn, false, false, false)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
+
+	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
+	client := MapClient{MapClient: mapClientTree}
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	_, _, err := client.Get(ctx, indexes, tracer)
+	assert.Error(t, err)
+	assert.True(t, IsVerificationError(err))
+}
+
+//TestGetByRevisionErrorInclusion tests an inclusion proof error while getting from the trillian map by revision
+func TestGetByRevisionErrorInclusion(t *testing.T) {
+	verifyRootError = false
+	verifyInclusionError = true
+	defer func() { verifyInclusionError = false }()
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	trillMapClient := mock.NewTrillianMapMockClient(conn, false, false, false)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
+
+	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
+	client := MapClient{MapClient: mapClientTree}
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	_, _, err := client.GetByRevision(ctx, indexes, 1, tracer)
+	assert.Error(t, err)
+	assert.True(t, IsVerificationError(err))
+}
+
+//TestGetProof tests successfully getting leaves and their signed map root from the trillian map
+func TestGetProof(t *testing.T) {
+	verifyRootError = false
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	trillMapClient := mock.NewTrillianMapMockClient(conn, false, false, false)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
+
+	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
+	client := MapClient{MapClient: mapClientTree}
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	for _, revision := range []int64{-1, 1} {
+		inclusions, signedRoot, mapRoot, err := client.GetProof(ctx, indexes, revision, tracer)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(inclusions))
+		assert.NotNil(t, signedRoot)
+		assert.Equal(t, uint64(1), mapRoot.Revision)
+	}
+}
+
+//TestGetProofError tests errors while getting leaves and their signed map root from the trillian map
+func TestGetProofError(t *testing.T) {
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
+	defer func() { verifyRootError = false }()
+
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	for _, revision := range []int64{-1, 1} {
+		verifyRootError = false
+		client := MapClient{MapClient: &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: mock.NewTrillianMapMockClient(conn, true, false, false)}}
+		_, _, _, err := client.GetProof(ctx, indexes, revision, tracer)
+		assert.Error(t, err)
+
+		client = MapClient{MapClient: &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: mock.NewTrillianMapMockClient(conn, false, true, false)}}
+		_, _, _, err = client.GetProof(ctx, indexes, revision, tracer)
+		assert.Error(t, err)
+
+		verifyRootError = true
+		client = MapClient{MapClient: &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: mock.NewTrillianMapMockClient(conn, false, false, false)}}
+		_, _, _, err = client.GetProof(ctx, indexes, revision, tracer)
+		assert.Error(t, err)
+	}
+}
+
+//TestGetProofErrorInclusion tests an inclusion proof error while getting leaves and their signed map root from the trillian map
+func TestGetProofErrorInclusion(t *testing.T) {
+	verifyRootError = false
+	verifyInclusionError = true
+	defer func() { verifyInclusionError = false }()
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	trillMapClient := mock.NewTrillianMapMockClient(conn, false, false, false)
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
+
+	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
+	client := MapClient{MapClient: mapClientTree}
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	_, _, _, err := client.GetProof(ctx, indexes, 1, tracer)
+	assert.True(t, IsVerificationError(err))
+}
+
+//TestVerifyInclusions tests verifying leaves against the root of an empty map
+func TestVerifyInclusions(t *testing.T) {
+	verifyMapLeafInclusion = (*tclient.MapVerifier).VerifyMapLeafInclusionHash
+	mapHasher, err := hashers.NewMapHasher(trillian.HashStrategy_CONIKS_SHA256)
+	assert.Nil(t, err)
+	verifier := &tclient.MapVerifier{MapID: 1, Hasher: mapHasher}
+	mapRoot := &types.MapRootV1{RootHash: mapHasher.HashEmpty(1, make([]byte, mapHasher.Size()), mapHasher.BitLen())}
+
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	proof := make([][]byte, mapHasher.BitLen())
+	absent := []*trillian.MapLeafInclusion{
+		{Leaf: &trillian.MapLeaf{Index: index}, Inclusion: proof},
+	}
+	assert.Nil(t, verifyInclusions(verifier, mapRoot, indexes, absent))
+
+	tampered := []*trillian.MapLeafInclusion{
+		{Leaf: &trillian.MapLeaf{Index: index, LeafValue: []byte("Test")}, Inclusion: proof},
+	}
+	assert.True(t, IsVerificationError(verifyInclusions(verifier, mapRoot, indexes, tampered)))
+}
+
+//TestVerifyInclusionsMismatch tests leaves that do not match the requested indexes
+func TestVerifyInclusionsMismatch(t *testing.T) {
+	verifyMapLeafInclusion = verifyInclusionMock
+	hasher := sha256.New()
+	hasher.Write([]byte("1"))
+	index := hasher.Sum(nil)
+	indexes := [][]byte{
+		index,
+	}
+	other := []*trillian.MapLeafInclusion{
+		{Leaf: &trillian.MapLeaf{Index: []byte("Test")}},
+	}
+	assert.True(t, IsVerificationError(verifyInclusions(&tclient.MapVerifier{}, &types.MapRootV1{}, indexes, other)))
+	assert.True(t, IsVerificationError(verifyInclusions(&tclient.MapVerifier{}, &types.MapRootV1{}, indexes, nil)))
+}
+
 //TestGetRevision tests successfully getting the revision from the trillian map
 func TestGetRevision(t *testing.T) {
 	verifyRootError = false
@@ -273,6 +474,7 @@ func TestGetRevision(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -293,6 +495,7 @@ func TestGetRevisionErrorRoot(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -314,6 +517,7 @@ func TestGetRevisionErrorVerify(t *testing.T) {
 	ctx := context.Background()
 
 	verifySignedMapRoot = verifyMock
+	verifyMapLeafInclusion = verifyInclusionMock
 
 	mapClientTree := &tclient.MapClient{MapVerifier: &tclient.MapVerifier{}, MapID: 1, Conn: trillMapClient}
 	assert.Equal(t, true, true)
@@ -331,3 +535,57 @@ func verifyMock(c tclient.MapVerifier, smr *trillian.SignedMapRoot) (*types.MapR
 	}
 	return &types.MapRootV1{Revision: 1}, nil
 }
+
+func verifyInclusionMock(m *tclient.MapVerifier, rootHash []byte, leafProof *trillian.MapLeafInclusion) error {
+	if verifyInclusionError {
+		return errors.New("Test Error")
+	}
+	return nil
+}
+
+//TestGetTamperedRoot tests that a signed map root which does not match its signature fails verification on every read
+func TestGetTamperedRoot(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	signedRoot, err := tcrypto.NewSigner(1, key, crypto.SHA256).SignMapRoot(&types.MapRootV1{Revision: 1})
+	assert.Nil(t, err)
+	signedRoot.MapRoot[len(signedRoot.MapRoot)-1] ^= 1
+
+	conn, _ := grpc.Dial("localhost:3000", grpc.WithInsecure())
+	defer conn.Close()
+	trillMapClient := tamperedRootClient{TrillianMapClient: mock.NewTrillianMapMockClient(conn, false, false, false), root: signedRoot}
+	tracer, _, _ := tracing.SetupGlobalTracer()
+	ctx := context.Background()
+
+	verifySignedMapRoot = tclient.MapVerifier.VerifySignedMapRoot
+	verifyMapLeafInclusion = verifyInclusionMock
+	defer func() { verifySignedMapRoot = verifyMock }()
+
+	verifier := &tclient.MapVerifier{RootVerifier: &maps.RootVerifier{PubKey: key.Public(), SigHash: crypto.SHA256}, MapID: 1}
+	client := MapClient{MapClient: &tclient.MapClient{MapVerifier: verifier, MapID: 1, Conn: trillMapClient}}
+	indexes := [][]byte{sha256.New().Sum(nil)}
+
+	_, _, err = client.Get(ctx, indexes, tracer)
+	assert.True(t, IsVerificationError(err))
+	_, _, err = client.GetByRevision(ctx, indexes, 1, tracer)
+	assert.True(t, IsVerificationError(err))
+	_, _, _, err = client.GetProof(ctx, indexes, 0, tracer)
+	assert.True(t, IsVerificationError(err))
+	_, err = client.GetCurrentRevision(ctx, 1, tracer)
+	assert.True(t, IsVerificationError(err))
+	assert.Contains(t, err.Error(), "verification of signed map root failed")
+}
+
+// tamperedRootClient serves a fixed signed map root
+type tamperedRootClient struct {
+	trillian.TrillianMapClient
+	root *trillian.SignedMapRoot
+}
+
+func (c tamperedRootClient) GetSignedMapRoot(ctx context.Context, in *trillian.GetSignedMapRootRequest, opts ...grpc.CallOption) (*trillian.GetSignedMapRootResponse, error) {
+	return &trillian.GetSignedMapRootResponse{MapRoot: c.root}, nil
+}
+
+func (c tamperedRootClient) GetSignedMapRootByRevision(ctx context.Context, in *trillian.GetSignedMapRootByRevisionRequest, opts ...grpc.CallOption) (*trillian.GetSignedMapRootResponse, error) {
+	return &trillian.GetSignedMapRootResponse{MapRoot: c.root}, nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package trillian
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+	"trillian-agent/logger"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+var connectionLogger = logger.GetLogger("Trillian:Connection")
+
+// ConnectionConfig is a type that represents the settings of the connection to Trillian
+type ConnectionConfig struct {
+	Endpoint         string
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	BackoffMaxDelay  time.Duration
+	TLS              TLSConfig
+}
+
+// TLSConfig is a type that represents the TLS settings of the connection to Trillian
+type TLSConfig struct {
+	CACertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
+	ServerName     string
+}
+
+// Enabled reports whether any TLS setting has been configured
+func (t TLSConfig) Enabled() bool {
+	return t.CACertFile != "" || t.ClientCertFile != "" || t.ClientKeyFile != "" || t.ServerName != ""
+}
+
+// TransportCredentials is a function that builds the gRPC transport credentials for the TLS settings
+func TransportCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+	if config.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in %v", config.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		if config.ClientCertFile == "" || config.ClientKeyFile == "" {
+			return nil, errors.New("both a client certificate and a client key are required for mutual TLS")
+		}
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// Connection is a type that represents a shared, long-lived gRPC connection to Trillian
+type Connection struct {
+	conn           *grpc.ClientConn
+	AdminClient    trillian.TrillianAdminClient
+	MapClient      trillian.TrillianMapClient
+	MapWriteClient trillian.TrillianMapWriteClient
+}
+
+// NewConnection is a function that dials Trillian once and creates the clients shared by all requests
+func NewConnection(config ConnectionConfig) (*Connection, error) {
+	connectionLogger.Info().Msg("[Connection:NewConnection] Entered")
+	transport := grpc.WithInsecure()
+	if config.TLS.Enabled() {
+		creds, err := TransportCredentials(config.TLS)
+		if err != nil {
+			connectionLogger.Err(err).Msg("Unable to load the trillian TLS configuration")
+			return nil, err
+		}
+		transport = grpc.WithTransportCredentials(creds)
+	}
+	backoffConfig := backoff.DefaultConfig
+	backoffConfig.MaxDelay = config.BackoffMaxDelay
+	conn, err := grpc.Dial(config.Endpoint,
+		transport,
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                config.KeepaliveTime,
+			Timeout:             config.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffConfig}),
+	)
+	if err != nil {
+		connectionLogger.Err(err).Msgf("Unable to dial trillian at %v", config.Endpoint)
+		return nil, err
+	}
+	connectionLogger.Info().Msg("[Connection:NewConnection] Finished")
+	return &Connection{
+		conn:           conn,
+		AdminClient:    trillian.NewTrillianAdminClient(conn),
+		MapClient:      trillian.NewTrillianMapClient(conn),
+		MapWriteClient: trillian.NewTrillianMapWriteClient(conn),
+	}, nil
+}
+
+// CheckConnectivity is a function that waits until the connection is ready or the context is done
+func (c *Connection) CheckConnectivity(ctx context.Context) error {
+	connectionLogger.Info().Msg("[Connection:CheckConnectivity] Entered")
+	for {
+		state := c.conn.GetState()
+		if state == connectivity.Ready {
+			connectionLogger.Info().Msg("[Connection:CheckConnectivity] Finished")
+			return nil
+		}
+		if state == connectivity.Idle {
+			c.conn.Connect()
+		}
+		if !c.conn.WaitForStateChange(ctx, state) {
+			err := fmt.Errorf("trillian connection not ready (%v): %v", state, ctx.Err())
+			connectionLogger.Err(err).Msg("[Connection:CheckConnectivity] Failed")
+			return err
+		}
+	}
+}
+
+// Close is a function that closes the connection to Trillian
+func (c *Connection) Close() error {
+	connectionLogger.Info().Msg("[Connection:Close] Closing trillian connection")
+	return c.conn.Close()
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package trillian
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var testConnectionConfig = ConnectionConfig{
+	KeepaliveTime:    30 * time.Second,
+	KeepaliveTimeout: 10 * time.Second,
+	BackoffMaxDelay:  time.Second,
+}
+
+//TestNewConnection tests successfully connecting to a trillian stand-in
+func TestNewConnection(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	defer server.Stop()
+
+	config := testConnectionConfig
+	config.Endpoint = listener.Addr().String()
+	conn, err := NewConnection(config)
+	assert.Nil(t, err)
+	assert.NotNil(t, conn.AdminClient)
+	assert.NotNil(t, conn.MapClient)
+	assert.NotNil(t, conn.MapWriteClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Nil(t, conn.CheckConnectivity(ctx))
+	assert.Nil(t, conn.Close())
+}
+
+//TestNewConnectionUnreachable tests the connectivity check against an endpoint that is not listening
+func TestNewConnectionUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	endpoint := listener.Addr().String()
+	listener.Close()
+
+	config := testConnectionConfig
+	config.Endpoint = endpoint
+	conn, err := NewConnection(config)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, conn.CheckConnectivity(ctx))
+}
+
+//TestNewConnectionMutualTLS tests connecting to a trillian stand-in that requires client certificates
+func TestNewConnectionMutualTLS(t *testing.T) {
+	pki := newTestPKI(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{pki.serverCert},
+		ClientCAs:    pki.pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	config := testConnectionConfig
+	config.Endpoint = listener.Addr().String()
+	config.TLS = TLSConfig{
+		CACertFile:     pki.caFile,
+		ClientCertFile: pki.clientCertFile,
+		ClientKeyFile:  pki.clientKeyFile,
+		ServerName:     "trillian.test",
+	}
+	conn, err := NewConnection(config)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Nil(t, conn.CheckConnectivity(ctx))
+}
+
+//TestNewConnectionMutualTLSNoClientCert tests that the handshake fails without a client certificate
+func TestNewConnectionMutualTLSNoClientCert(t *testing.T) {
+	pki := newTestPKI(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{pki.serverCert},
+		ClientCAs:    pki.pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	config := testConnectionConfig
+	config.Endpoint = listener.Addr().String()
+	config.TLS = TLSConfig{
+		CACertFile: pki.caFile,
+		ServerName: "trillian.test",
+	}
+	conn, err := NewConnection(config)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	assert.Error(t, conn.CheckConnectivity(ctx))
+}
+
+//TestTransportCredentialsErrors tests invalid TLS configurations
+func TestTransportCredentialsErrors(t *testing.T) {
+	pki := newTestPKI(t)
+	_, err := TransportCredentials(TLSConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+	_, err = TransportCredentials(TLSConfig{CACertFile: pki.clientKeyFile})
+	assert.Error(t, err)
+	_, err = TransportCredentials(TLSConfig{ClientCertFile: pki.clientCertFile})
+	assert.Error(t, err)
+	_, err = NewConnection(ConnectionConfig{Endpoint: "localhost:0", TLS: TLSConfig{ClientKeyFile: pki.clientKeyFile}})
+	assert.Error(t, err)
+	assert.False(t, TLSConfig{}.Enabled())
+}
+
+type testPKI struct {
+	pool           *x509.CertPool
+	serverCert     tls.Certificate
+	caFile         string
+	clientCertFile string
+	clientKeyFile  string
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	dir := t.TempDir()
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.Nil(t, err)
+	caCert, _ := x509.ParseCertificate(caDER)
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	issue := func(serial int64, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		assert.Nil(t, err)
+		keyDER, _ := x509.MarshalECPrivateKey(key)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+	serverCertPEM, serverKeyPEM := issue(2, "trillian.test", x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	assert.Nil(t, err)
+	clientCertPEM, clientKeyPEM := issue(3, "trillian-agent", x509.ExtKeyUsageClientAuth)
+
+	pki := testPKI{
+		pool:           pool,
+		serverCert:     serverCert,
+		caFile:         filepath.Join(dir, "ca.pem"),
+		clientCertFile: filepath.Join(dir, "client.pem"),
+		clientKeyFile:  filepath.Join(dir, "client-key.pem"),
+	}
+	ioutil.WriteFile(pki.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600)
+	ioutil.WriteFile(pki.clientCertFile, clientCertPEM, 0600)
+	ioutil.WriteFile(pki.clientKeyFile, clientKeyPEM, 0600)
+	return pki
+}
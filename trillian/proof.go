@@ -0,0 +1,96 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package trillian
+
+import (
+	"encoding/pem"
+	"fmt"
+	"trillian-agent/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
+	"github.com/google/trillian/types"
+)
+
+// NewProofVerifier creates a map verifier from the tree parameters of a proof bundle, so that it can be checked without trillian.
+// The public key embedded in the bundle is used unless publicKey, in PEM or DER form, is supplied
+func NewProofVerifier(bundle *models.ProofBundleDefinition, publicKey []byte) (*tclient.MapVerifier, error) {
+	if err := bundle.Validate(strfmt.Default); err != nil {
+		return nil, err
+	}
+	hashStrategy, ok := trillian.HashStrategy_value[*bundle.HashStrategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown hash strategy %v", *bundle.HashStrategy)
+	}
+	hashAlgorithm, ok := sigpb.DigitallySigned_HashAlgorithm_value[*bundle.HashAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown hash algorithm %v", *bundle.HashAlgorithm)
+	}
+	signatureAlgorithm, ok := sigpb.DigitallySigned_SignatureAlgorithm_value[*bundle.SignatureAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown signature algorithm %v", *bundle.SignatureAlgorithm)
+	}
+	der := []byte(*bundle.PublicKey)
+	if len(publicKey) > 0 {
+		der = publicKey
+		if block, _ := pem.Decode(publicKey); block != nil {
+			der = block.Bytes
+		}
+	}
+	tree := &trillian.Tree{
+		TreeId:             *bundle.MapID,
+		TreeType:           trillian.TreeType_MAP,
+		HashStrategy:       trillian.HashStrategy(hashStrategy),
+		HashAlgorithm:      sigpb.DigitallySigned_HashAlgorithm(hashAlgorithm),
+		SignatureAlgorithm: sigpb.DigitallySigned_SignatureAlgorithm(signatureAlgorithm),
+		PublicKey:          &keyspb.PublicKey{Der: der},
+	}
+	return tclient.NewMapVerifierFromTree(tree)
+}
+
+// VerifyProofRoot checks the signature of the map root of a proof bundle and that it is the revision the bundle claims
+func VerifyProofRoot(verifier *tclient.MapVerifier, bundle *models.ProofBundleDefinition) (*types.MapRootV1, error) {
+	signedRoot := &trillian.SignedMapRoot{
+		MapRoot:   *bundle.SignedMapRoot.MapRoot,
+		Signature: *bundle.SignedMapRoot.Signature,
+	}
+	mapRoot, err := verifier.VerifySignedMapRoot(signedRoot)
+	if err != nil {
+		return nil, err
+	}
+	if int64(mapRoot.Revision) != *bundle.Revision {
+		return nil, fmt.Errorf("map root is at revision %d, bundle claims revision %d", mapRoot.Revision, *bundle.Revision)
+	}
+	return mapRoot, nil
+}
+
+// VerifyProofLeaf checks that the leaf of a proof bundle is at index and is included in the verified map root
+func VerifyProofLeaf(verifier *tclient.MapVerifier, mapRoot *types.MapRootV1, bundle *models.ProofBundleDefinition, index []byte) error {
+	proof := make([][]byte, len(bundle.Inclusion))
+	for i, hash := range bundle.Inclusion {
+		proof[i] = hash
+	}
+	inclusion := &trillian.MapLeafInclusion{
+		Leaf:      &trillian.MapLeaf{Index: *bundle.LeafIndex, LeafValue: *bundle.LeafValue},
+		Inclusion: proof,
+	}
+	return verifyInclusions(verifier, mapRoot, [][]byte{index}, []*trillian.MapLeafInclusion{inclusion})
+}
@@ -0,0 +1,99 @@
+package trillian
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"trillian-agent/mock"
+
+	"github.com/go-openapi/strfmt"
+	tclient "github.com/google/trillian/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func proofIndex(recordID string) []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte(recordID))
+	return hasher.Sum(nil)
+}
+
+//TestVerifyProof tests verifying a proof bundle with its embedded and with a supplied public key
+func TestVerifyProof(t *testing.T) {
+	verifyMapLeafInclusion = (*tclient.MapVerifier).VerifyMapLeafInclusionHash
+	index := proofIndex("test-record")
+	bundle, err := mock.NewSignedProofBundle(651, "test-channel", "test-record", index, []byte("test-value"), 3)
+	assert.Nil(t, err)
+
+	verifier, err := NewProofVerifier(bundle, nil)
+	assert.Nil(t, err)
+	mapRoot, err := VerifyProofRoot(verifier, bundle)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyProofLeaf(verifier, mapRoot, bundle, index))
+
+	key := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: *bundle.PublicKey})
+	verifier, err = NewProofVerifier(bundle, key)
+	assert.Nil(t, err)
+	_, err = VerifyProofRoot(verifier, bundle)
+	assert.Nil(t, err)
+}
+
+//TestVerifyProofWrongKey tests a proof bundle whose map root was not signed by the supplied public key
+func TestVerifyProofWrongKey(t *testing.T) {
+	bundle, err := mock.NewSignedProofBundle(651, "test-channel", "test-record", proofIndex("test-record"), []byte("test-value"), 3)
+	assert.Nil(t, err)
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(key.Public())
+	verifier, err := NewProofVerifier(bundle, der)
+	assert.Nil(t, err)
+	_, err = VerifyProofRoot(verifier, bundle)
+	assert.Error(t, err)
+
+	revision := int64(4)
+	bundle.Revision = &revision
+	verifier, _ = NewProofVerifier(bundle, nil)
+	_, err = VerifyProofRoot(verifier, bundle)
+	assert.Error(t, err)
+}
+
+//TestVerifyProofTampered tests a proof bundle whose leaf does not match the map root or the record
+func TestVerifyProofTampered(t *testing.T) {
+	verifyMapLeafInclusion = (*tclient.MapVerifier).VerifyMapLeafInclusionHash
+	index := proofIndex("test-record")
+	bundle, err := mock.NewSignedProofBundle(651, "test-channel", "test-record", index, []byte("test-value"), 3)
+	assert.Nil(t, err)
+	verifier, _ := NewProofVerifier(bundle, nil)
+	mapRoot, err := VerifyProofRoot(verifier, bundle)
+	assert.Nil(t, err)
+
+	assert.True(t, IsVerificationError(VerifyProofLeaf(verifier, mapRoot, bundle, proofIndex("other-record"))))
+
+	tampered := strfmt.Base64("tampered-value")
+	bundle.LeafValue = &tampered
+	assert.True(t, IsVerificationError(VerifyProofLeaf(verifier, mapRoot, bundle, index)))
+}
+
+//TestNewProofVerifierError tests proof bundles that do not describe a map verifier
+func TestNewProofVerifierError(t *testing.T) {
+	bundle, err := mock.NewSignedProofBundle(651, "test-channel", "test-record", proofIndex("test-record"), []byte("test-value"), 3)
+	assert.Nil(t, err)
+
+	for _, field := range []*string{bundle.HashStrategy, bundle.HashAlgorithm, bundle.SignatureAlgorithm} {
+		valid := *field
+		*field = "UNKNOWN"
+		_, err = NewProofVerifier(bundle, nil)
+		assert.Error(t, err)
+		*field = valid
+	}
+
+	_, err = NewProofVerifier(bundle, []byte("test-key"))
+	assert.Error(t, err)
+
+	bundle.SignedMapRoot = nil
+	_, err = NewProofVerifier(bundle, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2020 Unisys Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package trillian
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	"github.com/google/trillian/types"
+)
+
+// VerificationError is returned when a leaf served by Trillian cannot be verified against the signed map root, or the signed map root cannot be verified against the map's public key
+type VerificationError struct {
+	Index []byte
+	Root  bool
+	Err   error
+}
+
+// Error returns the verification failure message
+func (e *VerificationError) Error() string {
+	if e.Root {
+		return fmt.Sprintf("verification of signed map root failed: %v", e.Err)
+	}
+	return fmt.Sprintf("verification of map leaf %x failed: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying verification failure
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// IsVerificationError reports whether err is, or wraps, a VerificationError
+func IsVerificationError(err error) bool {
+	var verificationErr *VerificationError
+	return errors.As(err, &verificationErr)
+}
+
+// rootVerificationError wraps a failure verifying the signature of a signed map root
+func rootVerificationError(err error) error {
+	return &VerificationError{Root: true, Err: err}
+}
+
+// verifyInclusions checks that every requested index was returned and that its inclusion, or non-inclusion, proof matches the map root
+func verifyInclusions(verifier *tclient.MapVerifier, mapRoot *types.MapRootV1, indexes [][]byte, inclusions []*trillian.MapLeafInclusion) error {
+	if len(inclusions) != len(indexes) {
+		return &VerificationError{Err: fmt.Errorf("got %d leaves, want %d", len(inclusions), len(indexes))}
+	}
+	for i, inclusion := range inclusions {
+		if !bytes.Equal(inclusion.GetLeaf().GetIndex(), indexes[i]) {
+			return &VerificationError{Index: indexes[i], Err: fmt.Errorf("got leaf index %x", inclusion.GetLeaf().GetIndex())}
+		}
+		if err := verifyMapLeafInclusion(verifier, mapRoot.RootHash, inclusion); err != nil {
+			return &VerificationError{Index: indexes[i], Err: err}
+		}
+	}
+	return nil
+}